@@ -0,0 +1,75 @@
+// Command fakeprovider serves InCrowd compatible article feeds from fixture directories,
+// so the service can run without reaching the real providers.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/KarolosLykos/sportsnews/internal/fakeprovider"
+)
+
+func main() {
+	var (
+		addr     = flag.String("addr", ":8090", "address to listen on")
+		fixtures = flag.String("fixtures", "cmd/fakeprovider/fixtures/hullcity", "comma separated fixture directories, each served under /<directory name>")
+		opts     fakeprovider.Options
+	)
+
+	flag.DurationVar(&opts.Latency, "latency", 0, "latency added to every response")
+	flag.DurationVar(&opts.Jitter, "jitter", 0, "random extra latency up to this duration")
+	flag.Float64Var(&opts.ErrorRate, "error-rate", 0, "share of requests answered with 500")
+	flag.Float64Var(&opts.NotModifiedRate, "not-modified-rate", 0, "share of conditional list requests answered with 304")
+	flag.DurationVar(&opts.Churn, "churn", 0, "interval between random article changes, 0 disables churn")
+	flag.Int64Var(&opts.Seed, "seed", 0, "random seed, 0 uses the current time")
+	flag.Parse()
+
+	stores := make([]*fakeprovider.Store, 0)
+
+	for _, dir := range strings.Split(*fixtures, ",") {
+		store, err := fakeprovider.LoadStore(strings.TrimSpace(dir))
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		log.Printf("serving %s under /%s", dir, store.Name())
+		stores = append(stores, store)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	s := fakeprovider.New(opts, stores...)
+	go s.Run(ctx)
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           s,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			log.Println(err)
+		}
+	}()
+
+	log.Printf("fake provider listening on %s", *addr)
+
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalln(err)
+	}
+}
@@ -8,16 +8,35 @@ import (
 )
 
 type Config struct {
-	Dev      bool `envconfig:"DEV" default:"true"`
-	HTTP     HTTP
-	Logger   Logger
-	MongoDB  MongoConfig
-	Redis    RedisConfig
-	Consumer ConsumerConfig
+	Dev       bool `envconfig:"DEV" default:"true"`
+	HTTP      HTTP
+	GRPC      GRPCConfig
+	Logger    Logger
+	MongoDB   MongoConfig
+	Redis     RedisConfig
+	Scheduler SchedulerConfig
+	Search    SearchConfig
+	Stream    StreamConfig
+	Socket    SocketConfig
+	Webhook   WebhookConfig
+	GraphQL   GraphQLConfig
+	Auth      AuthConfig
+	Consumer  ConsumerConfig
 }
 
+// HTTP configures the server. PublicURL is the address clients reach it at, which feeds link to.
+// The Cache-Control headers of single articles, of article pages and of feeds are left out when empty.
 type HTTP struct {
-	Port string `envconfig:"HTTP_PORT" default:":8081"`
+	Port                string `envconfig:"HTTP_PORT" default:":8081"`
+	PublicURL           string `envconfig:"HTTP_PUBLIC_URL" default:"http://localhost:8081"`
+	ArticleCacheControl string `envconfig:"HTTP_ARTICLE_CACHE_CONTROL" default:"public, max-age=300"`
+	ListCacheControl    string `envconfig:"HTTP_LIST_CACHE_CONTROL" default:"public, max-age=60"`
+	FeedCacheControl    string `envconfig:"HTTP_FEED_CACHE_CONTROL" default:"public, max-age=300"`
+}
+
+// GRPCConfig configures the gRPC server, which runs next to the HTTP server.
+type GRPCConfig struct {
+	Port string `envconfig:"GRPC_PORT" default:":9090"`
 }
 
 type Logger struct {
@@ -39,15 +58,154 @@ type RedisConfig struct {
 	KeyPrefix  string        `envconfig:"REDIS_KEY_PREFIX" default:"articles"`
 }
 
+type SchedulerConfig struct {
+	Timezone string `envconfig:"SCHEDULER_TIMEZONE" default:"UTC"`
+}
+
+// SearchConfig selects the article search engine: the Mongo text index ("mongo") or an
+// embedded index stored in IndexPath ("bleve").
+type SearchConfig struct {
+	Engine    string `envconfig:"SEARCH_ENGINE" default:"mongo"`
+	IndexPath string `envconfig:"SEARCH_INDEX_PATH" default:"data/articles.bleve"`
+	Fuzziness int    `envconfig:"SEARCH_FUZZINESS" default:"1"`
+}
+
+// StreamConfig configures the stream of article events: the number of recent events kept for
+// subscribers that resume, and the time between heartbeats.
+type StreamConfig struct {
+	ReplaySize int64         `envconfig:"STREAM_REPLAY_SIZE" default:"1000"`
+	Heartbeat  time.Duration `envconfig:"STREAM_HEARTBEAT" default:"15s"`
+}
+
+// SocketConfig configures the WebSocket subscriptions: the number of messages a connection may
+// fall behind before it is closed, the number of subscriptions it may hold, the time between pings,
+// how long to wait for a pong or any other message before giving up, and the timeout of a write.
+type SocketConfig struct {
+	SendBuffer       int           `envconfig:"SOCKET_SEND_BUFFER" default:"64"`
+	MaxSubscriptions int           `envconfig:"SOCKET_MAX_SUBSCRIPTIONS" default:"20"`
+	PingInterval     time.Duration `envconfig:"SOCKET_PING_INTERVAL" default:"30s"`
+	PongWait         time.Duration `envconfig:"SOCKET_PONG_WAIT" default:"60s"`
+	WriteWait        time.Duration `envconfig:"SOCKET_WRITE_WAIT" default:"10s"`
+}
+
+// WebhookConfig configures the delivery of article events to webhooks. A delivery is attempted
+// MaxAttempts times, waiting RetryBase before the first retry and twice as long before each next one,
+// at most RetryMax. The deliveries that are due are retried every PollInterval. A webhook is disabled
+// after DisableAfter consecutive failed deliveries. Deliveries are logged for Retention.
+type WebhookConfig struct {
+	Timeout      time.Duration `envconfig:"WEBHOOK_TIMEOUT" default:"10s"`
+	MaxAttempts  int           `envconfig:"WEBHOOK_MAX_ATTEMPTS" default:"5"`
+	RetryBase    time.Duration `envconfig:"WEBHOOK_RETRY_BASE" default:"30s"`
+	RetryMax     time.Duration `envconfig:"WEBHOOK_RETRY_MAX" default:"30m"`
+	PollInterval time.Duration `envconfig:"WEBHOOK_POLL_INTERVAL" default:"5s"`
+	DisableAfter int           `envconfig:"WEBHOOK_DISABLE_AFTER" default:"10"`
+	Retention    time.Duration `envconfig:"WEBHOOK_DELIVERY_RETENTION" default:"720h"`
+}
+
+// GraphQLConfig configures the GraphQL endpoint: the deepest selection a query may nest.
+type GraphQLConfig struct {
+	MaxDepth int `envconfig:"GRAPHQL_MAX_DEPTH" default:"10"`
+}
+
+// AuthConfig configures the authentication of the API. Requests need credentials only when Enabled.
+// Clients authenticate with API keys. RootKey, when set, is a key with every scope and no limits.
+// RateLimit (per minute) and DailyQuota are given to the keys that are created without limits of
+// their own. The daily usage of the keys is kept for UsageRetention.
+//
+// Operators of the admin API authenticate with JWT bearer tokens, signed with JWTSecret (HMAC) or
+// with a key of the JWKS in JWKSFile, and holding their roles in the RolesClaim claim. The issuer
+// and the audience of the tokens are checked when set. Their actions are audited for AuditRetention.
+type AuthConfig struct {
+	Enabled        bool          `envconfig:"AUTH_ENABLED" default:"false"`
+	RootKey        string        `envconfig:"AUTH_ROOT_KEY"`
+	RateLimit      int           `envconfig:"AUTH_RATE_LIMIT" default:"600"`
+	DailyQuota     int           `envconfig:"AUTH_DAILY_QUOTA" default:"100000"`
+	UsageRetention time.Duration `envconfig:"AUTH_USAGE_RETENTION" default:"720h"`
+	JWTSecret      string        `envconfig:"AUTH_JWT_SECRET"`
+	JWKSFile       string        `envconfig:"AUTH_JWKS_FILE"`
+	JWTIssuer      string        `envconfig:"AUTH_JWT_ISSUER"`
+	JWTAudience    string        `envconfig:"AUTH_JWT_AUDIENCE"`
+	RolesClaim     string        `envconfig:"AUTH_JWT_ROLES_CLAIM" default:"roles"`
+	AuditRetention time.Duration `envconfig:"AUTH_AUDIT_RETENTION" default:"8760h"`
+}
+
 type ConsumerConfig struct {
 	HullConsumer HullConsumer
 }
 
 type HullConsumer struct {
-	Frequency time.Duration `envconfig:"HULL_CONSUMER_FREQUENCY" default:"30m"`
-	SingleURL string        `envconfig:"HULL_CONSUMER_SINGLE_URL" default:"https://www.wearehullcity.co.uk/api/incrowd/getnewsarticleinformation"`
-	ListURL   string        `envconfig:"HULL_CONSUMER_LIST_URL" default:"https://www.wearehullcity.co.uk/api/incrowd/getnewlistinformation"`
-	Count     int           `envconfig:"HULL_CONSUMER_COUNT" default:"50"`
+	Frequency      time.Duration `envconfig:"HULL_CONSUMER_FREQUENCY" default:"30m"`
+	Cron           string        `envconfig:"HULL_CONSUMER_CRON"`
+	Jitter         time.Duration `envconfig:"HULL_CONSUMER_JITTER" default:"0s"`
+	QuietHours     string        `envconfig:"HULL_CONSUMER_QUIET_HOURS"`
+	QuietFrequency time.Duration `envconfig:"HULL_CONSUMER_QUIET_FREQUENCY" default:"2h"`
+	Adaptive       bool          `envconfig:"HULL_CONSUMER_ADAPTIVE" default:"false"`
+	MinFrequency   time.Duration `envconfig:"HULL_CONSUMER_MIN_FREQUENCY" default:"5m"`
+	MaxFrequency   time.Duration `envconfig:"HULL_CONSUMER_MAX_FREQUENCY" default:"2h"`
+	AdaptiveWindow int           `envconfig:"HULL_CONSUMER_ADAPTIVE_WINDOW" default:"6"`
+	AdaptiveTarget float64       `envconfig:"HULL_CONSUMER_ADAPTIVE_TARGET" default:"1"`
+	SingleURL      string        `envconfig:"HULL_CONSUMER_SINGLE_URL" default:"https://www.wearehullcity.co.uk/api/incrowd/getnewsarticleinformation"`
+	ListURL        string        `envconfig:"HULL_CONSUMER_LIST_URL" default:"https://www.wearehullcity.co.uk/api/incrowd/getnewlistinformation"`
+	Count          int           `envconfig:"HULL_CONSUMER_COUNT" default:"50"`
+	MaxBodySize    int64         `envconfig:"HULL_CONSUMER_MAX_BODY_SIZE" default:"10485760"`
+	StaleAfter     time.Duration `envconfig:"HULL_CONSUMER_STALE_AFTER" default:"2h"`
+	MaxLag         time.Duration `envconfig:"HULL_CONSUMER_MAX_INGESTION_LAG" default:"6h"`
+	MaxFailures    int           `envconfig:"HULL_CONSUMER_MAX_CONSECUTIVE_FAILURES" default:"3"`
+	MaxErrorRate   float64       `envconfig:"HULL_CONSUMER_MAX_ERROR_RATE" default:"0.5"`
+}
+
+// Schedule describes when a consumer job runs.
+// A non-empty Cron expression takes precedence over Frequency.
+// QuietHours is a daily "HH:MM-HH:MM" window during which the job
+// runs at most once every QuietFrequency.
+// When Adaptive is set the frequency moves between MinFrequency and MaxFrequency,
+// aiming for AdaptiveTarget new or changed articles per run over the last
+// AdaptiveWindow runs.
+type Schedule struct {
+	Cron           string
+	Frequency      time.Duration
+	Jitter         time.Duration
+	QuietHours     string
+	QuietFrequency time.Duration
+	Adaptive       bool
+	MinFrequency   time.Duration
+	MaxFrequency   time.Duration
+	AdaptiveWindow int
+	AdaptiveTarget float64
+}
+
+// Schedule returns the job schedule of the Hull City consumer.
+func (h HullConsumer) Schedule() Schedule {
+	return Schedule{
+		Cron:           h.Cron,
+		Frequency:      h.Frequency,
+		Jitter:         h.Jitter,
+		QuietHours:     h.QuietHours,
+		QuietFrequency: h.QuietFrequency,
+		Adaptive:       h.Adaptive,
+		MinFrequency:   h.MinFrequency,
+		MaxFrequency:   h.MaxFrequency,
+		AdaptiveWindow: h.AdaptiveWindow,
+		AdaptiveTarget: h.AdaptiveTarget,
+	}
+}
+
+// Health holds the thresholds past which a provider is reported as degraded.
+type Health struct {
+	StaleAfter   time.Duration
+	MaxLag       time.Duration
+	MaxFailures  int
+	MaxErrorRate float64
+}
+
+// Health returns the health thresholds of the Hull City consumer.
+func (h HullConsumer) Health() Health {
+	return Health{
+		StaleAfter:   h.StaleAfter,
+		MaxLag:       h.MaxLag,
+		MaxFailures:  h.MaxFailures,
+		MaxErrorRate: h.MaxErrorRate,
+	}
 }
 
 func Parse() (*Config, error) {
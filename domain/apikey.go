@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Scope is what an API key may be used for.
+type Scope string
+
+const (
+	// ScopeRead reads the articles, through the REST API, the feeds, the streams and GraphQL.
+	ScopeRead Scope = "read"
+	// ScopeWebhooks manages the webhooks.
+	ScopeWebhooks Scope = "webhooks"
+	// ScopeEditorial creates, updates and deletes the editorial articles.
+	ScopeEditorial Scope = "editorial"
+	// ScopeAdmin grants every other scope. The admin routes themselves need the token of an operator.
+	ScopeAdmin Scope = "admin"
+)
+
+var (
+	ErrInvalidAPIKeyRequest = errors.New("invalid api key request")
+	// ErrInvalidAPIKey is returned when a key is unknown or revoked.
+	ErrInvalidAPIKey = errors.New("invalid api key")
+)
+
+// APIKey is a key clients of the API authenticate with. Only the hash of the key is stored; the
+// key itself is only shown when it is created, and Prefix recognises it afterwards. RateLimit is
+// the number of requests allowed per minute and DailyQuota per UTC day, unlimited when zero.
+type APIKey struct {
+	ID         string     `json:"id" bson:"_id,omitempty"`
+	Name       string     `json:"name" bson:"name"`
+	Key        string     `json:"key,omitempty" bson:"-"`
+	Prefix     string     `json:"prefix" bson:"prefix"`
+	Hash       string     `json:"-" bson:"hash"`
+	Scopes     []Scope    `json:"scopes" bson:"scopes"`
+	RateLimit  int        `json:"rateLimit" bson:"rateLimit"`
+	DailyQuota int        `json:"dailyQuota" bson:"dailyQuota"`
+	Revoked    bool       `json:"revoked" bson:"revoked"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty" bson:"revokedAt,omitempty"`
+	Created    time.Time  `json:"created" bson:"created"`
+}
+
+// Validate tells whether the key has a name, known scopes and limits that are not negative.
+func (k *APIKey) Validate() error {
+	if k.Name == "" {
+		return fmt.Errorf("%w:name is required", ErrInvalidAPIKeyRequest)
+	}
+
+	if len(k.Scopes) == 0 {
+		return fmt.Errorf("%w:scopes are required", ErrInvalidAPIKeyRequest)
+	}
+
+	for _, s := range k.Scopes {
+		switch s {
+		case ScopeRead, ScopeWebhooks, ScopeEditorial, ScopeAdmin:
+		default:
+			return fmt.Errorf("%w:unknown scope %q", ErrInvalidAPIKeyRequest, s)
+		}
+	}
+
+	if k.RateLimit < 0 || k.DailyQuota < 0 {
+		return fmt.Errorf("%w:rateLimit and dailyQuota must not be negative", ErrInvalidAPIKeyRequest)
+	}
+
+	return nil
+}
+
+// HasScope tells whether the key may be used for the scope.
+func (k *APIKey) HasScope(scope Scope) bool {
+	for _, s := range k.Scopes {
+		if s == scope || s == ScopeAdmin {
+			return true
+		}
+	}
+
+	return false
+}
+
+type APIKeyRest struct {
+	Status string  `json:"status"`
+	Data   *APIKey `json:"data"`
+}
+
+func (k *APIKey) ToRest() *APIKeyRest {
+	return &APIKeyRest{
+		Status: "success",
+		Data:   k,
+	}
+}
+
+type APIKeys []*APIKey
+
+type APIKeysRest struct {
+	Status string  `json:"status"`
+	Data   APIKeys `json:"data"`
+}
+
+func (k APIKeys) ToRest() *APIKeysRest {
+	return &APIKeysRest{
+		Status: "success",
+		Data:   k,
+	}
+}
+
+// RateLimit is the state of the limits of a key after a request. The request is not Allowed when
+// it exceeds either of them. A zero limit is unlimited.
+type RateLimit struct {
+	Allowed        bool
+	Limit          int
+	Remaining      int
+	Reset          time.Time
+	QuotaLimit     int
+	QuotaRemaining int
+	QuotaReset     time.Time
+}
+
+// APIKeyUsage counts the requests made with a key on a UTC day, allowed and rejected.
+type APIKeyUsage struct {
+	Date     string `json:"date"`
+	Requests int64  `json:"requests"`
+	Rejected int64  `json:"rejected"`
+}
+
+type APIKeyUsages []*APIKeyUsage
+
+type APIKeyUsagesRest struct {
+	Status string       `json:"status"`
+	Data   APIKeyUsages `json:"data"`
+}
+
+func (u APIKeyUsages) ToRest() *APIKeyUsagesRest {
+	return &APIKeyUsagesRest{
+		Status: "success",
+		Data:   u,
+	}
+}
@@ -1,32 +1,48 @@
 package domain
 
 import (
+	"encoding/json"
+	"encoding/xml"
 	"time"
 )
 
 type Article struct {
-	ID          string    `json:"id" bson:"_id,omitempty"`
-	ArticleID   string    `json:"articleID" bson:"articleID"`
-	TeamID      string    `json:"teamId" bson:"teamId"`
-	ClubURL     string    `json:"ClubURL" bson:"clubURL,omitempty"`
-	OptaMatchID string    `json:"optaMatchId" bson:"optaMatchId,omitempty"`
-	Title       string    `json:"title" bson:"title"`
-	Type        []string  `json:"type" bson:"type,omitempty"`
-	Teaser      string    `json:"teaser" bson:"teaser,omitempty"`
-	Content     string    `json:"content" bson:"content,omitempty"`
-	URL         string    `json:"url" bson:"url,omitempty"`
-	ImageURL    string    `json:"imageUrl" bson:"imageUrl,omitempty"`
-	GalleryURLs []string  `json:"galleryUrls" bson:"galleryUrls,omitempty"`
-	VideoURL    string    `json:"videoUrl" bson:"videoUrl,omitempty"`
-	BodyText    string    `json:"bodyText" bson:"bodyText,omitempty"`
-	Subtitle    string    `json:"subtitle" bson:"subtitle,omitempty"`
-	IsPublished bool      `json:"isPublished" bson:"isPublished,omitempty"`
-	Published   time.Time `json:"published" bson:"published"`
+	ID          string    `json:"id" bson:"_id,omitempty" xml:"id,omitempty"`
+	ArticleID   string    `json:"articleID" bson:"articleID" xml:"articleID,omitempty"`
+	Provider    string    `json:"provider" bson:"provider,omitempty" xml:"provider,omitempty"`
+	Slug        string    `json:"slug" bson:"slug,omitempty" xml:"slug,omitempty"`
+	TeamID      string    `json:"teamId" bson:"teamId" xml:"teamId,omitempty"`
+	ClubURL     string    `json:"ClubURL" bson:"clubURL,omitempty" xml:"clubUrl,omitempty"`
+	OptaMatchID string    `json:"optaMatchId" bson:"optaMatchId,omitempty" xml:"optaMatchId,omitempty"`
+	Title       string    `json:"title" bson:"title" xml:"title,omitempty"`
+	Type        []string  `json:"type" bson:"type,omitempty" xml:"types>type,omitempty"`
+	Teaser      string    `json:"teaser" bson:"teaser,omitempty" xml:"teaser,omitempty"`
+	Content     string    `json:"content" bson:"content,omitempty" xml:"content,omitempty"`
+	URL         string    `json:"url" bson:"url,omitempty" xml:"url,omitempty"`
+	ImageURL    string    `json:"imageUrl" bson:"imageUrl,omitempty" xml:"imageUrl,omitempty"`
+	GalleryURLs []string  `json:"galleryUrls" bson:"galleryUrls,omitempty" xml:"galleryUrls>url,omitempty"`
+	VideoURL    string    `json:"videoUrl" bson:"videoUrl,omitempty" xml:"videoUrl,omitempty"`
+	BodyText    string    `json:"bodyText" bson:"bodyText,omitempty" xml:"bodyText,omitempty"`
+	Subtitle    string    `json:"subtitle" bson:"subtitle,omitempty" xml:"subtitle,omitempty"`
+	IsPublished bool      `json:"isPublished" bson:"isPublished,omitempty" xml:"isPublished"`
+	Published   time.Time `json:"published" bson:"published" xml:"published"`
+	Updated     time.Time `json:"updated" bson:"updated" xml:"updated"`
+	// Popularity ranks articles of providers that supply one, zero otherwise.
+	Popularity int64 `json:"popularity" bson:"popularity" xml:"popularity"`
+	// Hidden articles were hidden by an operator. They are left out of every read, and provider
+	// syncs do not show them again.
+	Hidden bool `json:"-" bson:"hidden,omitempty" xml:"-"`
 }
 
 type ArticleRest struct {
-	Status string   `json:"status"`
-	Data   *Article `json:"data"`
+	XMLName xml.Name `json:"-" xml:"response"`
+	Status  string   `json:"status" xml:"status"`
+	Data    *Article `json:"data" xml:"article"`
+}
+
+// Visible tells whether subscribers see the article: it is published and was not hidden.
+func (a *Article) Visible() bool {
+	return a.IsPublished && !a.Hidden
 }
 
 func (a *Article) ToRest() *ArticleRest {
@@ -36,25 +52,97 @@ func (a *Article) ToRest() *ArticleRest {
 	}
 }
 
+// Articles is a page of articles. Next and Prev are nil on the last and first page.
 type Articles struct {
 	Total    int64      `json:"total"`
 	Articles []*Article `json:"articles"`
+	Limit    int        `json:"limit"`
+	Offset   int        `json:"offset"`
+	Next     *Cursor    `json:"-"`
+	Prev     *Cursor    `json:"-"`
 }
 
 type ArticlesRest struct {
-	Status   string     `json:"status"`
-	Data     []*Article `json:"data"`
-	Metadata Metadata   `json:"metadata"`
+	XMLName  xml.Name   `json:"-" xml:"response"`
+	Status   string     `json:"status" xml:"status"`
+	Data     []*Article `json:"data" xml:"articles>article"`
+	Metadata Metadata   `json:"metadata" xml:"metadata"`
 }
 
 type Metadata struct {
-	Total int64 `json:"total"`
+	Total      int64  `json:"total" xml:"total"`
+	Limit      int    `json:"limit" xml:"limit"`
+	Offset     int    `json:"offset" xml:"offset"`
+	NextCursor string `json:"nextCursor,omitempty" xml:"nextCursor,omitempty"`
+	PrevCursor string `json:"prevCursor,omitempty" xml:"prevCursor,omitempty"`
+	Links      Links  `json:"links" xml:"links"`
+}
+
+type Links struct {
+	Self string `json:"self" xml:"self"`
+	Next string `json:"next,omitempty" xml:"next,omitempty"`
+	Prev string `json:"prev,omitempty" xml:"prev,omitempty"`
 }
 
+// ToRest returns the response of the page. Links are left to the caller, which knows the request URL.
 func (a *Articles) ToRest() *ArticlesRest {
+	m := Metadata{Total: a.Total, Limit: a.Limit, Offset: a.Offset}
+
+	if a.Next != nil {
+		m.NextCursor = a.Next.Encode()
+	}
+
+	if a.Prev != nil {
+		m.PrevCursor = a.Prev.Encode()
+	}
+
 	return &ArticlesRest{
 		Status:   "success",
 		Data:     a.Articles,
-		Metadata: Metadata{Total: a.Total},
+		Metadata: m,
+	}
+}
+
+type PartialArticlesRest struct {
+	Status   string                       `json:"status"`
+	Data     []map[string]json.RawMessage `json:"data"`
+	Metadata Metadata                     `json:"metadata"`
+}
+
+// ToPartialRest returns the response of the page with the articles restricted to the fields.
+func (a *Articles) ToPartialRest(fields Fields) (*PartialArticlesRest, error) {
+	res := a.ToRest()
+	data := make([]map[string]json.RawMessage, 0, len(a.Articles))
+
+	for _, article := range a.Articles {
+		selected, err := fields.Select(article)
+		if err != nil {
+			return nil, err
+		}
+
+		data = append(data, selected)
+	}
+
+	return &PartialArticlesRest{
+		Status:   res.Status,
+		Data:     data,
+		Metadata: res.Metadata,
+	}, nil
+}
+
+// PurgeResult is the outcome of purging the article cache.
+type PurgeResult struct {
+	Purged int64 `json:"purged"`
+}
+
+type PurgeResultRest struct {
+	Status string       `json:"status"`
+	Data   *PurgeResult `json:"data"`
+}
+
+func (r *PurgeResult) ToRest() *PurgeResultRest {
+	return &PurgeResultRest{
+		Status: "success",
+		Data:   r,
 	}
 }
@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// EditorialProvider is the provider of the articles written in-house. Provider syncs upsert the
+// articles of their own provider only, so they never overwrite editorial articles.
+const EditorialProvider = "editorial"
+
+// EditorialStatus is whether an editorial article is published.
+type EditorialStatus string
+
+const (
+	// EditorialDraft articles are stored unpublished.
+	EditorialDraft EditorialStatus = "draft"
+	// EditorialPublished articles are stored published.
+	EditorialPublished EditorialStatus = "published"
+)
+
+var ErrInvalidEditorialArticle = errors.New("invalid editorial article")
+
+// EditorialArticle is an article as editors write it. Published is when the article was published,
+// the time it is first published when empty.
+type EditorialArticle struct {
+	TeamID      string          `json:"teamId"`
+	Title       string          `json:"title"`
+	Subtitle    string          `json:"subtitle"`
+	Teaser      string          `json:"teaser"`
+	Content     string          `json:"content"`
+	BodyText    string          `json:"bodyText"`
+	Type        []string        `json:"type"`
+	OptaMatchID string          `json:"optaMatchId"`
+	URL         string          `json:"url"`
+	ImageURL    string          `json:"imageUrl"`
+	GalleryURLs []string        `json:"galleryUrls"`
+	VideoURL    string          `json:"videoUrl"`
+	Status      EditorialStatus `json:"status"`
+	Published   *time.Time      `json:"published"`
+}
+
+// Validate tells whether the article has a team, a title, content and a known status, whether its
+// URLs are absolute http(s) URLs, and whether it is not published in the future.
+func (e *EditorialArticle) Validate(now time.Time) error {
+	switch {
+	case e.TeamID == "":
+		return fmt.Errorf("%w:teamId is required", ErrInvalidEditorialArticle)
+	case e.Title == "":
+		return fmt.Errorf("%w:title is required", ErrInvalidEditorialArticle)
+	case e.Content == "":
+		return fmt.Errorf("%w:content is required", ErrInvalidEditorialArticle)
+	}
+
+	switch e.Status {
+	case EditorialDraft, EditorialPublished:
+	default:
+		return fmt.Errorf("%w:status must be %s or %s", ErrInvalidEditorialArticle, EditorialDraft, EditorialPublished)
+	}
+
+	fields, urls := []string{"url", "imageUrl", "videoUrl"}, []string{e.URL, e.ImageURL, e.VideoURL}
+	for i, u := range e.GalleryURLs {
+		fields, urls = append(fields, fmt.Sprintf("galleryUrls[%d]", i)), append(urls, u)
+	}
+
+	for i, u := range urls {
+		if u != "" && !isHTTPURL(u) {
+			return fmt.Errorf("%w:%s must be an absolute http or https URL", ErrInvalidEditorialArticle, fields[i])
+		}
+	}
+
+	if e.Published != nil {
+		if e.Published.IsZero() {
+			return fmt.Errorf("%w:published must be a date", ErrInvalidEditorialArticle)
+		}
+
+		if e.Status == EditorialPublished && e.Published.After(now) {
+			return fmt.Errorf("%w:published must not be in the future", ErrInvalidEditorialArticle)
+		}
+	}
+
+	return nil
+}
+
+// ToArticle returns the article of the editorial provider with the id, published at the time.
+func (e *EditorialArticle) ToArticle(articleID string, published, now time.Time) *Article {
+	return &Article{
+		ArticleID:   articleID,
+		Provider:    EditorialProvider,
+		Slug:        NewSlug(e.Title, articleID),
+		TeamID:      e.TeamID,
+		OptaMatchID: e.OptaMatchID,
+		Title:       e.Title,
+		Type:        e.Type,
+		Teaser:      e.Teaser,
+		Content:     e.Content,
+		URL:         e.URL,
+		ImageURL:    e.ImageURL,
+		GalleryURLs: e.GalleryURLs,
+		VideoURL:    e.VideoURL,
+		BodyText:    e.BodyText,
+		Subtitle:    e.Subtitle,
+		IsPublished: e.Status == EditorialPublished,
+		Published:   published.UTC(),
+		Updated:     now.UTC(),
+	}
+}
+
+func isHTTPURL(s string) bool {
+	u, err := url.Parse(s)
+
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"time"
+)
+
+// EventType is what happened to an article.
+type EventType string
+
+const (
+	EventCreated EventType = "created"
+	EventUpdated EventType = "updated"
+	// EventWithdrawn is an update of an article that is not published, or that was hidden.
+	EventWithdrawn EventType = "withdrawn"
+)
+
+// ArticleEvent is a change to a stored article. IDs increase in the order events are published,
+// across every replica, so that a subscriber can resume after the last event it saw.
+type ArticleEvent struct {
+	ID      int64     `json:"id"`
+	Type    EventType `json:"type"`
+	Time    time.Time `json:"time"`
+	Article *Article  `json:"article"`
+}
+
+// NewArticleEvent returns the event of an upsert of the article, given whether subscribers saw it
+// before: nil when it left the article unchanged, or when subscribers neither saw it nor see it now.
+// An article is created for subscribers once it is first visible, so drafts are never sent.
+func NewArticleEvent(a *Article, change Change, wasVisible bool, now time.Time) *ArticleEvent {
+	e := &ArticleEvent{Time: now, Article: a}
+
+	switch {
+	case change != ChangeCreated && change != ChangeUpdated:
+		return nil
+	case !wasVisible && a.Visible():
+		e.Type = EventCreated
+	case !wasVisible:
+		return nil
+	case !a.Visible():
+		e.Type = EventWithdrawn
+	default:
+		e.Type = EventUpdated
+	}
+
+	return e
+}
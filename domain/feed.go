@@ -0,0 +1,292 @@
+package domain
+
+import (
+	"encoding/xml"
+	"mime"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	atomNS      = "http://www.w3.org/2005/Atom"
+	jsonFeedURL = "https://jsonfeed.org/version/1.1"
+)
+
+// Feed is a syndication feed of articles. Link is the page the feed is about, URL is the feed itself
+// and ArticleURL returns the URL of an article in this API, which identifies it in the feed.
+type Feed struct {
+	Title      string
+	Link       string
+	URL        string
+	Updated    time.Time
+	Articles   []*Article
+	ArticleURL func(a *Article) string
+}
+
+// RSS is an RSS 2.0 document.
+type RSS struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	AtomNS  string     `xml:"xmlns:atom,attr"`
+	Channel RSSChannel `xml:"channel"`
+}
+
+type RSSChannel struct {
+	Title         string     `xml:"title"`
+	Link          string     `xml:"link"`
+	Description   string     `xml:"description"`
+	Self          AtomLink   `xml:"atom:link"`
+	LastBuildDate string     `xml:"lastBuildDate,omitempty"`
+	Items         []*RSSItem `xml:"item"`
+}
+
+type RSSItem struct {
+	Title       string         `xml:"title"`
+	Link        string         `xml:"link,omitempty"`
+	Description string         `xml:"description,omitempty"`
+	GUID        RSSGUID        `xml:"guid"`
+	PubDate     string         `xml:"pubDate"`
+	Categories  []string       `xml:"category"`
+	Enclosures  []RSSEnclosure `xml:"enclosure"`
+}
+
+type RSSGUID struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+}
+
+type RSSEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Length int    `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+// Atom is an Atom 1.0 feed.
+type Atom struct {
+	XMLName xml.Name     `xml:"feed"`
+	NS      string       `xml:"xmlns,attr"`
+	ID      string       `xml:"id"`
+	Title   string       `xml:"title"`
+	Updated string       `xml:"updated"`
+	Links   []AtomLink   `xml:"link"`
+	Entries []*AtomEntry `xml:"entry"`
+}
+
+type AtomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Updated    string         `xml:"updated"`
+	Published  string         `xml:"published"`
+	Links      []AtomLink     `xml:"link"`
+	Categories []AtomCategory `xml:"category"`
+	Summary    *AtomText      `xml:"summary,omitempty"`
+	Content    *AtomText      `xml:"content,omitempty"`
+}
+
+type AtomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type AtomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type AtomText struct {
+	Type  string `xml:"type,attr,omitempty"`
+	Value string `xml:",chardata"`
+}
+
+// JSONFeed is a JSON Feed 1.1 document.
+type JSONFeed struct {
+	Version     string          `json:"version"`
+	Title       string          `json:"title"`
+	HomePageURL string          `json:"home_page_url,omitempty"`
+	FeedURL     string          `json:"feed_url"`
+	Items       []*JSONFeedItem `json:"items"`
+}
+
+type JSONFeedItem struct {
+	ID            string                `json:"id"`
+	URL           string                `json:"url,omitempty"`
+	Title         string                `json:"title"`
+	Summary       string                `json:"summary,omitempty"`
+	ContentHTML   string                `json:"content_html"`
+	Image         string                `json:"image,omitempty"`
+	DatePublished string                `json:"date_published"`
+	DateModified  string                `json:"date_modified,omitempty"`
+	Tags          []string              `json:"tags,omitempty"`
+	Attachments   []*JSONFeedAttachment `json:"attachments,omitempty"`
+}
+
+type JSONFeedAttachment struct {
+	URL      string `json:"url"`
+	MimeType string `json:"mime_type"`
+}
+
+// ToRSS returns the feed as RSS. Images and videos are enclosed with an unknown length, which
+// RSS readers accept as zero.
+func (f *Feed) ToRSS() *RSS {
+	rss := &RSS{
+		Version: "2.0",
+		AtomNS:  atomNS,
+		Channel: RSSChannel{
+			Title:       f.Title,
+			Link:        f.Link,
+			Description: f.Title,
+			Self:        AtomLink{Href: f.URL, Rel: "self", Type: "application/rss+xml"},
+			Items:       make([]*RSSItem, 0, len(f.Articles)),
+		},
+	}
+
+	if !f.Updated.IsZero() {
+		rss.Channel.LastBuildDate = f.Updated.UTC().Format(time.RFC1123Z)
+	}
+
+	for _, a := range f.Articles {
+		item := &RSSItem{
+			Title:       a.Title,
+			Link:        a.URL,
+			Description: summary(a),
+			GUID:        RSSGUID{Value: f.ArticleURL(a)},
+			PubDate:     a.Published.UTC().Format(time.RFC1123Z),
+			Categories:  a.Type,
+		}
+
+		for _, m := range media(a) {
+			item.Enclosures = append(item.Enclosures, RSSEnclosure{URL: m.URL, Type: m.MimeType})
+		}
+
+		rss.Channel.Items = append(rss.Channel.Items, item)
+	}
+
+	return rss
+}
+
+// ToAtom returns the feed as Atom. Entries are identified by their URL in this API.
+func (f *Feed) ToAtom() *Atom {
+	atom := &Atom{
+		NS:      atomNS,
+		ID:      f.URL,
+		Title:   f.Title,
+		Updated: f.Updated.UTC().Format(time.RFC3339),
+		Links: []AtomLink{
+			{Href: f.URL, Rel: "self", Type: "application/atom+xml"},
+			{Href: f.Link, Rel: "alternate"},
+		},
+		Entries: make([]*AtomEntry, 0, len(f.Articles)),
+	}
+
+	for _, a := range f.Articles {
+		entry := &AtomEntry{
+			ID:        f.ArticleURL(a),
+			Title:     a.Title,
+			Updated:   updated(a).UTC().Format(time.RFC3339),
+			Published: a.Published.UTC().Format(time.RFC3339),
+		}
+
+		if a.URL != "" {
+			entry.Links = append(entry.Links, AtomLink{Href: a.URL, Rel: "alternate"})
+		}
+
+		for _, m := range media(a) {
+			entry.Links = append(entry.Links, AtomLink{Href: m.URL, Rel: "enclosure", Type: m.MimeType})
+		}
+
+		for _, t := range a.Type {
+			entry.Categories = append(entry.Categories, AtomCategory{Term: t})
+		}
+
+		if s := summary(a); s != "" {
+			entry.Summary = &AtomText{Type: "text", Value: s}
+		}
+
+		if a.Content != "" {
+			entry.Content = &AtomText{Type: "html", Value: a.Content}
+		}
+
+		atom.Entries = append(atom.Entries, entry)
+	}
+
+	return atom
+}
+
+// ToJSONFeed returns the feed as JSON Feed. The image is the main image of an item and the videos
+// are attached to it.
+func (f *Feed) ToJSONFeed() *JSONFeed {
+	feed := &JSONFeed{
+		Version:     jsonFeedURL,
+		Title:       f.Title,
+		HomePageURL: f.Link,
+		FeedURL:     f.URL,
+		Items:       make([]*JSONFeedItem, 0, len(f.Articles)),
+	}
+
+	for _, a := range f.Articles {
+		item := &JSONFeedItem{
+			ID:            f.ArticleURL(a),
+			URL:           a.URL,
+			Title:         a.Title,
+			Summary:       summary(a),
+			ContentHTML:   a.Content,
+			Image:         a.ImageURL,
+			DatePublished: a.Published.UTC().Format(time.RFC3339),
+			DateModified:  updated(a).UTC().Format(time.RFC3339),
+			Tags:          a.Type,
+		}
+
+		for _, m := range media(a) {
+			if m.URL != a.ImageURL {
+				item.Attachments = append(item.Attachments, m)
+			}
+		}
+
+		feed.Items = append(feed.Items, item)
+	}
+
+	return feed
+}
+
+// summary returns the teaser of the article, or its subtitle when it has none.
+func summary(a *Article) string {
+	if a.Teaser != "" {
+		return a.Teaser
+	}
+
+	return a.Subtitle
+}
+
+// updated returns the time the article was last updated, its publish time when unknown.
+func updated(a *Article) time.Time {
+	if a.Updated.IsZero() {
+		return a.Published
+	}
+
+	return a.Updated
+}
+
+// media returns the image and the video of the article, with their media type guessed from their extension.
+func media(a *Article) []*JSONFeedAttachment {
+	attachments := make([]*JSONFeedAttachment, 0, 2)
+
+	if a.ImageURL != "" {
+		attachments = append(attachments, &JSONFeedAttachment{URL: a.ImageURL, MimeType: mediaType(a.ImageURL, "image/jpeg")})
+	}
+
+	if a.VideoURL != "" {
+		attachments = append(attachments, &JSONFeedAttachment{URL: a.VideoURL, MimeType: mediaType(a.VideoURL, "video/mp4")})
+	}
+
+	return attachments
+}
+
+func mediaType(url, fallback string) string {
+	ext := path.Ext(strings.SplitN(strings.SplitN(url, "?", 2)[0], "#", 2)[0])
+	if t := mime.TypeByExtension(ext); t != "" {
+		return strings.SplitN(t, ";", 2)[0]
+	}
+
+	return fallback
+}
@@ -0,0 +1,174 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const (
+	ViewFull    = "full"
+	ViewSummary = "summary"
+)
+
+var ErrInvalidFields = errors.New("invalid fields")
+
+// articleField is a field of the Article struct.
+type articleField struct {
+	index  int
+	stored string
+}
+
+// articleFields maps the JSON name of each article field to its position in the Article struct
+// and its name in the database. articleFieldNames are the JSON names in the order of the struct.
+var articleFields, articleFieldNames = func() (map[string]articleField, []string) {
+	t := reflect.TypeOf(Article{})
+	fields := make(map[string]articleField, t.NumField())
+	names := make([]string, 0, t.NumField())
+
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+
+		fields[name] = articleField{index: i, stored: strings.Split(t.Field(i).Tag.Get("bson"), ",")[0]}
+		names = append(names, name)
+	}
+
+	return fields, names
+}()
+
+// summaryOmits are the fields left out of the summary view: the bodies and the gallery.
+var summaryOmits = map[string]bool{"content": true, "bodyText": true, "galleryUrls": true}
+
+// Fields are the JSON names of the article fields a response is restricted to.
+// Nil selects every field. The id is always selected.
+type Fields []string
+
+// ParseFields parses a comma separated list of fields or the name of a view. Only one of them may be given.
+// The full view and an empty list select every field.
+func ParseFields(fields, view string) (Fields, error) {
+	switch {
+	case fields != "" && view != "":
+		return nil, fmt.Errorf("%w:fields and view are mutually exclusive", ErrInvalidFields)
+	case view == "" || view == ViewFull:
+	case view == ViewSummary:
+		summary := make(Fields, 0, len(articleFieldNames))
+		for _, name := range articleFieldNames {
+			if !summaryOmits[name] {
+				summary = append(summary, name)
+			}
+		}
+
+		return summary, nil
+	default:
+		return nil, fmt.Errorf("%w:unknown view %q", ErrInvalidFields, view)
+	}
+
+	if fields == "" {
+		return nil, nil
+	}
+
+	selected := Fields{"id"}
+	seen := map[string]bool{"id": true}
+
+	for _, name := range strings.Split(fields, ",") {
+		name = strings.TrimSpace(name)
+		if _, ok := articleFields[name]; !ok {
+			return nil, fmt.Errorf("%w:unknown field %q", ErrInvalidFields, name)
+		}
+
+		if !seen[name] {
+			seen[name] = true
+			selected = append(selected, name)
+		}
+	}
+
+	return selected, nil
+}
+
+// Stored returns the database names of the fields.
+func (f Fields) Stored() []string {
+	stored := make([]string, 0, len(f))
+	for _, name := range f {
+		stored = append(stored, articleFields[name].stored)
+	}
+
+	return stored
+}
+
+// Select returns the JSON of the selected fields of the article.
+func (f Fields) Select(a *Article) (map[string]json.RawMessage, error) {
+	b, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+
+	all := make(map[string]json.RawMessage)
+	if err = json.Unmarshal(b, &all); err != nil {
+		return nil, err
+	}
+
+	selected := make(map[string]json.RawMessage, len(f))
+	for _, name := range f {
+		selected[name] = all[name]
+	}
+
+	return selected, nil
+}
+
+// Names returns the JSON names of the fields, of every article field when f is nil.
+func (f Fields) Names() []string {
+	if f == nil {
+		return articleFieldNames
+	}
+
+	return f
+}
+
+// Keep returns a copy of the article with only the selected fields set.
+func (f Fields) Keep(a *Article) *Article {
+	if f == nil {
+		return a
+	}
+
+	kept := &Article{}
+	src, dst := reflect.ValueOf(a).Elem(), reflect.ValueOf(kept).Elem()
+
+	for _, name := range f {
+		i := articleFields[name].index
+		dst.Field(i).Set(src.Field(i))
+	}
+
+	return kept
+}
+
+// Record returns the selected fields of the article as text, in the order of Names. Lists are
+// joined with "|" and times are formatted as RFC 3339.
+func (f Fields) Record(a *Article) []string {
+	names := f.Names()
+	record := make([]string, 0, len(names))
+	v := reflect.ValueOf(a).Elem()
+
+	for _, name := range names {
+		switch value := v.Field(articleFields[name].index).Interface().(type) {
+		case []string:
+			record = append(record, strings.Join(value, "|"))
+		case time.Time:
+			if value.IsZero() {
+				record = append(record, "")
+				continue
+			}
+
+			record = append(record, value.Format(time.RFC3339))
+		default:
+			record = append(record, fmt.Sprint(value))
+		}
+	}
+
+	return record
+}
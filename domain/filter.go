@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// TypeMatch tells whether an article needs any or all of the requested types.
+type TypeMatch string
+
+const (
+	TypeMatchAny TypeMatch = "any"
+	TypeMatchAll TypeMatch = "all"
+)
+
+var ErrInvalidFilter = errors.New("invalid filter")
+
+// ArticleFilter narrows the article list. Zero fields do not filter.
+type ArticleFilter struct {
+	TeamID    string
+	Types     []string
+	TypeMatch TypeMatch
+	// PublishedSince is inclusive, PublishedUntil is exclusive.
+	PublishedSince *time.Time
+	PublishedUntil *time.Time
+	IsPublished    *bool
+	OptaMatchID    string
+	// OptaMatchIDs selects the articles of any of the matches.
+	OptaMatchIDs []string
+	HasVideo     *bool
+}
+
+// Validate checks that the filter can match articles.
+func (f ArticleFilter) Validate() error {
+	switch f.TypeMatch {
+	case "", TypeMatchAny, TypeMatchAll:
+	default:
+		return fmt.Errorf("%w:typeMatch must be %q or %q", ErrInvalidFilter, TypeMatchAny, TypeMatchAll)
+	}
+
+	if f.PublishedSince != nil && f.PublishedUntil != nil && !f.PublishedSince.Before(*f.PublishedUntil) {
+		return fmt.Errorf("%w:publishedSince must be before publishedUntil", ErrInvalidFilter)
+	}
+
+	return nil
+}
+
+// Matches reports whether the article passes the filter, as the list query would.
+func (f ArticleFilter) Matches(a *Article) bool {
+	switch {
+	case f.TeamID != "" && a.TeamID != f.TeamID,
+		f.OptaMatchID != "" && a.OptaMatchID != f.OptaMatchID,
+		f.PublishedSince != nil && a.Published.Before(*f.PublishedSince),
+		f.PublishedUntil != nil && !a.Published.Before(*f.PublishedUntil),
+		f.IsPublished != nil && a.IsPublished != *f.IsPublished,
+		f.HasVideo != nil && (a.VideoURL != "") != *f.HasVideo,
+		len(f.OptaMatchIDs) > 0 && !contains(f.OptaMatchIDs, a.OptaMatchID):
+		return false
+	}
+
+	if len(f.Types) == 0 {
+		return true
+	}
+
+	types := make(map[string]bool, len(a.Type))
+	for _, t := range a.Type {
+		types[t] = true
+	}
+
+	for _, t := range f.Types {
+		if types[t] && f.TypeMatch != TypeMatchAll {
+			return true
+		}
+
+		if !types[t] && f.TypeMatch == TypeMatchAll {
+			return false
+		}
+	}
+
+	return f.TypeMatch == TypeMatchAll
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
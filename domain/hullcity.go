@@ -4,6 +4,9 @@ import (
 	"time"
 )
 
+// HullCityProvider is the name of the Hull City feed provider.
+const HullCityProvider = "hullcity"
+
 type HullArticles struct {
 	ClubName            string `xml:"ClubName"`
 	ClubWebsiteURL      string `xml:"ClubWebsiteURL"`
@@ -37,8 +40,15 @@ type HullArticleInformation struct {
 func (h *HullArticle) ToDomain(clubName, clubURL, body, subtitle string) *Article {
 	publishedDate, _ := time.Parse("2006-01-02 15:04:05", h.PublishDate)
 
+	updatedDate, err := time.Parse("2006-01-02 15:04:05", h.LastUpdateDate)
+	if err != nil {
+		updatedDate = publishedDate
+	}
+
 	return &Article{
 		ArticleID: h.NewsArticleID,
+		Provider:  HullCityProvider,
+		Slug:      NewSlug(h.Title, h.NewsArticleID),
 		// teamID == clubName ?.
 		TeamID:      clubName,
 		ClubURL:     clubURL,
@@ -54,5 +64,6 @@ func (h *HullArticle) ToDomain(clubName, clubURL, body, subtitle string) *Articl
 		Subtitle:    subtitle,
 		IsPublished: h.IsPublished,
 		Published:   publishedDate,
+		Updated:     updatedDate,
 	}
 }
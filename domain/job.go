@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrUnknownJob = errors.New("unknown job")
+	// ErrJobRunning is returned when a job is run while it is running already.
+	ErrJobRunning = errors.New("job is running")
+)
+
+type Job struct {
+	Name           string     `json:"name"`
+	Schedule       string     `json:"schedule"`
+	Interval       string     `json:"interval,omitempty"`
+	Jitter         string     `json:"jitter,omitempty"`
+	QuietHours     string     `json:"quietHours,omitempty"`
+	QuietFrequency string     `json:"quietFrequency,omitempty"`
+	Quiet          bool       `json:"quiet"`
+	Running        bool       `json:"running"`
+	RunCount       int        `json:"runCount"`
+	LastRun        *time.Time `json:"lastRun"`
+	LastDuration   string     `json:"lastDuration,omitempty"`
+	NextRun        *time.Time `json:"nextRun"`
+
+	LastResult *SyncResult     `json:"lastResult,omitempty"`
+	Adaptive   *AdaptiveStatus `json:"adaptive,omitempty"`
+}
+
+type AdaptiveStatus struct {
+	MinFrequency   string     `json:"minFrequency"`
+	MaxFrequency   string     `json:"maxFrequency"`
+	ChangesPerHour float64    `json:"changesPerHour"`
+	LastDecision   string     `json:"lastDecision,omitempty"`
+	DecidedAt      *time.Time `json:"decidedAt,omitempty"`
+}
+
+type Jobs []*Job
+
+type JobsRest struct {
+	Status string `json:"status"`
+	Data   Jobs   `json:"data"`
+}
+
+func (j Jobs) ToRest() *JobsRest {
+	return &JobsRest{
+		Status: "success",
+		Data:   j,
+	}
+}
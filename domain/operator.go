@@ -0,0 +1,73 @@
+package domain
+
+import "time"
+
+// Role is what an operator of the admin API may do. Each role grants the ones below it.
+type Role string
+
+const (
+	// RoleViewer reads the state of the service: its jobs, providers and health.
+	RoleViewer Role = "viewer"
+	// RoleOperator runs operational actions, such as rebuilding the search index.
+	RoleOperator Role = "operator"
+	// RoleAdmin manages the API keys and reads the audit log.
+	RoleAdmin Role = "admin"
+)
+
+// rank orders the roles, unknown roles last.
+func (r Role) rank() int {
+	switch r {
+	case RoleViewer:
+		return 1
+	case RoleOperator:
+		return 2
+	case RoleAdmin:
+		return 3
+	}
+
+	return 0
+}
+
+// Operator is the caller of the admin API, as told by its token.
+type Operator struct {
+	Subject string
+	Roles   []Role
+}
+
+// Has tells whether one of the roles of the operator grants the role.
+func (o *Operator) Has(role Role) bool {
+	for _, r := range o.Roles {
+		if r.rank() >= role.rank() && r.rank() > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AuditEntry records an action of an operator on the admin API: the method and route, the
+// parameters of the route and the status of the response.
+type AuditEntry struct {
+	ID       string            `json:"id" bson:"_id,omitempty"`
+	Actor    string            `json:"actor" bson:"actor"`
+	Roles    []Role            `json:"roles" bson:"roles"`
+	Action   string            `json:"action" bson:"action"`
+	Params   map[string]string `json:"params,omitempty" bson:"params,omitempty"`
+	Status   int               `json:"status" bson:"status"`
+	RemoteIP string            `json:"remoteIp" bson:"remoteIp"`
+	Time     time.Time         `json:"time" bson:"time"`
+}
+
+type AuditEntries []*AuditEntry
+
+type AuditEntriesRest struct {
+	Status string       `json:"status"`
+	Data   AuditEntries `json:"data"`
+}
+
+func (a AuditEntries) ToRest() *AuditEntriesRest {
+	return &AuditEntriesRest{
+		Status: "success",
+		Data:   a,
+	}
+}
@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// ListParams selects a page of the filtered and sorted articles.
+// A page starts either Offset articles into the list or right after (or before) Cursor.
+// Fields restricts the articles to some of their fields.
+type ListParams struct {
+	Filter ArticleFilter
+	Sort   Sort
+	Limit  int
+	Offset int
+	Cursor *Cursor
+	Fields Fields
+}
+
+// Normalize applies the default sort and page size and caps the page size to the maximum.
+// A cursor is only valid in the sort it was made for, so its sort wins.
+func (p ListParams) Normalize() ListParams {
+	switch {
+	case p.Cursor != nil:
+		p.Sort = p.Cursor.Sort
+	case len(p.Sort) == 0:
+		p.Sort = DefaultSort
+	}
+
+	switch {
+	case p.Limit <= 0:
+		p.Limit = DefaultPageSize
+	case p.Limit > MaxPageSize:
+		p.Limit = MaxPageSize
+	}
+
+	if p.Offset < 0 || p.Cursor != nil {
+		p.Offset = 0
+	}
+
+	return p
+}
+
+// Cursor is a position in a sorted article list: the sort values and id of an article.
+// A Before cursor selects the articles that come before the position instead of after it.
+type Cursor struct {
+	Sort   Sort          `json:"-"`
+	Values []interface{} `json:"v"`
+	ID     string        `json:"id"`
+	Before bool          `json:"b,omitempty"`
+}
+
+// NewCursor returns the position of the article in the list sorted by sort.
+func NewCursor(sort Sort, a *Article, before bool) *Cursor {
+	return &Cursor{Sort: sort, Values: sort.Values(a), ID: a.ID, Before: before}
+}
+
+// cursorJSON is the encoded form of a cursor, which carries its sort.
+type cursorJSON struct {
+	Sort string `json:"s"`
+	*Cursor
+}
+
+// Encode returns the opaque form of the cursor.
+func (c *Cursor) Encode() string {
+	b, _ := json.Marshal(cursorJSON{Sort: c.Sort.String(), Cursor: c}) //nolint:errchkjson // a cursor always marshals.
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeCursor parses a cursor returned by Encode.
+func DecodeCursor(s string) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrInvalidCursor, err)
+	}
+
+	c := cursorJSON{Cursor: &Cursor{}}
+	if err = json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrInvalidCursor, err)
+	}
+
+	if c.ID == "" {
+		return nil, fmt.Errorf("%w:missing id", ErrInvalidCursor)
+	}
+
+	if c.Cursor.Sort, err = ParseSort(c.Sort); err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrInvalidCursor, err)
+	}
+
+	if c.Values, err = c.Cursor.Sort.parseValues(c.Values); err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrInvalidCursor, err)
+	}
+
+	return c.Cursor, nil
+}
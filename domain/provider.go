@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	ProviderUnknown  = "unknown"
+	ProviderHealthy  = "healthy"
+	ProviderDegraded = "degraded"
+)
+
+type ProviderHealth struct {
+	Provider            string        `json:"provider"`
+	Status              string        `json:"status"`
+	Reasons             []string      `json:"reasons,omitempty"`
+	LastSuccess         *time.Time    `json:"lastSuccess"`
+	LastFailure         *time.Time    `json:"lastFailure"`
+	LastError           string        `json:"lastError,omitempty"`
+	ConsecutiveFailures int           `json:"consecutiveFailures"`
+	Requests            int           `json:"requests"`
+	ErrorRate           float64       `json:"errorRate"`
+	IngestionLag        *IngestionLag `json:"ingestionLag,omitempty"`
+}
+
+// IngestionLag is the time between an article's publish date and when it was stored.
+type IngestionLag struct {
+	Articles int    `json:"articles"`
+	Last     string `json:"last"`
+	Average  string `json:"average"`
+	Max      string `json:"max"`
+}
+
+type ProviderHealths []*ProviderHealth
+
+type ProviderHealthsRest struct {
+	Status string          `json:"status"`
+	Data   ProviderHealths `json:"data"`
+}
+
+func (p ProviderHealths) ToRest() *ProviderHealthsRest {
+	return &ProviderHealthsRest{
+		Status: "success",
+		Data:   p,
+	}
+}
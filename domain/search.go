@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const maxQueryLength = 256
+
+var ErrInvalidQuery = errors.New("invalid query")
+
+// SearchParams selects a page of the articles matching Query, most relevant first.
+// Fuzzy also matches words a few typos away from the query, where the engine supports it.
+type SearchParams struct {
+	Query  string
+	Filter ArticleFilter
+	Fuzzy  bool
+	Limit  int
+	Offset int
+}
+
+// Validate checks the query and the filter.
+func (p SearchParams) Validate() error {
+	switch q := strings.TrimSpace(p.Query); {
+	case q == "":
+		return fmt.Errorf("%w:q is required", ErrInvalidQuery)
+	case len(q) > maxQueryLength:
+		return fmt.Errorf("%w:q is longer than %d bytes", ErrInvalidQuery, maxQueryLength)
+	}
+
+	return p.Filter.Validate()
+}
+
+// Normalize applies the default page size and caps it to the maximum.
+func (p SearchParams) Normalize() SearchParams {
+	list := ListParams{Limit: p.Limit, Offset: p.Offset}.Normalize()
+	p.Limit, p.Offset = list.Limit, list.Offset
+	p.Query = strings.TrimSpace(p.Query)
+
+	return p
+}
+
+// SearchHit is an article matching a search, with its relevance score and the fragments of
+// its fields that match, keyed by field. Matches are wrapped in <mark> tags and the rest
+// of a fragment is HTML escaped.
+type SearchHit struct {
+	*Article
+	Score      float64             `json:"score"`
+	Highlights map[string][]string `json:"highlights,omitempty"`
+}
+
+// SearchResults is a page of search hits. Facets count the matching articles by team and type,
+// where the engine supports it.
+type SearchResults struct {
+	Total  int64             `json:"total"`
+	Hits   []*SearchHit      `json:"hits"`
+	Limit  int               `json:"limit"`
+	Offset int               `json:"offset"`
+	Facets map[string]Facets `json:"facets,omitempty"`
+}
+
+type Facet struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+type Facets []*Facet
+
+type SearchResultsRest struct {
+	Status   string         `json:"status"`
+	Data     []*SearchHit   `json:"data"`
+	Metadata SearchMetadata `json:"metadata"`
+}
+
+type SearchMetadata struct {
+	Total  int64             `json:"total"`
+	Limit  int               `json:"limit"`
+	Offset int               `json:"offset"`
+	Facets map[string]Facets `json:"facets,omitempty"`
+}
+
+func (r *SearchResults) ToRest() *SearchResultsRest {
+	return &SearchResultsRest{
+		Status:   "success",
+		Data:     r.Hits,
+		Metadata: SearchMetadata{Total: r.Total, Limit: r.Limit, Offset: r.Offset, Facets: r.Facets},
+	}
+}
+
+// ReindexResult is the outcome of rebuilding a search index from the database.
+// Removed is the number of articles that were no longer in the database.
+type ReindexResult struct {
+	Indexed int    `json:"indexed"`
+	Removed int    `json:"removed"`
+	Took    string `json:"took"`
+}
+
+type ReindexResultRest struct {
+	Status string         `json:"status"`
+	Data   *ReindexResult `json:"data"`
+}
+
+func (r *ReindexResult) ToRest() *ReindexResultRest {
+	return &ReindexResultRest{
+		Status: "success",
+		Data:   r,
+	}
+}
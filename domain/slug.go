@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// maxSlugWords keeps slugs of long titles readable.
+const maxSlugWords = 10
+
+// NewSlug returns the URL slug of an article: the words of its title in lowercase ASCII, joined
+// by hyphens and followed by its provider id, which keeps slugs of equal titles unique.
+func NewSlug(title, articleID string) string {
+	stripAccents := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+
+	ascii, _, err := transform.String(stripAccents, strings.ToLower(title))
+	if err != nil {
+		ascii = strings.ToLower(title)
+	}
+
+	words := strings.FieldsFunc(ascii, func(r rune) bool {
+		return !('a' <= r && r <= 'z' || '0' <= r && r <= '9')
+	})
+
+	if len(words) > maxSlugWords {
+		words = words[:maxSlugWords]
+	}
+
+	return strings.Join(append(words, strings.ToLower(articleID)), "-")
+}
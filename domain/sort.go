@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const maxSortFields = 4
+
+var ErrInvalidSort = errors.New("invalid sort")
+
+// sortable are the fields the article list can be sorted by, with the value of an article
+// and the parser of a value read back from a cursor.
+var sortable = map[string]struct {
+	value func(a *Article) interface{}
+	parse func(v interface{}) (interface{}, bool)
+}{
+	"published":  {value: func(a *Article) interface{} { return a.Published }, parse: parseTime},
+	"updated":    {value: func(a *Article) interface{} { return a.Updated }, parse: parseTime},
+	"title":      {value: func(a *Article) interface{} { return a.Title }, parse: parseString},
+	"popularity": {value: func(a *Article) interface{} { return a.Popularity }, parse: parseInt},
+}
+
+// DefaultSort lists the newest articles first.
+var DefaultSort = Sort{{Field: "published", Desc: true}}
+
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// Sort is the order of the article list. Articles that are equal on every field are
+// ordered by id, in the direction of the last field, so that the order is stable.
+type Sort []SortField
+
+// ParseSort parses a comma separated list of fields, each prefixed with - for a descending order.
+// An empty list is the DefaultSort.
+func ParseSort(s string) (Sort, error) {
+	if s == "" {
+		return DefaultSort, nil
+	}
+
+	fields := strings.Split(s, ",")
+	if len(fields) > maxSortFields {
+		return nil, fmt.Errorf("%w:at most %d fields", ErrInvalidSort, maxSortFields)
+	}
+
+	sort := make(Sort, 0, len(fields))
+	seen := make(map[string]bool, len(fields))
+
+	for _, f := range fields {
+		f = strings.TrimSpace(f)
+
+		field := SortField{Field: strings.TrimLeft(f, "+-"), Desc: strings.HasPrefix(f, "-")}
+		if _, ok := sortable[field.Field]; !ok {
+			return nil, fmt.Errorf("%w:unknown field %q", ErrInvalidSort, field.Field)
+		}
+
+		if seen[field.Field] {
+			return nil, fmt.Errorf("%w:duplicate field %q", ErrInvalidSort, field.Field)
+		}
+
+		seen[field.Field] = true
+		sort = append(sort, field)
+	}
+
+	return sort, nil
+}
+
+// String returns the form parsed by ParseSort.
+func (s Sort) String() string {
+	fields := make([]string, 0, len(s))
+	for _, f := range s {
+		if f.Desc {
+			fields = append(fields, "-"+f.Field)
+			continue
+		}
+
+		fields = append(fields, f.Field)
+	}
+
+	return strings.Join(fields, ",")
+}
+
+// Values returns the values of the article for each field of the sort.
+func (s Sort) Values(a *Article) []interface{} {
+	values := make([]interface{}, 0, len(s))
+	for _, f := range s {
+		values = append(values, sortable[f.Field].value(a))
+	}
+
+	return values
+}
+
+// parseValues converts values read back from JSON to the types of the sort fields.
+func (s Sort) parseValues(values []interface{}) ([]interface{}, error) {
+	if len(values) != len(s) {
+		return nil, errors.New("values do not match the sort")
+	}
+
+	parsed := make([]interface{}, 0, len(values))
+	for i, f := range s {
+		v, ok := sortable[f.Field].parse(values[i])
+		if !ok {
+			return nil, fmt.Errorf("invalid %s value", f.Field)
+		}
+
+		parsed = append(parsed, v)
+	}
+
+	return parsed, nil
+}
+
+func parseTime(v interface{}) (interface{}, bool) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, false
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, s)
+
+	return t, err == nil
+}
+
+func parseString(v interface{}) (interface{}, bool) {
+	s, ok := v.(string)
+	return s, ok
+}
+
+func parseInt(v interface{}) (interface{}, bool) {
+	f, ok := v.(float64)
+	return int64(f), ok
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"time"
+)
+
+// Change describes what an upsert did to a stored article.
+type Change string
+
+const (
+	ChangeCreated   Change = "created"
+	ChangeUpdated   Change = "updated"
+	ChangeUnchanged Change = "unchanged"
+)
+
+// SyncResult summarises a single consumer run.
+type SyncResult struct {
+	Provider  string        `json:"provider"`
+	Started   time.Time     `json:"started"`
+	Duration  time.Duration `json:"-"`
+	Created   int           `json:"created"`
+	Updated   int           `json:"updated"`
+	Unchanged int           `json:"unchanged"`
+	Failed    int           `json:"failed"`
+}
+
+// Add records the outcome of a single article upsert.
+func (s *SyncResult) Add(change Change) {
+	switch change {
+	case ChangeCreated:
+		s.Created++
+	case ChangeUpdated:
+		s.Updated++
+	case ChangeUnchanged:
+		s.Unchanged++
+	}
+}
+
+// Changed returns the number of new or changed articles.
+func (s *SyncResult) Changed() int {
+	return s.Created + s.Updated
+}
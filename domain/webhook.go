@@ -0,0 +1,220 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryFailed    = "failed"
+)
+
+var (
+	ErrInvalidWebhook = errors.New("invalid webhook")
+	// ErrDeliveryExists is returned when the delivery of an event to a webhook was started already,
+	// by this replica or by another one.
+	ErrDeliveryExists = errors.New("delivery exists")
+)
+
+// Webhook is a URL that article events are posted to. Events selects the types of the events,
+// every type when empty, and TeamID the team of their articles, every team when empty. The secret
+// signs the deliveries. It is only shown when the webhook is created. Owner is the id of the API
+// key that created the webhook, empty when it was created with the root key or without
+// authentication; keys only manage the webhooks they own.
+type Webhook struct {
+	ID                  string      `json:"id" bson:"_id,omitempty"`
+	Owner               string      `json:"owner,omitempty" bson:"owner,omitempty"`
+	URL                 string      `json:"url" bson:"url"`
+	Events              []EventType `json:"events" bson:"events"`
+	TeamID              string      `json:"teamId,omitempty" bson:"teamId,omitempty"`
+	Secret              string      `json:"secret,omitempty" bson:"secret"`
+	Disabled            bool        `json:"disabled" bson:"disabled"`
+	DisabledAt          *time.Time  `json:"disabledAt,omitempty" bson:"disabledAt,omitempty"`
+	ConsecutiveFailures int         `json:"consecutiveFailures" bson:"consecutiveFailures"`
+	Created             time.Time   `json:"created" bson:"created"`
+}
+
+// nonPublicNetworks are the networks that are not reachable on the internet, beyond the loopback,
+// private, link-local, multicast and unspecified addresses: the shared address space, the IETF
+// protocol assignments, the benchmarking, reserved and broadcast addresses, and the NAT64 prefixes.
+var nonPublicNetworks = parseCIDRs(
+	"0.0.0.0/8",
+	"100.64.0.0/10",
+	"192.0.0.0/24",
+	"198.18.0.0/15",
+	"240.0.0.0/4",
+	"64:ff9b::/96",
+	"64:ff9b:1::/48",
+)
+
+// Validate tells whether the webhook has an absolute http(s) URL of a public host and known event
+// types. Hosts that are addresses must be public addresses; names must be fully qualified and not
+// local. The addresses names resolve to are checked when deliveries connect.
+func (w *Webhook) Validate() error {
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w:url must be an absolute http or https URL", ErrInvalidWebhook)
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if ip := net.ParseIP(host); ip != nil {
+		if !PublicIP(ip) {
+			return fmt.Errorf("%w:url must not point to a private address", ErrInvalidWebhook)
+		}
+	} else if !strings.Contains(host, ".") || host == "localhost" || hasSuffix(host, ".localhost", ".local", ".internal") {
+		return fmt.Errorf("%w:url must point to a public host", ErrInvalidWebhook)
+	}
+
+	for _, e := range w.Events {
+		switch e {
+		case EventCreated, EventUpdated, EventWithdrawn:
+		default:
+			return fmt.Errorf("%w:unknown event %q", ErrInvalidWebhook, e)
+		}
+	}
+
+	return nil
+}
+
+// PublicIP tells whether the address is reachable on the internet, which webhooks are delivered to.
+func PublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsMulticast() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+		return false
+	}
+
+	for _, n := range nonPublicNetworks {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func parseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, c := range cidrs {
+		_, n, err := net.ParseCIDR(c)
+		if err != nil {
+			panic(err)
+		}
+
+		networks = append(networks, n)
+	}
+
+	return networks
+}
+
+func hasSuffix(s string, suffixes ...string) bool {
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(s, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Matches tells whether the event is delivered to the webhook.
+func (w *Webhook) Matches(e *ArticleEvent) bool {
+	if w.TeamID != "" && e.Article.TeamID != w.TeamID {
+		return false
+	}
+
+	if len(w.Events) == 0 {
+		return true
+	}
+
+	for _, t := range w.Events {
+		if t == e.Type {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Redacted returns a copy of the webhook without its secret.
+func (w *Webhook) Redacted() *Webhook {
+	redacted := *w
+	redacted.Secret = ""
+
+	return &redacted
+}
+
+type WebhookRest struct {
+	Status string   `json:"status"`
+	Data   *Webhook `json:"data"`
+}
+
+func (w *Webhook) ToRest() *WebhookRest {
+	return &WebhookRest{
+		Status: "success",
+		Data:   w,
+	}
+}
+
+type Webhooks []*Webhook
+
+type WebhooksRest struct {
+	Status string   `json:"status"`
+	Data   Webhooks `json:"data"`
+}
+
+// ToRest returns the response of the webhooks, without their secrets.
+func (w Webhooks) ToRest() *WebhooksRest {
+	data := make(Webhooks, 0, len(w))
+	for _, hook := range w {
+		data = append(data, hook.Redacted())
+	}
+
+	return &WebhooksRest{
+		Status: "success",
+		Data:   data,
+	}
+}
+
+// WebhookDelivery is the delivery of an event to a webhook, with each of its attempts. Pending
+// deliveries keep the body they post and when their next attempt is due, so that any replica can
+// retry them.
+type WebhookDelivery struct {
+	ID          string            `json:"id" bson:"_id,omitempty"`
+	WebhookID   string            `json:"webhookId" bson:"webhookId"`
+	EventID     int64             `json:"eventId" bson:"eventId"`
+	EventType   EventType         `json:"eventType" bson:"eventType"`
+	ArticleID   string            `json:"articleId" bson:"articleId"`
+	Status      string            `json:"status" bson:"status"`
+	Attempts    []*WebhookAttempt `json:"attempts" bson:"attempts"`
+	NextAttempt *time.Time        `json:"nextAttempt,omitempty" bson:"nextAttempt,omitempty"`
+	Body        []byte            `json:"-" bson:"body,omitempty"`
+	Created     time.Time         `json:"created" bson:"created"`
+}
+
+// WebhookAttempt is a POST of a delivery. StatusCode is zero when no response was received.
+type WebhookAttempt struct {
+	Time       time.Time `json:"time" bson:"time"`
+	StatusCode int       `json:"statusCode,omitempty" bson:"statusCode,omitempty"`
+	Error      string    `json:"error,omitempty" bson:"error,omitempty"`
+	Duration   string    `json:"duration" bson:"duration"`
+}
+
+type WebhookDeliveries []*WebhookDelivery
+
+type WebhookDeliveriesRest struct {
+	Status string            `json:"status"`
+	Data   WebhookDeliveries `json:"data"`
+}
+
+func (d WebhookDeliveries) ToRest() *WebhookDeliveriesRest {
+	return &WebhookDeliveriesRest{
+		Status: "success",
+		Data:   d,
+	}
+}
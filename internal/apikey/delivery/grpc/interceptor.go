@@ -0,0 +1,199 @@
+package grpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+	"github.com/KarolosLykos/sportsnews/internal/apikey"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+// MetadataAPIKey is the metadata key calls send their key in.
+const MetadataAPIKey = "x-api-key"
+
+type contextKey struct{}
+
+type authInterceptor struct {
+	cfg     *config.Config
+	logger  logger.Logger
+	uc      apikey.UseCase
+	limiter apikey.Limiter
+}
+
+func NewAuthInterceptor(cfg *config.Config, logger logger.Logger, uc apikey.UseCase, limiter apikey.Limiter) *authInterceptor {
+	return &authInterceptor{
+		cfg:     cfg,
+		logger:  logger,
+		uc:      uc,
+		limiter: limiter,
+	}
+}
+
+// Unary checks the unary calls to the methods of the service like the HTTP routes are checked: they
+// need a key with the scope that is within its limits. The calls to the other services, health and
+// reflection, are let through.
+func (i *authInterceptor) Unary(service string, scope domain.Scope) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !inService(service, info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		ctx, header, err := i.authorize(ctx, scope)
+		if header.Len() > 0 {
+			if serr := grpc.SetHeader(ctx, header); serr != nil {
+				i.logger.Warnf(ctx, serr, "could not set the rate limit headers of %s", info.FullMethod)
+			}
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// Stream checks the streaming calls to the methods of the service like Unary does.
+func (i *authInterceptor) Stream(service string, scope domain.Scope) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !inService(service, info.FullMethod) {
+			return handler(srv, ss)
+		}
+
+		ctx, header, err := i.authorize(ss.Context(), scope)
+		if header.Len() > 0 {
+			if serr := ss.SetHeader(header); serr != nil {
+				i.logger.Warnf(ctx, serr, "could not set the rate limit headers of %s", info.FullMethod)
+			}
+		}
+
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authorize returns the context of the call with its key, and the rate limit headers to send. The
+// calls are let through unchecked when authentication is disabled, and unlimited with the root key.
+// When the limits cannot be checked, calls are let through rather than failed.
+func (i *authInterceptor) authorize(ctx context.Context, scope domain.Scope) (context.Context, metadata.MD, error) {
+	if !i.cfg.Auth.Enabled {
+		return ctx, nil, nil
+	}
+
+	plain := keyOf(ctx)
+	if plain == "" {
+		return ctx, nil, status.Error(codes.Unauthenticated, "missing api key")
+	}
+
+	if root := i.cfg.Auth.RootKey; root != "" && subtle.ConstantTimeCompare([]byte(plain), []byte(root)) == 1 {
+		return ctx, nil, nil
+	}
+
+	key, err := i.uc.Authenticate(ctx, plain)
+	if errors.Is(err, domain.ErrInvalidAPIKey) {
+		return ctx, nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	if err != nil {
+		return ctx, nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if !key.HasScope(scope) {
+		return ctx, nil, status.Errorf(codes.PermissionDenied, "api key lacks the %s scope", scope)
+	}
+
+	ctx = context.WithValue(ctx, contextKey{}, key)
+
+	limit, err := i.limiter.Allow(ctx, key)
+	if err != nil {
+		i.logger.Warnf(ctx, err, "could not check the limits of api key: %s", key.ID)
+		return ctx, nil, nil
+	}
+
+	header := limitHeaders(limit)
+
+	if !limit.Allowed {
+		reset := limit.Reset
+		if limit.QuotaLimit > 0 && limit.QuotaRemaining == 0 {
+			reset = limit.QuotaReset
+		}
+
+		header.Set("retry-after", strconv.Itoa(int(time.Until(reset).Seconds())+1))
+
+		return ctx, header, status.Error(codes.ResourceExhausted, "api key exceeded its limits")
+	}
+
+	return ctx, header, nil
+}
+
+// APIKeyOf returns the key of the call, nil when authentication is disabled or when the call was made
+// with the root key.
+func APIKeyOf(ctx context.Context) *domain.APIKey {
+	key, _ := ctx.Value(contextKey{}).(*domain.APIKey)
+
+	return key
+}
+
+// keyOf returns the key of the call, from the x-api-key metadata or a bearer token, in that order.
+func keyOf(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	if keys := md.Get(MetadataAPIKey); len(keys) > 0 && keys[0] != "" {
+		return keys[0]
+	}
+
+	if auth := md.Get("authorization"); len(auth) > 0 && len(auth[0]) > len("Bearer ") &&
+		strings.EqualFold(auth[0][:len("Bearer ")], "Bearer ") {
+		return auth[0][len("Bearer "):]
+	}
+
+	return ""
+}
+
+// limitHeaders reports the rate limit of the window and the quota of the day, when limited, like the
+// X-RateLimit-* headers of the HTTP routes.
+func limitHeaders(limit *domain.RateLimit) metadata.MD {
+	header := metadata.MD{}
+
+	if limit.Limit > 0 {
+		header.Set("x-ratelimit-limit", strconv.Itoa(limit.Limit))
+		header.Set("x-ratelimit-remaining", strconv.Itoa(limit.Remaining))
+		header.Set("x-ratelimit-reset", strconv.FormatInt(limit.Reset.Unix(), 10))
+	}
+
+	if limit.QuotaLimit > 0 {
+		header.Set("x-ratelimit-quota-limit", strconv.Itoa(limit.QuotaLimit))
+		header.Set("x-ratelimit-quota-remaining", strconv.Itoa(limit.QuotaRemaining))
+		header.Set("x-ratelimit-quota-reset", strconv.FormatInt(limit.QuotaReset.Unix(), 10))
+	}
+
+	return header
+}
+
+func inService(service, fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, "/"+service+"/")
+}
+
+// serverStream is a stream with the context that holds the key of the call.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
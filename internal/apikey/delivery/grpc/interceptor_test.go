@@ -0,0 +1,276 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+	mock "github.com/KarolosLykos/sportsnews/internal/apikey/mock"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+func TestAuthInterceptor(t *testing.T) {
+	reader := &domain.APIKey{ID: "1", Scopes: []domain.Scope{domain.ScopeRead}, RateLimit: 10, DailyQuota: 100}
+	reset := time.Now().Add(30*time.Second + 500*time.Millisecond)
+	quotaReset := time.Now().Add(time.Hour)
+
+	tt := []struct {
+		name     string
+		disabled bool
+		service  string
+		md       map[string]string
+		scope    domain.Scope
+		stub     func(uc *mock.MockUseCase, limiter *mock.MockLimiter)
+		code     codes.Code
+		headers  map[string]string
+		keyID    string
+	}{
+		{
+			name:     "disabled",
+			disabled: true,
+			scope:    domain.ScopeRead,
+			code:     codes.OK,
+		},
+		{
+			name:    "other service",
+			service: "other.v1.OtherService",
+			scope:   domain.ScopeRead,
+			code:    codes.OK,
+		},
+		{
+			name:  "missing key",
+			scope: domain.ScopeRead,
+			code:  codes.Unauthenticated,
+		},
+		{
+			name:  "root key",
+			md:    map[string]string{MetadataAPIKey: "root"},
+			scope: domain.ScopeAdmin,
+			code:  codes.OK,
+		},
+		{
+			name:  "metadata",
+			md:    map[string]string{MetadataAPIKey: "snk_1"},
+			scope: domain.ScopeRead,
+			stub: func(uc *mock.MockUseCase, limiter *mock.MockLimiter) {
+				uc.EXPECT().Authenticate(gomock.Any(), "snk_1").Times(1).Return(reader, nil)
+				limiter.EXPECT().Allow(gomock.Any(), reader).Times(1).Return(&domain.RateLimit{
+					Allowed: true, Limit: 10, Remaining: 9, Reset: reset, QuotaLimit: 100, QuotaRemaining: 99, QuotaReset: quotaReset,
+				}, nil)
+			},
+			code: codes.OK,
+			headers: map[string]string{
+				"x-ratelimit-limit":           "10",
+				"x-ratelimit-remaining":       "9",
+				"x-ratelimit-reset":           strconv.FormatInt(reset.Unix(), 10),
+				"x-ratelimit-quota-limit":     "100",
+				"x-ratelimit-quota-remaining": "99",
+				"x-ratelimit-quota-reset":     strconv.FormatInt(quotaReset.Unix(), 10),
+			},
+			keyID: "1",
+		},
+		{
+			name:  "bearer",
+			md:    map[string]string{"authorization": "Bearer snk_1"},
+			scope: domain.ScopeRead,
+			stub: func(uc *mock.MockUseCase, limiter *mock.MockLimiter) {
+				uc.EXPECT().Authenticate(gomock.Any(), "snk_1").Times(1).Return(reader, nil)
+				limiter.EXPECT().Allow(gomock.Any(), reader).Times(1).Return(&domain.RateLimit{Allowed: true}, nil)
+			},
+			code:  codes.OK,
+			keyID: "1",
+		},
+		{
+			name:  "invalid key",
+			md:    map[string]string{MetadataAPIKey: "snk_1"},
+			scope: domain.ScopeRead,
+			stub: func(uc *mock.MockUseCase, limiter *mock.MockLimiter) {
+				uc.EXPECT().Authenticate(gomock.Any(), "snk_1").Times(1).Return(nil, domain.ErrInvalidAPIKey)
+			},
+			code: codes.Unauthenticated,
+		},
+		{
+			name:  "authentication error",
+			md:    map[string]string{MetadataAPIKey: "snk_1"},
+			scope: domain.ScopeRead,
+			stub: func(uc *mock.MockUseCase, limiter *mock.MockLimiter) {
+				uc.EXPECT().Authenticate(gomock.Any(), "snk_1").Times(1).Return(nil, errors.New("something went wrong"))
+			},
+			code: codes.Internal,
+		},
+		{
+			name:  "missing scope",
+			md:    map[string]string{MetadataAPIKey: "snk_1"},
+			scope: domain.ScopeWebhooks,
+			stub: func(uc *mock.MockUseCase, limiter *mock.MockLimiter) {
+				uc.EXPECT().Authenticate(gomock.Any(), "snk_1").Times(1).Return(reader, nil)
+			},
+			code: codes.PermissionDenied,
+		},
+		{
+			name:  "rate limited",
+			md:    map[string]string{MetadataAPIKey: "snk_1"},
+			scope: domain.ScopeRead,
+			stub: func(uc *mock.MockUseCase, limiter *mock.MockLimiter) {
+				uc.EXPECT().Authenticate(gomock.Any(), "snk_1").Times(1).Return(reader, nil)
+				limiter.EXPECT().Allow(gomock.Any(), reader).Times(1).Return(&domain.RateLimit{
+					Limit: 10, Reset: reset, QuotaLimit: 100, QuotaRemaining: 90, QuotaReset: quotaReset,
+				}, nil)
+			},
+			code:    codes.ResourceExhausted,
+			headers: map[string]string{"x-ratelimit-remaining": "0", "retry-after": "31"},
+		},
+		{
+			name:  "limiter error",
+			md:    map[string]string{MetadataAPIKey: "snk_1"},
+			scope: domain.ScopeRead,
+			stub: func(uc *mock.MockUseCase, limiter *mock.MockLimiter) {
+				uc.EXPECT().Authenticate(gomock.Any(), "snk_1").Times(1).Return(reader, nil)
+				limiter.EXPECT().Allow(gomock.Any(), reader).Times(1).Return(nil, errors.New("something went wrong"))
+			},
+			code:  codes.OK,
+			keyID: "1",
+		},
+	}
+
+	for _, tc := range tt {
+		for _, stream := range []bool{false, true} {
+			name := tc.name + " unary"
+			if stream {
+				name = tc.name + " stream"
+			}
+
+			t.Run(name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				uc := mock.NewMockUseCase(ctrl)
+				limiter := mock.NewMockLimiter(ctrl)
+				if tc.stub != nil {
+					tc.stub(uc, limiter)
+				}
+
+				cfg := &config.Config{}
+				cfg.Auth.Enabled = !tc.disabled
+				cfg.Auth.RootKey = "root"
+
+				service := tc.service
+				if service == "" {
+					service = healthpb.Health_ServiceDesc.ServiceName
+				}
+
+				var key *domain.APIKey
+				client := newClient(t, NewAuthInterceptor(cfg, getLogger(), uc, limiter), service, tc.scope, &key)
+
+				ctx := metadata.NewOutgoingContext(context.Background(), metadata.New(tc.md))
+
+				var (
+					header metadata.MD
+					err    error
+				)
+
+				if stream {
+					var watch healthpb.Health_WatchClient
+
+					watch, err = client.Watch(ctx, &healthpb.HealthCheckRequest{})
+					require.NoError(t, err)
+
+					_, err = watch.Recv()
+					header, _ = watch.Header()
+				} else {
+					_, err = client.Check(ctx, &healthpb.HealthCheckRequest{}, grpc.Header(&header))
+				}
+
+				assert.Equal(t, tc.code, status.Code(err), err)
+
+				for k, v := range tc.headers {
+					assert.Equal(t, []string{v}, header.Get(k), k)
+				}
+
+				if tc.keyID == "" {
+					assert.Nil(t, key)
+					return
+				}
+
+				require.NotNil(t, key)
+				assert.Equal(t, tc.keyID, key.ID)
+			})
+		}
+	}
+}
+
+// newClient serves the health service behind the interceptors of the service, and keeps the key of
+// the calls they let through.
+func newClient(t *testing.T, auth *authInterceptor, service string, scope domain.Scope, key **domain.APIKey) healthpb.HealthClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			auth.Unary(service, scope),
+			func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+				*key = APIKeyOf(ctx)
+				return handler(ctx, req)
+			},
+		),
+		grpc.ChainStreamInterceptor(
+			auth.Stream(service, scope),
+			func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+				*key = APIKeyOf(ss.Context())
+				return handler(srv, ss)
+			},
+		),
+	)
+	healthpb.RegisterHealthServer(srv, grpchealth.NewServer())
+
+	go func() { _ = srv.Serve(lis) }()
+
+	conn, err := grpc.Dial(
+		"bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		conn.Close()
+		srv.Stop()
+	})
+
+	return healthpb.NewHealthClient(conn)
+}
+
+func getLogger() logger.Logger {
+	cfg := &config.Config{}
+
+	l := &logrus.Logger{
+		Out:          io.Discard,
+		Hooks:        make(logrus.LevelHooks),
+		ReportCaller: false,
+		ExitFunc:     os.Exit,
+		Level:        logrus.InfoLevel,
+		Formatter:    &logrus.JSONFormatter{},
+	}
+
+	return logger.New(cfg, l)
+}
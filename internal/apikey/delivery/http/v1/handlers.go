@@ -0,0 +1,116 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+	"github.com/KarolosLykos/sportsnews/internal/apikey"
+	httperrors "github.com/KarolosLykos/sportsnews/internal/utils/http_errors"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+// defaultUsageDays is the number of days the usage of a key is returned for, unless asked otherwise.
+const defaultUsageDays = 7
+
+type apiKeyHandler struct {
+	cfg    *config.Config
+	logger logger.Logger
+	uc     apikey.UseCase
+}
+
+func NewAPIKeyHandler(cfg *config.Config, logger logger.Logger, uc apikey.UseCase) *apiKeyHandler {
+	return &apiKeyHandler{
+		cfg:    cfg,
+		logger: logger,
+		uc:     uc,
+	}
+}
+
+// Create issues a key with the name, scopes and limits of the body. The response holds the key,
+// which is not shown again.
+func (h *apiKeyHandler) Create() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		k := &domain.APIKey{}
+		if err := c.Bind(k); err != nil {
+			return httperrors.ErrorResponse(c, fmt.Errorf("%w:%v", httperrors.ErrBadRequest, err))
+		}
+
+		if err := k.Validate(); err != nil {
+			return httperrors.ErrorResponse(c, fmt.Errorf("%w:%v", httperrors.ErrBadRequest, err))
+		}
+
+		created, err := h.uc.Create(c.Request().Context(), k)
+		if err != nil {
+			return httperrors.ErrorResponse(c, err)
+		}
+
+		return c.JSON(http.StatusCreated, created.ToRest())
+	}
+}
+
+func (h *apiKeyHandler) GetByID() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		k, err := h.uc.GetByID(c.Request().Context(), c.Param("id"))
+		if err != nil {
+			return httperrors.ErrorResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, k.ToRest())
+	}
+}
+
+func (h *apiKeyHandler) List() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		keys, err := h.uc.List(c.Request().Context())
+		if err != nil {
+			return httperrors.ErrorResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, keys.ToRest())
+	}
+}
+
+// Revoke revokes the key, which is refused from then on. Revoked keys are kept with their usage.
+func (h *apiKeyHandler) Revoke() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		k, err := h.uc.Revoke(c.Request().Context(), c.Param("id"))
+		if err != nil {
+			return httperrors.ErrorResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, k.ToRest())
+	}
+}
+
+// Usage returns the requests made with the key on each of the last days, at most as many as the
+// usage is kept for.
+func (h *apiKeyHandler) Usage() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		days := defaultUsageDays
+		if s := c.QueryParam("days"); s != "" {
+			n, err := strconv.Atoi(s)
+			if err != nil || n <= 0 {
+				return httperrors.ErrorResponse(c, fmt.Errorf("%w:days must be a positive integer", httperrors.ErrBadRequest))
+			}
+
+			days = n
+		}
+
+		if retention := int(h.cfg.Auth.UsageRetention / (24 * time.Hour)); retention > 0 && days > retention {
+			days = retention
+		}
+
+		usage, err := h.uc.Usage(c.Request().Context(), c.Param("id"), days)
+		if err != nil {
+			return httperrors.ErrorResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, usage.ToRest())
+	}
+}
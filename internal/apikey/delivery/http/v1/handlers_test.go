@@ -0,0 +1,162 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+	mock "github.com/KarolosLykos/sportsnews/internal/apikey/mock"
+)
+
+func TestAPIKeyHandler_Create(t *testing.T) {
+	tt := []struct {
+		name string
+		body string
+		stub func(uc *mock.MockUseCase)
+		code int
+	}{
+		{
+			name: "created",
+			body: `{"name":"partner","scopes":["read","webhooks"],"rateLimit":60}`,
+			stub: func(uc *mock.MockUseCase) {
+				uc.EXPECT().Create(gomock.Any(), &domain.APIKey{
+					Name:      "partner",
+					Scopes:    []domain.Scope{domain.ScopeRead, domain.ScopeWebhooks},
+					RateLimit: 60,
+				}).Times(1).Return(&domain.APIKey{ID: "1", Name: "partner", Key: "snk_1", Hash: "hash"}, nil)
+			},
+			code: http.StatusCreated,
+		},
+		{
+			name: "malformed",
+			body: `{"name":`,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "without scopes",
+			body: `{"name":"partner"}`,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "unknown scope",
+			body: `{"name":"partner","scopes":["write"]}`,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "negative quota",
+			body: `{"name":"partner","scopes":["read"],"dailyQuota":-1}`,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "error",
+			body: `{"name":"partner","scopes":["read"]}`,
+			stub: func(uc *mock.MockUseCase) {
+				uc.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("something went wrong"))
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := mock.NewMockUseCase(ctrl)
+			if tc.stub != nil {
+				tc.stub(uc)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/keys", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+
+			require.NoError(t, NewAPIKeyHandler(&config.Config{}, getLogger(), uc).Create()(c))
+			assert.Equal(t, tc.code, rec.Code)
+
+			if tc.code != http.StatusCreated {
+				return
+			}
+
+			res := struct {
+				Data map[string]interface{} `json:"data"`
+			}{}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+			assert.Equal(t, "snk_1", res.Data["key"])
+			assert.NotContains(t, res.Data, "hash")
+		})
+	}
+}
+
+func TestAPIKeyHandler_Usage(t *testing.T) {
+	tt := []struct {
+		name  string
+		query string
+		stub  func(uc *mock.MockUseCase)
+		code  int
+	}{
+		{
+			name: "default days",
+			stub: func(uc *mock.MockUseCase) {
+				uc.EXPECT().Usage(gomock.Any(), "1", defaultUsageDays).Times(1).Return(domain.APIKeyUsages{}, nil)
+			},
+			code: http.StatusOK,
+		},
+		{
+			name:  "days beyond retention",
+			query: "?days=365",
+			stub: func(uc *mock.MockUseCase) {
+				uc.EXPECT().Usage(gomock.Any(), "1", 30).Times(1).Return(domain.APIKeyUsages{}, nil)
+			},
+			code: http.StatusOK,
+		},
+		{
+			name:  "invalid days",
+			query: "?days=0",
+			code:  http.StatusBadRequest,
+		},
+		{
+			name: "not found",
+			stub: func(uc *mock.MockUseCase) {
+				uc.EXPECT().Usage(gomock.Any(), "1", defaultUsageDays).Times(1).Return(nil, errors.New("no documents in result"))
+			},
+			code: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := mock.NewMockUseCase(ctrl)
+			if tc.stub != nil {
+				tc.stub(uc)
+			}
+
+			cfg := &config.Config{}
+			cfg.Auth.UsageRetention = 720 * time.Hour
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/keys/1/usage"+tc.query, nil)
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+
+			require.NoError(t, NewAPIKeyHandler(cfg, getLogger(), uc).Usage()(c))
+			assert.Equal(t, tc.code, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package v1
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+	"github.com/KarolosLykos/sportsnews/internal/apikey"
+	httperrors "github.com/KarolosLykos/sportsnews/internal/utils/http_errors"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+const (
+	// HeaderAPIKey is the header requests send their key in.
+	HeaderAPIKey = "X-API-Key"
+	// queryAPIKey is the query parameter that carries the key of the requests that cannot set
+	// headers: feed readers, EventSource and browser WebSockets.
+	queryAPIKey = "apiKey"
+	// contextKey is the key of the API key of a request in its echo context.
+	contextKey = "apiKey"
+)
+
+type authMiddleware struct {
+	cfg     *config.Config
+	logger  logger.Logger
+	uc      apikey.UseCase
+	limiter apikey.Limiter
+}
+
+func NewAuthMiddleware(cfg *config.Config, logger logger.Logger, uc apikey.UseCase, limiter apikey.Limiter) *authMiddleware {
+	return &authMiddleware{
+		cfg:     cfg,
+		logger:  logger,
+		uc:      uc,
+		limiter: limiter,
+	}
+}
+
+// Require lets through the requests made with a key that has the scope and is within its limits,
+// and keeps the key in the context of the request. The limits are reported in the X-RateLimit-*
+// headers. Requests are let through unchecked when authentication is disabled, and unlimited with
+// the root key. When the limits cannot be checked, requests are let through rather than failed.
+func (m *authMiddleware) Require(scope domain.Scope) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !m.cfg.Auth.Enabled {
+				return next(c)
+			}
+
+			plain := keyOf(c.Request())
+			if plain == "" {
+				return httperrors.ErrorResponse(c, fmt.Errorf("%w:missing api key", httperrors.ErrUnauthorized))
+			}
+
+			if root := m.cfg.Auth.RootKey; root != "" && subtle.ConstantTimeCompare([]byte(plain), []byte(root)) == 1 {
+				return next(c)
+			}
+
+			ctx := c.Request().Context()
+
+			key, err := m.uc.Authenticate(ctx, plain)
+			if errors.Is(err, domain.ErrInvalidAPIKey) {
+				return httperrors.ErrorResponse(c, fmt.Errorf("%w:%v", httperrors.ErrUnauthorized, err))
+			}
+
+			if err != nil {
+				return httperrors.ErrorResponse(c, err)
+			}
+
+			if !key.HasScope(scope) {
+				return httperrors.ErrorResponse(c, fmt.Errorf("%w:api key lacks the %s scope", httperrors.ErrForbidden, scope))
+			}
+
+			c.Set(contextKey, key)
+
+			limit, err := m.limiter.Allow(ctx, key)
+			if err != nil {
+				m.logger.Warnf(ctx, err, "could not check the limits of api key: %s", key.ID)
+				return next(c)
+			}
+
+			setLimitHeaders(c.Response().Header(), limit)
+
+			if !limit.Allowed {
+				reset := limit.Reset
+				if limit.QuotaLimit > 0 && limit.QuotaRemaining == 0 {
+					reset = limit.QuotaReset
+				}
+
+				retryAfter := int(time.Until(reset).Seconds()) + 1
+				c.Response().Header().Set("Retry-After", strconv.Itoa(retryAfter))
+
+				return httperrors.ErrorResponse(c, fmt.Errorf("%w:api key exceeded its limits", httperrors.ErrTooManyRequests))
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// APIKeyOf returns the key of the request, nil before Require let it through, when authentication
+// is disabled or when the request was made with the root key.
+func APIKeyOf(c echo.Context) *domain.APIKey {
+	key, _ := c.Get(contextKey).(*domain.APIKey)
+
+	return key
+}
+
+// keyOf returns the key of the request, from the X-API-Key header, a bearer token or the apiKey
+// query parameter, in that order.
+func keyOf(r *http.Request) string {
+	if key := r.Header.Get(HeaderAPIKey); key != "" {
+		return key
+	}
+
+	if auth := r.Header.Get(echo.HeaderAuthorization); len(auth) > len("Bearer ") &&
+		strings.EqualFold(auth[:len("Bearer ")], "Bearer ") {
+		return auth[len("Bearer "):]
+	}
+
+	return r.URL.Query().Get(queryAPIKey)
+}
+
+// WithoutKey returns a copy of the URL without the apiKey query parameter, so that the URLs built
+// from a request do not pass its key on.
+func WithoutKey(u *url.URL) *url.URL {
+	stripped := *u
+
+	query := u.Query()
+	if query.Has(queryAPIKey) {
+		query.Del(queryAPIKey)
+		stripped.RawQuery = query.Encode()
+	}
+
+	return &stripped
+}
+
+// RedactedURI returns the URI of the request, with the key of the apiKey query parameter redacted,
+// so that it can be logged.
+func RedactedURI(r *http.Request) string {
+	query := r.URL.Query()
+	if !query.Has(queryAPIKey) {
+		return r.RequestURI
+	}
+
+	query.Set(queryAPIKey, "REDACTED")
+
+	u := *r.URL
+	u.RawQuery = query.Encode()
+
+	return u.RequestURI()
+}
+
+// setLimitHeaders reports the rate limit of the window and the quota of the day, when limited.
+func setLimitHeaders(h http.Header, limit *domain.RateLimit) {
+	if limit.Limit > 0 {
+		h.Set("X-RateLimit-Limit", strconv.Itoa(limit.Limit))
+		h.Set("X-RateLimit-Remaining", strconv.Itoa(limit.Remaining))
+		h.Set("X-RateLimit-Reset", strconv.FormatInt(limit.Reset.Unix(), 10))
+	}
+
+	if limit.QuotaLimit > 0 {
+		h.Set("X-RateLimit-Quota-Limit", strconv.Itoa(limit.QuotaLimit))
+		h.Set("X-RateLimit-Quota-Remaining", strconv.Itoa(limit.QuotaRemaining))
+		h.Set("X-RateLimit-Quota-Reset", strconv.FormatInt(limit.QuotaReset.Unix(), 10))
+	}
+}
@@ -0,0 +1,277 @@
+package v1
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+	mock "github.com/KarolosLykos/sportsnews/internal/apikey/mock"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+func TestAuthMiddleware_Require(t *testing.T) {
+	reader := &domain.APIKey{ID: "1", Scopes: []domain.Scope{domain.ScopeRead}, RateLimit: 10, DailyQuota: 100}
+	admin := &domain.APIKey{ID: "2", Scopes: []domain.Scope{domain.ScopeAdmin}}
+	reset := time.Now().Add(30*time.Second + 500*time.Millisecond)
+	quotaReset := time.Now().Add(time.Hour)
+
+	tt := []struct {
+		name     string
+		disabled bool
+		target   string
+		header   map[string]string
+		scope    domain.Scope
+		stub     func(uc *mock.MockUseCase, limiter *mock.MockLimiter)
+		code     int
+		headers  map[string]string
+		keyID    string
+	}{
+		{
+			name:     "disabled",
+			disabled: true,
+			target:   "/api/v1/articles",
+			scope:    domain.ScopeRead,
+			code:     http.StatusOK,
+		},
+		{
+			name:   "missing key",
+			target: "/api/v1/articles",
+			scope:  domain.ScopeRead,
+			code:   http.StatusUnauthorized,
+		},
+		{
+			name:   "root key",
+			target: "/api/v1/admin/keys",
+			header: map[string]string{HeaderAPIKey: "root"},
+			scope:  domain.ScopeAdmin,
+			code:   http.StatusOK,
+		},
+		{
+			name:   "header",
+			target: "/api/v1/articles",
+			header: map[string]string{HeaderAPIKey: "snk_1"},
+			scope:  domain.ScopeRead,
+			stub: func(uc *mock.MockUseCase, limiter *mock.MockLimiter) {
+				uc.EXPECT().Authenticate(gomock.Any(), "snk_1").Times(1).Return(reader, nil)
+				limiter.EXPECT().Allow(gomock.Any(), reader).Times(1).Return(&domain.RateLimit{
+					Allowed: true, Limit: 10, Remaining: 9, Reset: reset, QuotaLimit: 100, QuotaRemaining: 99, QuotaReset: quotaReset,
+				}, nil)
+			},
+			code: http.StatusOK,
+			headers: map[string]string{
+				"X-RateLimit-Limit":           "10",
+				"X-RateLimit-Remaining":       "9",
+				"X-RateLimit-Reset":           strconv.FormatInt(reset.Unix(), 10),
+				"X-RateLimit-Quota-Limit":     "100",
+				"X-RateLimit-Quota-Remaining": "99",
+				"X-RateLimit-Quota-Reset":     strconv.FormatInt(quotaReset.Unix(), 10),
+			},
+			keyID: "1",
+		},
+		{
+			name:   "bearer",
+			target: "/api/v1/articles",
+			header: map[string]string{echo.HeaderAuthorization: "Bearer snk_1"},
+			scope:  domain.ScopeRead,
+			stub: func(uc *mock.MockUseCase, limiter *mock.MockLimiter) {
+				uc.EXPECT().Authenticate(gomock.Any(), "snk_1").Times(1).Return(reader, nil)
+				limiter.EXPECT().Allow(gomock.Any(), reader).Times(1).Return(&domain.RateLimit{Allowed: true}, nil)
+			},
+			code:  http.StatusOK,
+			keyID: "1",
+		},
+		{
+			name:   "query",
+			target: "/feeds/articles.rss?apiKey=snk_1",
+			scope:  domain.ScopeRead,
+			stub: func(uc *mock.MockUseCase, limiter *mock.MockLimiter) {
+				uc.EXPECT().Authenticate(gomock.Any(), "snk_1").Times(1).Return(reader, nil)
+				limiter.EXPECT().Allow(gomock.Any(), reader).Times(1).Return(&domain.RateLimit{Allowed: true}, nil)
+			},
+			code:  http.StatusOK,
+			keyID: "1",
+		},
+		{
+			name:   "invalid key",
+			target: "/api/v1/articles",
+			header: map[string]string{HeaderAPIKey: "snk_1"},
+			scope:  domain.ScopeRead,
+			stub: func(uc *mock.MockUseCase, limiter *mock.MockLimiter) {
+				uc.EXPECT().Authenticate(gomock.Any(), "snk_1").Times(1).Return(nil, domain.ErrInvalidAPIKey)
+			},
+			code: http.StatusUnauthorized,
+		},
+		{
+			name:   "authentication error",
+			target: "/api/v1/articles",
+			header: map[string]string{HeaderAPIKey: "snk_1"},
+			scope:  domain.ScopeRead,
+			stub: func(uc *mock.MockUseCase, limiter *mock.MockLimiter) {
+				uc.EXPECT().Authenticate(gomock.Any(), "snk_1").Times(1).Return(nil, errors.New("something went wrong"))
+			},
+			code: http.StatusInternalServerError,
+		},
+		{
+			name:   "missing scope",
+			target: "/api/v1/webhooks",
+			header: map[string]string{HeaderAPIKey: "snk_1"},
+			scope:  domain.ScopeWebhooks,
+			stub: func(uc *mock.MockUseCase, limiter *mock.MockLimiter) {
+				uc.EXPECT().Authenticate(gomock.Any(), "snk_1").Times(1).Return(reader, nil)
+			},
+			code: http.StatusForbidden,
+		},
+		{
+			name:   "admin key",
+			target: "/api/v1/webhooks",
+			header: map[string]string{HeaderAPIKey: "snk_2"},
+			scope:  domain.ScopeWebhooks,
+			stub: func(uc *mock.MockUseCase, limiter *mock.MockLimiter) {
+				uc.EXPECT().Authenticate(gomock.Any(), "snk_2").Times(1).Return(admin, nil)
+				limiter.EXPECT().Allow(gomock.Any(), admin).Times(1).Return(&domain.RateLimit{Allowed: true}, nil)
+			},
+			code:  http.StatusOK,
+			keyID: "2",
+		},
+		{
+			name:   "rate limited",
+			target: "/api/v1/articles",
+			header: map[string]string{HeaderAPIKey: "snk_1"},
+			scope:  domain.ScopeRead,
+			stub: func(uc *mock.MockUseCase, limiter *mock.MockLimiter) {
+				uc.EXPECT().Authenticate(gomock.Any(), "snk_1").Times(1).Return(reader, nil)
+				limiter.EXPECT().Allow(gomock.Any(), reader).Times(1).Return(&domain.RateLimit{
+					Limit: 10, Reset: reset, QuotaLimit: 100, QuotaRemaining: 90, QuotaReset: quotaReset,
+				}, nil)
+			},
+			code:    http.StatusTooManyRequests,
+			headers: map[string]string{"X-RateLimit-Remaining": "0", "Retry-After": "31"},
+		},
+		{
+			name:   "limiter error",
+			target: "/api/v1/articles",
+			header: map[string]string{HeaderAPIKey: "snk_1"},
+			scope:  domain.ScopeRead,
+			stub: func(uc *mock.MockUseCase, limiter *mock.MockLimiter) {
+				uc.EXPECT().Authenticate(gomock.Any(), "snk_1").Times(1).Return(reader, nil)
+				limiter.EXPECT().Allow(gomock.Any(), reader).Times(1).Return(nil, errors.New("something went wrong"))
+			},
+			code:  http.StatusOK,
+			keyID: "1",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := mock.NewMockUseCase(ctrl)
+			limiter := mock.NewMockLimiter(ctrl)
+			if tc.stub != nil {
+				tc.stub(uc, limiter)
+			}
+
+			cfg := &config.Config{}
+			cfg.Auth.Enabled = !tc.disabled
+			cfg.Auth.RootKey = "root"
+
+			req := httptest.NewRequest(http.MethodGet, tc.target, nil)
+			for k, v := range tc.header {
+				req.Header.Set(k, v)
+			}
+
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+
+			var key *domain.APIKey
+			next := func(c echo.Context) error {
+				key = APIKeyOf(c)
+				return c.NoContent(http.StatusOK)
+			}
+
+			require.NoError(t, NewAuthMiddleware(cfg, getLogger(), uc, limiter).Require(tc.scope)(next)(c))
+			assert.Equal(t, tc.code, rec.Code, rec.Body.String())
+
+			for k, v := range tc.headers {
+				assert.Equal(t, v, rec.Header().Get(k), k)
+			}
+
+			if tc.keyID == "" {
+				assert.Nil(t, key)
+				return
+			}
+
+			require.NotNil(t, key)
+			assert.Equal(t, tc.keyID, key.ID)
+		})
+	}
+}
+
+func TestRedactedURI(t *testing.T) {
+	tt := []struct {
+		name     string
+		target   string
+		expected string
+	}{
+		{name: "without key", target: "/api/v1/articles?teamId=t1", expected: "/api/v1/articles?teamId=t1"},
+		{name: "with key", target: "/api/v1/feed.rss?apiKey=secret&teamId=t1", expected: "/api/v1/feed.rss?apiKey=REDACTED&teamId=t1"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tc.target, nil)
+
+			assert.Equal(t, tc.expected, RedactedURI(req))
+		})
+	}
+}
+
+func TestWithoutKey(t *testing.T) {
+	tt := []struct {
+		name     string
+		target   string
+		expected string
+	}{
+		{name: "without key", target: "/api/v1/articles?teamId=t1&limit=3", expected: "/api/v1/articles?teamId=t1&limit=3"},
+		{name: "with key", target: "/feeds/t1.rss?apiKey=secret&category=News", expected: "/feeds/t1.rss?category=News"},
+		{name: "only key", target: "/feeds/t1.rss?apiKey=secret", expected: "/feeds/t1.rss"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tc.target, nil)
+
+			assert.Equal(t, tc.expected, WithoutKey(req.URL).RequestURI())
+			assert.Equal(t, tc.target, req.URL.RequestURI())
+		})
+	}
+}
+
+func getLogger() logger.Logger {
+	cfg := &config.Config{}
+
+	l := &logrus.Logger{
+		Out:          io.Discard,
+		Hooks:        make(logrus.LevelHooks),
+		ReportCaller: false,
+		ExitFunc:     os.Exit,
+		Level:        logrus.InfoLevel,
+		Formatter:    &logrus.JSONFormatter{},
+	}
+
+	return logger.New(cfg, l)
+}
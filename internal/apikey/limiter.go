@@ -0,0 +1,15 @@
+package apikey
+
+import (
+	"context"
+
+	"github.com/KarolosLykos/sportsnews/domain"
+)
+
+type Limiter interface {
+	// Allow counts a request made with the key, unless it exceeds the rate limit or the daily quota
+	// of the key. Rejected requests are counted apart.
+	Allow(ctx context.Context, key *domain.APIKey) (*domain.RateLimit, error)
+	// Usage returns the requests made with the key on each of the last days, newest first.
+	Usage(ctx context.Context, id string, days int) (domain.APIKeyUsages, error)
+}
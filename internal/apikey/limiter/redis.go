@@ -0,0 +1,142 @@
+package limiter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+// window is the period the rate limit of a key counts requests over.
+const window = time.Minute
+
+var (
+	ErrAllow = errors.New("limiter: allow")
+	ErrUsage = errors.New("limiter: usage")
+)
+
+// allow checks both limits and counts the request, atomically, so that replicas share the limits
+// of a key. KEYS are the counter of the window and the usage of the day. ARGV are the rate limit,
+// the daily quota, both unlimited when zero, and the expiries of the keys in milliseconds. It
+// returns whether the request is allowed, and the requests allowed in the window and on the day.
+var allow = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local quota = tonumber(ARGV[2])
+local rate = tonumber(redis.call("GET", KEYS[1]) or "0")
+local requests = tonumber(redis.call("HGET", KEYS[2], "requests") or "0")
+local allowed = 0
+if (limit == 0 or rate < limit) and (quota == 0 or requests < quota) then
+  allowed = 1
+  rate = redis.call("INCR", KEYS[1])
+  redis.call("PEXPIRE", KEYS[1], ARGV[3])
+  requests = redis.call("HINCRBY", KEYS[2], "requests", 1)
+else
+  redis.call("HINCRBY", KEYS[2], "rejected", 1)
+end
+redis.call("PEXPIRE", KEYS[2], ARGV[4])
+return {allowed, rate, requests}
+`)
+
+// redisLimiter enforces the rate limits and daily quotas of the keys with counters in Redis. The
+// rate limit counts requests in fixed windows of a minute; the quota counts them per UTC day.
+type redisLimiter struct {
+	cfg    *config.Config
+	logger logger.Logger
+	client *redis.Client
+	now    func() time.Time
+}
+
+func NewRedisLimiter(cfg *config.Config, logger logger.Logger, client *redis.Client) *redisLimiter {
+	return &redisLimiter{
+		cfg:    cfg,
+		logger: logger,
+		client: client,
+		now:    time.Now,
+	}
+}
+
+func (r *redisLimiter) Allow(ctx context.Context, key *domain.APIKey) (*domain.RateLimit, error) {
+	now := r.now().UTC()
+	start := now.Truncate(window)
+	day := now.Truncate(24 * time.Hour)
+
+	res, err := allow.Run(
+		ctx,
+		r.client,
+		[]string{r.rateKey(key.ID, start), r.usageKey(key.ID, day)},
+		key.RateLimit,
+		key.DailyQuota,
+		window.Milliseconds(),
+		r.cfg.Auth.UsageRetention.Milliseconds(),
+	).Int64Slice()
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrAllow, err)
+	}
+
+	if len(res) != 3 {
+		return nil, fmt.Errorf("%w:unexpected reply %v", ErrAllow, res)
+	}
+
+	return &domain.RateLimit{
+		Allowed:        res[0] == 1,
+		Limit:          key.RateLimit,
+		Remaining:      remaining(key.RateLimit, res[1]),
+		Reset:          start.Add(window),
+		QuotaLimit:     key.DailyQuota,
+		QuotaRemaining: remaining(key.DailyQuota, res[2]),
+		QuotaReset:     day.AddDate(0, 0, 1),
+	}, nil
+}
+
+func (r *redisLimiter) Usage(ctx context.Context, id string, days int) (domain.APIKeyUsages, error) {
+	day := r.now().UTC().Truncate(24 * time.Hour)
+
+	pipe := r.client.Pipeline()
+	cmds := make([]*redis.StringStringMapCmd, 0, days)
+	for i := 0; i < days; i++ {
+		cmds = append(cmds, pipe.HGetAll(ctx, r.usageKey(id, day.AddDate(0, 0, -i))))
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrUsage, err)
+	}
+
+	usage := make(domain.APIKeyUsages, 0, days)
+	for i, cmd := range cmds {
+		counts := cmd.Val()
+		requests, _ := strconv.ParseInt(counts["requests"], 10, 64)
+		rejected, _ := strconv.ParseInt(counts["rejected"], 10, 64)
+
+		usage = append(usage, &domain.APIKeyUsage{
+			Date:     day.AddDate(0, 0, -i).Format("2006-01-02"),
+			Requests: requests,
+			Rejected: rejected,
+		})
+	}
+
+	return usage, nil
+}
+
+func (r *redisLimiter) rateKey(id string, start time.Time) string {
+	return fmt.Sprintf("%s:apikeys:%s:rate:%d", r.cfg.Redis.KeyPrefix, id, start.Unix())
+}
+
+func (r *redisLimiter) usageKey(id string, day time.Time) string {
+	return fmt.Sprintf("%s:apikeys:%s:usage:%s", r.cfg.Redis.KeyPrefix, id, day.Format("2006-01-02"))
+}
+
+// remaining returns the requests left under the limit once used were made, none when unlimited.
+func remaining(limit int, used int64) int {
+	if limit == 0 || used >= int64(limit) {
+		return 0
+	}
+
+	return limit - int(used)
+}
@@ -0,0 +1,175 @@
+package limiter
+
+import (
+	"context"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+func TestRedisLimiter_Allow(t *testing.T) {
+	tt := []struct {
+		name     string
+		key      *domain.APIKey
+		requests int
+		allowed  int
+		limit    *domain.RateLimit
+	}{
+		{
+			name:     "within limits",
+			key:      &domain.APIKey{ID: "1", RateLimit: 5, DailyQuota: 10},
+			requests: 3,
+			allowed:  3,
+			limit:    &domain.RateLimit{Allowed: true, Limit: 5, Remaining: 2, QuotaLimit: 10, QuotaRemaining: 7},
+		},
+		{
+			name:     "rate limited",
+			key:      &domain.APIKey{ID: "1", RateLimit: 2, DailyQuota: 10},
+			requests: 3,
+			allowed:  2,
+			limit:    &domain.RateLimit{Allowed: false, Limit: 2, Remaining: 0, QuotaLimit: 10, QuotaRemaining: 8},
+		},
+		{
+			name:     "quota exhausted",
+			key:      &domain.APIKey{ID: "1", RateLimit: 5, DailyQuota: 1},
+			requests: 2,
+			allowed:  1,
+			limit:    &domain.RateLimit{Allowed: false, Limit: 5, Remaining: 4, QuotaLimit: 1, QuotaRemaining: 0},
+		},
+		{
+			name:     "unlimited",
+			key:      &domain.APIKey{ID: "1"},
+			requests: 3,
+			allowed:  3,
+			limit:    &domain.RateLimit{Allowed: true},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			r, _ := newRedisLimiter(t)
+			now := time.Date(2023, 3, 6, 10, 30, 15, 0, time.UTC)
+			r.now = func() time.Time { return now }
+
+			var (
+				limit   *domain.RateLimit
+				err     error
+				allowed int
+			)
+
+			for i := 0; i < tc.requests; i++ {
+				limit, err = r.Allow(context.Background(), tc.key)
+				require.NoError(t, err)
+
+				if limit.Allowed {
+					allowed++
+				}
+			}
+
+			assert.Equal(t, tc.allowed, allowed)
+
+			tc.limit.Reset = time.Date(2023, 3, 6, 10, 31, 0, 0, time.UTC)
+			tc.limit.QuotaReset = time.Date(2023, 3, 7, 0, 0, 0, 0, time.UTC)
+			assert.Equal(t, tc.limit, limit)
+
+			usage, err := r.Usage(context.Background(), tc.key.ID, 1)
+			require.NoError(t, err)
+			assert.Equal(t, domain.APIKeyUsages{{
+				Date:     "2023-03-06",
+				Requests: int64(tc.allowed),
+				Rejected: int64(tc.requests - tc.allowed),
+			}}, usage)
+		})
+	}
+}
+
+func TestRedisLimiter_AllowNextWindow(t *testing.T) {
+	r, mr := newRedisLimiter(t)
+	now := time.Date(2023, 3, 6, 10, 30, 15, 0, time.UTC)
+	r.now = func() time.Time { return now }
+
+	key := &domain.APIKey{ID: "1", RateLimit: 1, DailyQuota: 10}
+
+	limit, err := r.Allow(context.Background(), key)
+	require.NoError(t, err)
+	assert.True(t, limit.Allowed)
+
+	limit, err = r.Allow(context.Background(), key)
+	require.NoError(t, err)
+	assert.False(t, limit.Allowed)
+
+	now = now.Add(time.Minute)
+	mr.FastForward(time.Minute)
+
+	limit, err = r.Allow(context.Background(), key)
+	require.NoError(t, err)
+	assert.True(t, limit.Allowed)
+	assert.Equal(t, 8, limit.QuotaRemaining)
+}
+
+func TestRedisLimiter_Usage(t *testing.T) {
+	r, _ := newRedisLimiter(t)
+	now := time.Date(2023, 3, 6, 23, 59, 0, 0, time.UTC)
+	r.now = func() time.Time { return now }
+
+	key := &domain.APIKey{ID: "1"}
+
+	_, err := r.Allow(context.Background(), key)
+	require.NoError(t, err)
+
+	now = now.Add(2 * time.Minute)
+	for i := 0; i < 2; i++ {
+		_, err = r.Allow(context.Background(), key)
+		require.NoError(t, err)
+	}
+
+	usage, err := r.Usage(context.Background(), "1", 3)
+	require.NoError(t, err)
+	assert.Equal(t, domain.APIKeyUsages{
+		{Date: "2023-03-07", Requests: 2},
+		{Date: "2023-03-06", Requests: 1},
+		{Date: "2023-03-05"},
+	}, usage)
+
+	usage, err = r.Usage(context.Background(), "2", 1)
+	require.NoError(t, err)
+	assert.Equal(t, domain.APIKeyUsages{{Date: "2023-03-07"}}, usage)
+}
+
+func newRedisLimiter(t *testing.T) (*redisLimiter, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	cfg := &config.Config{}
+	cfg.Redis.KeyPrefix = "test"
+	cfg.Auth.UsageRetention = 720 * time.Hour
+
+	return NewRedisLimiter(cfg, getLogger(), client), mr
+}
+
+func getLogger() logger.Logger {
+	cfg := &config.Config{}
+
+	l := &logrus.Logger{
+		Out:          io.Discard,
+		Hooks:        make(logrus.LevelHooks),
+		ReportCaller: false,
+		ExitFunc:     os.Exit,
+		Level:        logrus.InfoLevel,
+		Formatter:    &logrus.JSONFormatter{},
+	}
+
+	return logger.New(cfg, l)
+}
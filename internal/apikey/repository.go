@@ -0,0 +1,17 @@
+package apikey
+
+import (
+	"context"
+
+	"github.com/KarolosLykos/sportsnews/domain"
+)
+
+type Repository interface {
+	Create(ctx context.Context, key *domain.APIKey) (*domain.APIKey, error)
+	GetByID(ctx context.Context, id string) (*domain.APIKey, error)
+	// GetByHash returns the key with the hash, or domain.ErrInvalidAPIKey when there is none.
+	GetByHash(ctx context.Context, hash string) (*domain.APIKey, error)
+	List(ctx context.Context) (domain.APIKeys, error)
+	// Revoke revokes the key, which keeps the time it was first revoked at.
+	Revoke(ctx context.Context, id string) (*domain.APIKey, error)
+}
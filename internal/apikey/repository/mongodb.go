@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/KarolosLykos/sportsnews/domain"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+const (
+	sportsNewsDB      = "sportsnews"
+	apiKeysCollection = "apiKeys"
+)
+
+var (
+	ErrCreate    = errors.New("repository: create")
+	ErrGetByID   = errors.New("repository: getByID")
+	ErrGetByHash = errors.New("repository: getByHash")
+	ErrList      = errors.New("repository: list")
+	ErrRevoke    = errors.New("repository: revoke")
+	ErrIndexes   = errors.New("repository: indexes")
+)
+
+type mongoRepository struct {
+	logger logger.Logger
+	client *mongo.Client
+}
+
+func NewMongoRepository(client *mongo.Client, logger logger.Logger) *mongoRepository {
+	return &mongoRepository{
+		client: client,
+		logger: logger,
+	}
+}
+
+// EnsureIndexes creates the unique index of the hashes, which keys are looked up by, when it does
+// not exist yet.
+func (m *mongoRepository) EnsureIndexes(ctx context.Context) error {
+	index := mongo.IndexModel{
+		Keys:    bson.D{{Key: "hash", Value: 1}},
+		Options: options.Index().SetName("hash").SetUnique(true),
+	}
+
+	if _, err := m.collection().Indexes().CreateOne(ctx, index); err != nil {
+		return fmt.Errorf("%w:%v", ErrIndexes, err)
+	}
+
+	return nil
+}
+
+func (m *mongoRepository) Create(ctx context.Context, key *domain.APIKey) (*domain.APIKey, error) {
+	res, err := m.collection().InsertOne(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrCreate, err)
+	}
+
+	created := *key
+	if id, ok := res.InsertedID.(primitive.ObjectID); ok {
+		created.ID = id.Hex()
+	}
+
+	return &created, nil
+}
+
+func (m *mongoRepository) GetByID(ctx context.Context, id string) (*domain.APIKey, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrGetByID, err)
+	}
+
+	key := &domain.APIKey{}
+	if err = m.collection().FindOne(ctx, bson.M{"_id": objectID}).Decode(key); err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrGetByID, err)
+	}
+
+	return key, nil
+}
+
+func (m *mongoRepository) GetByHash(ctx context.Context, hash string) (*domain.APIKey, error) {
+	key := &domain.APIKey{}
+
+	err := m.collection().FindOne(ctx, bson.M{"hash": hash}).Decode(key)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, fmt.Errorf("%w:%v", domain.ErrInvalidAPIKey, err)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrGetByHash, err)
+	}
+
+	return key, nil
+}
+
+func (m *mongoRepository) List(ctx context.Context) (domain.APIKeys, error) {
+	cursor, err := m.collection().Find(ctx, bson.D{}, options.Find().SetSort(bson.D{{Key: "created", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrList, err)
+	}
+
+	keys := make(domain.APIKeys, 0)
+	if err = cursor.All(ctx, &keys); err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrList, err)
+	}
+
+	return keys, nil
+}
+
+// Revoke revokes the key. Revoking it again leaves it as it is.
+func (m *mongoRepository) Revoke(ctx context.Context, id string) (*domain.APIKey, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrRevoke, err)
+	}
+
+	if _, err = m.collection().UpdateOne(
+		ctx,
+		bson.M{"_id": objectID, "revoked": false},
+		bson.D{{Key: "$set", Value: bson.D{{Key: "revoked", Value: true}, {Key: "revokedAt", Value: time.Now().UTC()}}}},
+	); err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrRevoke, err)
+	}
+
+	key := &domain.APIKey{}
+	if err = m.collection().FindOne(ctx, bson.M{"_id": objectID}).Decode(key); err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrRevoke, err)
+	}
+
+	return key, nil
+}
+
+func (m *mongoRepository) collection() *mongo.Collection {
+	return m.client.Database(sportsNewsDB).Collection(apiKeysCollection)
+}
@@ -0,0 +1,17 @@
+package apikey
+
+import (
+	"context"
+
+	"github.com/KarolosLykos/sportsnews/domain"
+)
+
+type UseCase interface {
+	Create(ctx context.Context, key *domain.APIKey) (*domain.APIKey, error)
+	GetByID(ctx context.Context, id string) (*domain.APIKey, error)
+	List(ctx context.Context) (domain.APIKeys, error)
+	Revoke(ctx context.Context, id string) (*domain.APIKey, error)
+	Usage(ctx context.Context, id string, days int) (domain.APIKeyUsages, error)
+	// Authenticate returns the key, or domain.ErrInvalidAPIKey when it is unknown or revoked.
+	Authenticate(ctx context.Context, key string) (*domain.APIKey, error)
+}
@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+	"github.com/KarolosLykos/sportsnews/internal/apikey"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+const (
+	// keyPrefix marks the API keys, so that they are recognised when leaked.
+	keyPrefix = "snk_"
+	// shownPrefix is the number of leading characters of a key that are kept to recognise it.
+	shownPrefix = len(keyPrefix) + 8
+)
+
+var (
+	ErrCreate       = errors.New("usecase: create")
+	ErrGetByID      = errors.New("usecase: getByID")
+	ErrList         = errors.New("usecase: list")
+	ErrRevoke       = errors.New("usecase: revoke")
+	ErrUsage        = errors.New("usecase: usage")
+	ErrAuthenticate = errors.New("usecase: authenticate")
+)
+
+type apiKeyUseCase struct {
+	cfg        *config.Config
+	logger     logger.Logger
+	repository apikey.Repository
+	limiter    apikey.Limiter
+}
+
+func New(cfg *config.Config, logger logger.Logger, repository apikey.Repository, limiter apikey.Limiter) *apiKeyUseCase {
+	return &apiKeyUseCase{
+		cfg:        cfg,
+		logger:     logger,
+		repository: repository,
+		limiter:    limiter,
+	}
+}
+
+// Create stores the hash of a new key with the name and scopes of k. Limits left at zero get the
+// configured defaults. The key is returned with its plain text, which is not stored.
+func (u *apiKeyUseCase) Create(ctx context.Context, k *domain.APIKey) (*domain.APIKey, error) {
+	plain, err := newKey()
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrCreate, err)
+	}
+
+	key := &domain.APIKey{
+		Name:       k.Name,
+		Prefix:     plain[:shownPrefix],
+		Hash:       hash(plain),
+		Scopes:     k.Scopes,
+		RateLimit:  k.RateLimit,
+		DailyQuota: k.DailyQuota,
+		Created:    time.Now().UTC(),
+	}
+
+	if key.RateLimit == 0 {
+		key.RateLimit = u.cfg.Auth.RateLimit
+	}
+
+	if key.DailyQuota == 0 {
+		key.DailyQuota = u.cfg.Auth.DailyQuota
+	}
+
+	created, err := u.repository.Create(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrCreate, err)
+	}
+
+	created.Key = plain
+
+	return created, nil
+}
+
+func (u *apiKeyUseCase) GetByID(ctx context.Context, id string) (*domain.APIKey, error) {
+	k, err := u.repository.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrGetByID, err)
+	}
+
+	return k, nil
+}
+
+func (u *apiKeyUseCase) List(ctx context.Context) (domain.APIKeys, error) {
+	keys, err := u.repository.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrList, err)
+	}
+
+	return keys, nil
+}
+
+func (u *apiKeyUseCase) Revoke(ctx context.Context, id string) (*domain.APIKey, error) {
+	k, err := u.repository.Revoke(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrRevoke, err)
+	}
+
+	return k, nil
+}
+
+// Usage returns the requests made with the key, which must exist, on each of the last days.
+func (u *apiKeyUseCase) Usage(ctx context.Context, id string, days int) (domain.APIKeyUsages, error) {
+	if _, err := u.repository.GetByID(ctx, id); err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrUsage, err)
+	}
+
+	usage, err := u.limiter.Usage(ctx, id, days)
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrUsage, err)
+	}
+
+	return usage, nil
+}
+
+func (u *apiKeyUseCase) Authenticate(ctx context.Context, key string) (*domain.APIKey, error) {
+	k, err := u.repository.GetByHash(ctx, hash(key))
+	if errors.Is(err, domain.ErrInvalidAPIKey) {
+		return nil, err
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrAuthenticate, err)
+	}
+
+	if k.Revoked {
+		return nil, fmt.Errorf("%w:revoked", domain.ErrInvalidAPIKey)
+	}
+
+	return k, nil
+}
+
+// newKey returns a random API key.
+func newKey() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return keyPrefix + hex.EncodeToString(b), nil
+}
+
+// hash returns the hash keys are stored and looked up by. Keys are random, so they need no salt.
+func hash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return hex.EncodeToString(sum[:])
+}
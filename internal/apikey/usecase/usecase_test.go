@@ -0,0 +1,202 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+	mock "github.com/KarolosLykos/sportsnews/internal/apikey/mock"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+func TestAPIKeyUseCase_Create(t *testing.T) {
+	tt := []struct {
+		name       string
+		key        *domain.APIKey
+		rateLimit  int
+		dailyQuota int
+	}{
+		{
+			name:       "default limits",
+			key:        &domain.APIKey{Name: "partner", Scopes: []domain.Scope{domain.ScopeRead}},
+			rateLimit:  600,
+			dailyQuota: 100000,
+		},
+		{
+			name:       "own limits",
+			key:        &domain.APIKey{Name: "partner", Scopes: []domain.Scope{domain.ScopeRead}, RateLimit: 10, DailyQuota: 20},
+			rateLimit:  10,
+			dailyQuota: 20,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			var stored *domain.APIKey
+
+			repo := mock.NewMockRepository(ctrl)
+			repo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).
+				DoAndReturn(func(_ context.Context, k *domain.APIKey) (*domain.APIKey, error) {
+					stored = k
+
+					created := *k
+					created.ID = "6405f896a019b8815f6892c7"
+
+					return &created, nil
+				})
+
+			cfg := &config.Config{}
+			cfg.Auth.RateLimit = 600
+			cfg.Auth.DailyQuota = 100000
+
+			k, err := New(cfg, getLogger(), repo, mock.NewMockLimiter(ctrl)).Create(context.Background(), tc.key)
+			require.NoError(t, err)
+
+			assert.Equal(t, "6405f896a019b8815f6892c7", k.ID)
+			assert.Equal(t, "partner", k.Name)
+			assert.True(t, strings.HasPrefix(k.Key, keyPrefix))
+			assert.Len(t, k.Key, len(keyPrefix)+64)
+			assert.Equal(t, k.Key[:shownPrefix], k.Prefix)
+			assert.Equal(t, tc.rateLimit, k.RateLimit)
+			assert.Equal(t, tc.dailyQuota, k.DailyQuota)
+			assert.False(t, k.Created.IsZero())
+
+			assert.Empty(t, stored.Key)
+			assert.Equal(t, hash(k.Key), stored.Hash)
+		})
+	}
+}
+
+func TestAPIKeyUseCase_Authenticate(t *testing.T) {
+	tt := []struct {
+		name string
+		stub func(repo *mock.MockRepository)
+		err  error
+	}{
+		{
+			name: "ok",
+			stub: func(repo *mock.MockRepository) {
+				repo.EXPECT().GetByHash(gomock.Any(), hash("snk_1")).Times(1).Return(&domain.APIKey{ID: "1"}, nil)
+			},
+		},
+		{
+			name: "unknown",
+			stub: func(repo *mock.MockRepository) {
+				repo.EXPECT().GetByHash(gomock.Any(), hash("snk_1")).Times(1).Return(nil, domain.ErrInvalidAPIKey)
+			},
+			err: domain.ErrInvalidAPIKey,
+		},
+		{
+			name: "revoked",
+			stub: func(repo *mock.MockRepository) {
+				repo.EXPECT().GetByHash(gomock.Any(), hash("snk_1")).Times(1).Return(&domain.APIKey{ID: "1", Revoked: true}, nil)
+			},
+			err: domain.ErrInvalidAPIKey,
+		},
+		{
+			name: "error",
+			stub: func(repo *mock.MockRepository) {
+				repo.EXPECT().GetByHash(gomock.Any(), hash("snk_1")).Times(1).Return(nil, errors.New("something went wrong"))
+			},
+			err: ErrAuthenticate,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockRepository(ctrl)
+			tc.stub(repo)
+
+			k, err := New(&config.Config{}, getLogger(), repo, mock.NewMockLimiter(ctrl)).Authenticate(context.Background(), "snk_1")
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "1", k.ID)
+		})
+	}
+}
+
+func TestAPIKeyUseCase_Usage(t *testing.T) {
+	tt := []struct {
+		name string
+		stub func(repo *mock.MockRepository, limiter *mock.MockLimiter)
+		err  error
+	}{
+		{
+			name: "ok",
+			stub: func(repo *mock.MockRepository, limiter *mock.MockLimiter) {
+				repo.EXPECT().GetByID(gomock.Any(), "1").Times(1).Return(&domain.APIKey{ID: "1"}, nil)
+				limiter.EXPECT().Usage(gomock.Any(), "1", 7).Times(1).Return(domain.APIKeyUsages{{Date: "2023-03-06"}}, nil)
+			},
+		},
+		{
+			name: "not found",
+			stub: func(repo *mock.MockRepository, limiter *mock.MockLimiter) {
+				repo.EXPECT().GetByID(gomock.Any(), "1").Times(1).Return(nil, errors.New("no documents in result"))
+			},
+			err: ErrUsage,
+		},
+		{
+			name: "limiter error",
+			stub: func(repo *mock.MockRepository, limiter *mock.MockLimiter) {
+				repo.EXPECT().GetByID(gomock.Any(), "1").Times(1).Return(&domain.APIKey{ID: "1"}, nil)
+				limiter.EXPECT().Usage(gomock.Any(), "1", 7).Times(1).Return(nil, errors.New("something went wrong"))
+			},
+			err: ErrUsage,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockRepository(ctrl)
+			limiter := mock.NewMockLimiter(ctrl)
+			tc.stub(repo, limiter)
+
+			usage, err := New(&config.Config{}, getLogger(), repo, limiter).Usage(context.Background(), "1", 7)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Len(t, usage, 1)
+		})
+	}
+}
+
+func getLogger() logger.Logger {
+	cfg := &config.Config{}
+
+	l := &logrus.Logger{
+		Out:          io.Discard,
+		Hooks:        make(logrus.LevelHooks),
+		ReportCaller: false,
+		ExitFunc:     os.Exit,
+		Level:        logrus.InfoLevel,
+		Formatter:    &logrus.JSONFormatter{},
+	}
+
+	return logger.New(cfg, l)
+}
@@ -2,8 +2,10 @@ package article
 
 import (
 	"context"
+
+	"github.com/KarolosLykos/sportsnews/domain"
 )
 
 type Consumer interface {
-	Consume(ctx context.Context)
+	Consume(ctx context.Context) (*domain.SyncResult, error)
 }
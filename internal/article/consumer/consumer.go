@@ -5,29 +5,35 @@ import (
 	"encoding/xml"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"sync"
+	"time"
 
 	"github.com/KarolosLykos/sportsnews/config"
 	"github.com/KarolosLykos/sportsnews/domain"
 	"github.com/KarolosLykos/sportsnews/internal/article"
+	"github.com/KarolosLykos/sportsnews/internal/provider"
 	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+	"github.com/KarolosLykos/sportsnews/internal/utils/metrics"
 )
 
 var (
 	ErrGetByID   = errors.New("consumer: getByID")
 	ErrList      = errors.New("consumer: list")
 	ErrBadStatus = errors.New("bad status code")
-
-	maxWorkers = 30
 )
 
+const maxWorkers = 30
+
 type HullCityConsumer struct {
 	cfg        *config.Config
 	logger     logger.Logger
 	client     *http.Client
 	repository article.Repository
 	cache      article.Cache
+	health     provider.HealthTracker
 }
 
 func NewHullCityConsumer(
@@ -36,6 +42,7 @@ func NewHullCityConsumer(
 	client *http.Client,
 	repository article.Repository,
 	cache article.Cache,
+	health provider.HealthTracker,
 ) *HullCityConsumer {
 	return &HullCityConsumer{
 		cfg:        cfg,
@@ -43,113 +50,268 @@ func NewHullCityConsumer(
 		client:     client,
 		repository: repository,
 		cache:      cache,
+		health:     health,
 	}
 }
 
-func (c *HullCityConsumer) GetByID(ctx context.Context, id string) (*domain.HullArticleInformation, error) {
+func (c *HullCityConsumer) GetByID(ctx context.Context, id string) (_ *domain.HullArticleInformation, err error) {
+	defer func() { c.health.RecordRequest(domain.HullCityProvider, err) }()
+
 	uri := c.cfg.Consumer.HullConsumer.SingleURL + "?id=" + id
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
 	if err != nil {
 		return nil, fmt.Errorf("%w:%v", ErrGetByID, err)
 	}
 
-	res, err := c.client.Do(req)
+	res, err := c.do(req, "getByID")
 	if err != nil {
 		return nil, fmt.Errorf("%w:%v", ErrGetByID, err)
 	}
 
+	defer res.Body.Close()
+
 	if res.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("%w:%v", ErrBadStatus, res.Status)
 	}
 
-	defer res.Body.Close()
+	maxSize := c.cfg.Consumer.HullConsumer.MaxBodySize
+	if err = checkSize(res.ContentLength, maxSize); err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrGetByID, err)
+	}
 
 	hullArticle := &domain.HullArticleInformation{}
-	if err = xml.NewDecoder(res.Body).Decode(hullArticle); err != nil {
+	if err = newDecoder(res.Body, maxSize).Decode(hullArticle); err != nil {
 		return nil, fmt.Errorf("%w:%v", ErrGetByID, err)
 	}
 
 	return hullArticle, nil
 }
 
+// List returns the whole article list of the provider.
 func (c *HullCityConsumer) List(ctx context.Context) (*domain.HullArticles, error) {
+	hullArticles := &domain.HullArticles{}
+
+	feed, err := c.Stream(ctx, func(_ *domain.HullArticles, item domain.HullArticle) error {
+		hullArticles.NewsletterNewsItems.NewsletterNewsItem = append(
+			hullArticles.NewsletterNewsItems.NewsletterNewsItem,
+			item,
+		)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	hullArticles.ClubName = feed.ClubName
+	hullArticles.ClubWebsiteURL = feed.ClubWebsiteURL
+
+	return hullArticles, nil
+}
+
+// Stream decodes the article list token by token and calls fn with each NewsletterNewsItem
+// as soon as it is parsed, together with the club details read so far.
+// The returned feed holds the club details only.
+func (c *HullCityConsumer) Stream(
+	ctx context.Context,
+	fn func(feed *domain.HullArticles, item domain.HullArticle) error,
+) (_ *domain.HullArticles, err error) {
+	defer func() { c.health.RecordRequest(domain.HullCityProvider, err) }()
+
 	uri := c.cfg.Consumer.HullConsumer.ListURL + "?count=" + strconv.Itoa(c.cfg.Consumer.HullConsumer.Count)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
 	if err != nil {
 		return nil, fmt.Errorf("%w:%v", ErrList, err)
 	}
 
-	res, err := c.client.Do(req)
+	res, err := c.do(req, "list")
 	if err != nil {
 		return nil, fmt.Errorf("%w:%v", ErrList, err)
 	}
 
+	defer res.Body.Close()
+
 	if res.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("%w:%v", ErrBadStatus, res.Status)
 	}
 
-	defer res.Body.Close()
-
-	hullArticles := &domain.HullArticles{}
-	if err = xml.NewDecoder(res.Body).Decode(hullArticles); err != nil {
+	maxSize := c.cfg.Consumer.HullConsumer.MaxBodySize
+	if err = checkSize(res.ContentLength, maxSize); err != nil {
 		return nil, fmt.Errorf("%w:%v", ErrList, err)
 	}
 
-	return hullArticles, nil
+	feed := &domain.HullArticles{}
+	decoder := newDecoder(res.Body, maxSize)
+	root := false
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%w:%v", ErrList, err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		root = true
+
+		switch start.Name.Local {
+		case "ClubName":
+			err = decoder.DecodeElement(&feed.ClubName, &start)
+		case "ClubWebsiteURL":
+			err = decoder.DecodeElement(&feed.ClubWebsiteURL, &start)
+		case "NewsletterNewsItem":
+			item := domain.HullArticle{}
+			if err = decoder.DecodeElement(&item, &start); err == nil {
+				err = fn(feed, item)
+			}
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%w:%v", ErrList, err)
+		}
+	}
+
+	if !root {
+		return nil, fmt.Errorf("%w:%v", ErrList, io.ErrUnexpectedEOF)
+	}
+
+	return feed, nil
+}
+
+// do sends the request to the provider and records its metrics.
+func (c *HullCityConsumer) do(req *http.Request, operation string) (*http.Response, error) {
+	start := time.Now()
+
+	res, err := c.client.Do(req)
+	metrics.ObserveProviderRequest(domain.HullCityProvider, operation, res, start)
+
+	return res, err
 }
 
 // Consume consumes feeds from the Hull City Fc external provider.
-func (c *HullCityConsumer) Consume(ctx context.Context) {
-	hullArticles, err := c.List(ctx)
-	if err != nil {
-		c.logger.Error(ctx, err)
-		return
-	}
+// Article items are handed to the workers while the list is still being decoded.
+func (c *HullCityConsumer) Consume(ctx context.Context) (*domain.SyncResult, error) {
+	result := &domain.SyncResult{Provider: domain.HullCityProvider, Started: time.Now()}
 
-	jobs := make(chan domain.HullArticle, len(hullArticles.NewsletterNewsItems.NewsletterNewsItem))
+	jobs := make(chan job, maxWorkers)
 
-	if len(hullArticles.NewsletterNewsItems.NewsletterNewsItem) < maxWorkers {
-		maxWorkers = len(hullArticles.NewsletterNewsItems.NewsletterNewsItem)
-	}
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
 
 	// Start workers to process each article item.
 	for w := 0; w < maxWorkers; w++ {
-		go c.worker(ctx, jobs, hullArticles.ClubName, hullArticles.ClubWebsiteURL)
-	}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.worker(ctx, jobs, func(change domain.Change, err error) {
+				mu.Lock()
+				defer mu.Unlock()
+
+				if err != nil {
+					result.Failed++
+					return
+				}
 
-	// Send each article item to the job channel.
-	for _, h := range hullArticles.NewsletterNewsItems.NewsletterNewsItem {
-		jobs <- h
+				result.Add(change)
+			})
+		}()
 	}
 
+	// Send each article item to the job channel as soon as it is decoded.
+	_, err := c.Stream(ctx, func(feed *domain.HullArticles, item domain.HullArticle) error {
+		select {
+		case jobs <- job{item: item, clubName: feed.ClubName, clubURL: feed.ClubWebsiteURL}:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+
 	close(jobs)
+	wg.Wait()
+
+	if err != nil {
+		return nil, err
+	}
+
+	result.Duration = time.Since(result.Started)
+
+	return result, nil
+}
+
+// job is a single article item to process.
+type job struct {
+	item     domain.HullArticle
+	clubName string
+	clubURL  string
 }
 
-func (c *HullCityConsumer) worker(ctx context.Context, jobs <-chan domain.HullArticle, clubName, clubURL string) {
+func (c *HullCityConsumer) worker(
+	ctx context.Context,
+	jobs <-chan job,
+	done func(change domain.Change, err error),
+) {
 	for j := range jobs {
-		c.logger.Debugf(ctx, "processing job for article %s", j.NewsArticleID)
+		c.logger.Debugf(ctx, "processing job for article %s", j.item.NewsArticleID)
 
-		item, err := c.GetByID(ctx, j.NewsArticleID)
+		change, err := c.process(ctx, j.item, j.clubName, j.clubURL)
 		if err != nil {
 			c.logger.Warn(ctx, err)
-			continue
 		}
 
-		a := j.ToDomain(
-			clubName,
-			clubURL,
-			item.NewsArticle.BodyText,
-			item.NewsArticle.Subtitle,
-		)
+		done(change, err)
+	}
+}
 
-		updatedArticle, err := c.repository.Upsert(ctx, a)
-		if err != nil {
-			c.logger.Warn(ctx, err)
-			continue
-		}
+// process fetches the details of a single article item and stores it.
+func (c *HullCityConsumer) process(
+	ctx context.Context,
+	j domain.HullArticle,
+	clubName, clubURL string,
+) (domain.Change, error) {
+	item, err := c.GetByID(ctx, j.NewsArticleID)
+	if err != nil {
+		return "", err
+	}
 
-		if err = c.cache.Set(ctx, updatedArticle); err != nil {
+	a := j.ToDomain(
+		clubName,
+		clubURL,
+		item.NewsArticle.BodyText,
+		item.NewsArticle.Subtitle,
+	)
+
+	updatedArticle, change, err := c.repository.Upsert(ctx, a)
+	if err != nil {
+		return "", err
+	}
+
+	if change == domain.ChangeCreated {
+		c.health.RecordIngestion(domain.HullCityProvider, a.Published, time.Now())
+	}
+
+	// Hidden articles are still stored by the sync, but must not be served from the cache, which
+	// does not keep the mark.
+	if updatedArticle.Hidden {
+		if err = c.cache.Delete(ctx, updatedArticle.ID); err != nil {
 			c.logger.Warn(ctx, err)
 		}
+
+		return change, nil
 	}
+
+	if err = c.cache.Set(ctx, updatedArticle); err != nil {
+		c.logger.Warn(ctx, err)
+	}
+
+	return change, nil
 }
@@ -2,6 +2,7 @@ package consumer
 
 import (
 	"context"
+	"errors"
 	"io"
 	"net/http"
 	"os"
@@ -16,6 +17,7 @@ import (
 	"github.com/KarolosLykos/sportsnews/config"
 	"github.com/KarolosLykos/sportsnews/domain"
 	mock "github.com/KarolosLykos/sportsnews/internal/article/mock"
+	"github.com/KarolosLykos/sportsnews/internal/provider/health"
 	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
 )
 
@@ -74,7 +76,7 @@ func TestHullCityConsumer_GetByID(t *testing.T) {
 
 			httpmock.RegisterResponder(http.MethodGet, "single?id="+tc.id, tc.responder)
 
-			c := NewHullCityConsumer(cfg, log, &http.Client{}, repo, cache)
+			c := NewHullCityConsumer(cfg, log, &http.Client{}, repo, cache, health.NewTracker())
 
 			a, err := c.GetByID(context.Background(), tc.id)
 			if err != nil && tc.err != nil {
@@ -145,7 +147,7 @@ func TestHullCityConsumer_List(t *testing.T) {
 
 			httpmock.RegisterResponder(http.MethodGet, "list?count=3", tc.responder)
 
-			c := NewHullCityConsumer(cfg, log, &http.Client{}, repo, cache)
+			c := NewHullCityConsumer(cfg, log, &http.Client{}, repo, cache, health.NewTracker())
 
 			a, err := c.List(context.Background())
 			if err != nil && tc.err != nil {
@@ -159,6 +161,97 @@ func TestHullCityConsumer_List(t *testing.T) {
 	}
 }
 
+func TestHullCityConsumer_Consume(t *testing.T) {
+	log := getLogger()
+
+	cfg := &config.Config{Consumer: config.ConsumerConfig{HullConsumer: config.HullConsumer{
+		SingleURL: "single",
+		ListURL:   "list",
+		Count:     3,
+	}}}
+
+	tt := []struct {
+		name      string
+		responder httpmock.Responder
+		repoStub  func(repo *mock.MockRepository)
+		cacheStub func(cache *mock.MockCache)
+		expected  *domain.SyncResult
+		err       error
+	}{
+		{
+			name:      "ok",
+			responder: httpmock.NewStringResponder(http.StatusOK, testXMLListIDs),
+			repoStub: func(repo *mock.MockRepository) {
+				repo.EXPECT().Upsert(gomock.Any(), gomock.Any()).Times(1).Return(&domain.Article{}, domain.ChangeCreated, nil)
+				repo.EXPECT().Upsert(gomock.Any(), gomock.Any()).Times(1).Return(&domain.Article{}, domain.ChangeUpdated, nil)
+				repo.EXPECT().Upsert(gomock.Any(), gomock.Any()).Times(1).Return(nil, domain.Change(""), errors.New("generic error"))
+			},
+			cacheStub: func(cache *mock.MockCache) {
+				cache.EXPECT().Set(gomock.Any(), gomock.Any()).Times(2).Return(nil)
+			},
+			expected: &domain.SyncResult{Provider: domain.HullCityProvider, Created: 1, Updated: 1, Failed: 1},
+		},
+		{
+			name:      "hidden article synced again",
+			responder: httpmock.NewStringResponder(http.StatusOK, testXMLListIDs),
+			repoStub: func(repo *mock.MockRepository) {
+				repo.EXPECT().Upsert(gomock.Any(), gomock.Any()).Times(1).
+					Return(&domain.Article{ID: "6405f896a019b8815f6892c7", Hidden: true}, domain.ChangeUpdated, nil)
+				repo.EXPECT().Upsert(gomock.Any(), gomock.Any()).Times(2).Return(&domain.Article{}, domain.ChangeUnchanged, nil)
+			},
+			cacheStub: func(cache *mock.MockCache) {
+				cache.EXPECT().Delete(gomock.Any(), "6405f896a019b8815f6892c7").Times(1).Return(nil)
+				cache.EXPECT().Set(gomock.Any(), gomock.Any()).Times(2).
+					Do(func(_ context.Context, a *domain.Article) {
+						assert.False(t, a.Hidden)
+					}).Return(nil)
+			},
+			expected: &domain.SyncResult{Provider: domain.HullCityProvider, Updated: 1, Unchanged: 2},
+		},
+		{
+			name:      "list error",
+			responder: httpmock.NewStringResponder(http.StatusBadRequest, "{}"),
+			repoStub:  func(repo *mock.MockRepository) {},
+			cacheStub: func(cache *mock.MockCache) {},
+			err:       ErrBadStatus,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockRepository(ctrl)
+			cache := mock.NewMockCache(ctrl)
+
+			tc.repoStub(repo)
+			tc.cacheStub(cache)
+
+			httpmock.RegisterResponder(http.MethodGet, "list?count=3", tc.responder)
+			httpmock.RegisterResponder(http.MethodGet, `=~^single\?id=\d+`, httpmock.NewStringResponder(http.StatusOK, testXMLSingle))
+
+			c := NewHullCityConsumer(cfg, log, &http.Client{}, repo, cache, health.NewTracker())
+
+			res, err := c.Consume(context.Background())
+			if err != nil && tc.err != nil {
+				assert.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, res)
+				assert.Equal(t, tc.expected.Provider, res.Provider)
+				assert.Equal(t, tc.expected.Created, res.Created)
+				assert.Equal(t, tc.expected.Updated, res.Updated)
+				assert.Equal(t, tc.expected.Unchanged, res.Unchanged)
+				assert.Equal(t, tc.expected.Failed, res.Failed)
+			}
+		})
+	}
+}
+
 func getLogger() logger.Logger {
 	cfg := &config.Config{}
 
@@ -203,5 +296,24 @@ var (
 <Title>test title3</Title>
 </NewsletterNewsItem>
 </NewsletterNewsItems>
+</NewListInformation>`
+
+	testXMLListIDs = `<NewListInformation>
+<ClubName>clubname</ClubName>
+<ClubWebsiteURL>club.com</ClubWebsiteURL>
+<NewsletterNewsItems>
+<NewsletterNewsItem>
+<NewsArticleID>1</NewsArticleID>
+<Title>test title1</Title>
+</NewsletterNewsItem>
+<NewsletterNewsItem>
+<NewsArticleID>2</NewsArticleID>
+<Title>test title2</Title>
+</NewsletterNewsItem>
+<NewsletterNewsItem>
+<NewsArticleID>3</NewsArticleID>
+<Title>test title3</Title>
+</NewsletterNewsItem>
+</NewsletterNewsItems>
 </NewListInformation>`
 )
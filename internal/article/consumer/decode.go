@@ -0,0 +1,63 @@
+package consumer
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/net/html/charset"
+)
+
+var ErrBodyTooLarge = errors.New("consumer: response body too large")
+
+// newDecoder returns an XML decoder that reads at most maxSize bytes from r
+// and understands the charsets declared by the feed, e.g. ISO-8859-1 or Windows-1252.
+// A maxSize of zero disables the limit.
+func newDecoder(r io.Reader, maxSize int64) *xml.Decoder {
+	if maxSize > 0 {
+		r = &limitedReader{r: r, n: maxSize}
+	}
+
+	d := xml.NewDecoder(r)
+	d.CharsetReader = charset.NewReaderLabel
+
+	return d
+}
+
+// limitedReader reads from r until n bytes are left, then fails with ErrBodyTooLarge.
+// Unlike io.LimitReader it does not silently truncate the body.
+type limitedReader struct {
+	r io.Reader
+	n int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.n <= 0 {
+		// Make sure the body really is larger than the limit.
+		var b [1]byte
+		if n, _ := l.r.Read(b[:]); n > 0 {
+			return 0, ErrBodyTooLarge
+		}
+
+		return 0, io.EOF
+	}
+
+	if int64(len(p)) > l.n {
+		p = p[:l.n]
+	}
+
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+
+	return n, err
+}
+
+// checkSize rejects responses that declare a body larger than maxSize.
+func checkSize(contentLength, maxSize int64) error {
+	if maxSize > 0 && contentLength > maxSize {
+		return fmt.Errorf("%w:%d bytes", ErrBodyTooLarge, contentLength)
+	}
+
+	return nil
+}
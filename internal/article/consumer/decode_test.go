@@ -0,0 +1,71 @@
+package consumer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/sportsnews/domain"
+)
+
+func TestNewDecoder(t *testing.T) {
+	tt := []struct {
+		name     string
+		body     string
+		maxSize  int64
+		expected string
+		err      error
+	}{
+		{
+			name:     "utf-8",
+			body:     `<?xml version="1.0" encoding="UTF-8"?><NewsArticleInformation><NewsArticle><Title>Café</Title></NewsArticle></NewsArticleInformation>`,
+			expected: "Café",
+		},
+		{
+			name:     "iso-8859-1",
+			body:     "<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?><NewsArticleInformation><NewsArticle><Title>Caf\xe9</Title></NewsArticle></NewsArticleInformation>",
+			expected: "Café",
+		},
+		{
+			name:     "windows-1252",
+			body:     "<?xml version=\"1.0\" encoding=\"windows-1252\"?><NewsArticleInformation><NewsArticle><Title>\x93Tigers\x94</Title></NewsArticle></NewsArticleInformation>",
+			expected: "\u201cTigers\u201d",
+		},
+		{
+			name:     "within limit",
+			body:     `<NewsArticleInformation><NewsArticle><Title>title</Title></NewsArticle></NewsArticleInformation>`,
+			maxSize:  100,
+			expected: "title",
+		},
+		{
+			name:    "too large",
+			body:    `<NewsArticleInformation><NewsArticle><Title>title</Title></NewsArticle></NewsArticleInformation>`,
+			maxSize: 50,
+			err:     ErrBodyTooLarge,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			a := &domain.HullArticleInformation{}
+
+			err := newDecoder(strings.NewReader(tc.body), tc.maxSize).Decode(a)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, a.NewsArticle.Title)
+		})
+	}
+}
+
+func TestCheckSize(t *testing.T) {
+	assert.NoError(t, checkSize(-1, 10))
+	assert.NoError(t, checkSize(10, 10))
+	assert.NoError(t, checkSize(100, 0))
+	assert.ErrorIs(t, checkSize(11, 10), ErrBodyTooLarge)
+}
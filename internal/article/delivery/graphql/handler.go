@@ -0,0 +1,120 @@
+package graphql
+
+import (
+	_ "embed" // the schema.
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	graphqlgo "github.com/graph-gophers/graphql-go"
+	"github.com/labstack/echo/v4"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/internal/article"
+	httperrors "github.com/KarolosLykos/sportsnews/internal/utils/http_errors"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+const mimeEventStream = "text/event-stream"
+
+//go:embed schema.graphql
+var schemaString string
+
+type graphqlHandler struct {
+	logger logger.Logger
+	uc     article.UseCase
+	schema *graphqlgo.Schema
+}
+
+func NewGraphQLHandler(cfg *config.Config, logger logger.Logger, uc article.UseCase, events article.Events) *graphqlHandler {
+	return &graphqlHandler{
+		logger: logger,
+		uc:     uc,
+		schema: graphqlgo.MustParseSchema(
+			schemaString,
+			&resolver{uc: uc, events: events},
+			graphqlgo.MaxDepth(cfg.GraphQL.MaxDepth),
+		),
+	}
+}
+
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Query executes the GraphQL request of the body, or of the query parameters of a GET.
+// Requests that accept text/event-stream receive the responses of a subscription as
+// Server-Sent Events, a next event for each of them and a complete event at the end.
+func (h *graphqlHandler) Query() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req, err := parseRequest(c)
+		if err != nil {
+			return httperrors.ErrorResponse(c, fmt.Errorf("%w:%v", httperrors.ErrBadRequest, err))
+		}
+
+		ctx := withLoaders(c.Request().Context(), newLoaders(h.uc))
+
+		if !strings.Contains(c.Request().Header.Get(echo.HeaderAccept), mimeEventStream) {
+			return c.JSON(http.StatusOK, h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables))
+		}
+
+		responses, err := h.schema.Subscribe(ctx, req.Query, req.OperationName, req.Variables)
+		if err != nil {
+			return httperrors.ErrorResponse(c, err)
+		}
+
+		res := c.Response()
+		res.Header().Set(echo.HeaderContentType, mimeEventStream)
+		res.Header().Set(echo.HeaderCacheControl, "no-cache")
+		res.Header().Set("X-Accel-Buffering", "no")
+		res.WriteHeader(http.StatusOK)
+		res.Flush()
+
+		for resp := range responses {
+			data, err := json.Marshal(resp)
+			if err != nil {
+				h.logger.Warn(ctx, err, "could not marshal graphql response")
+				continue
+			}
+
+			if _, err = fmt.Fprintf(res, "event: next\ndata: %s\n\n", data); err != nil {
+				return nil
+			}
+
+			res.Flush()
+		}
+
+		if _, err = fmt.Fprint(res, "event: complete\ndata:\n\n"); err == nil {
+			res.Flush()
+		}
+
+		return nil
+	}
+}
+
+func parseRequest(c echo.Context) (*request, error) {
+	req := &request{}
+
+	if c.Request().Method == http.MethodGet {
+		req.Query = c.QueryParam("query")
+		req.OperationName = c.QueryParam("operationName")
+
+		if v := c.QueryParam("variables"); v != "" {
+			if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+				return nil, fmt.Errorf("variables: %v", err)
+			}
+		}
+	} else if err := json.NewDecoder(c.Request().Body).Decode(req); err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(req.Query) == "" {
+		return nil, errors.New("query is required")
+	}
+
+	return req, nil
+}
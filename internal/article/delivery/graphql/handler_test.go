@@ -0,0 +1,266 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+	mock "github.com/KarolosLykos/sportsnews/internal/article/mock"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+func TestGraphQLHandler_Query(t *testing.T) {
+	published := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+	first := &domain.Article{ID: "1", TeamID: "Hull City", OptaMatchID: "g1", Title: "first", Published: published}
+	second := &domain.Article{ID: "2", TeamID: "Hull City", OptaMatchID: "g2", Title: "second", Published: published}
+	third := &domain.Article{ID: "3", TeamID: "Hull City", OptaMatchID: "g1", Title: "third", Published: published}
+
+	tt := []struct {
+		name   string
+		method string
+		query  string
+		ucStub func(uc *mock.MockUseCase)
+		code   int
+		want   string
+	}{
+		{
+			name:  "articles",
+			query: `{ articles(filter: {teamId: "Hull City", typeMatch: ALL}, first: 2) { totalCount edges { node { title team { id } } } pageInfo { hasNextPage hasPreviousPage } } }`,
+			ucStub: func(uc *mock.MockUseCase) {
+				uc.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, params domain.ListParams) (*domain.Articles, error) {
+						assert.Equal(t, 2, params.Limit)
+						assert.Equal(t, "Hull City", params.Filter.TeamID)
+						assert.Equal(t, domain.TypeMatchAll, params.Filter.TypeMatch)
+
+						next := domain.NewCursor(domain.DefaultSort, second, false)
+
+						return &domain.Articles{Total: 3, Articles: []*domain.Article{first, second}, Next: next}, nil
+					})
+			},
+			code: http.StatusOK,
+			want: `{"data":{"articles":{"totalCount":3,"edges":[` +
+				`{"node":{"title":"first","team":{"id":"Hull City"}}},{"node":{"title":"second","team":{"id":"Hull City"}}}],` +
+				`"pageInfo":{"hasNextPage":true,"hasPreviousPage":false}}}}`,
+		},
+		{
+			name:   "articles over get",
+			method: http.MethodGet,
+			query:  `{ articles { totalCount } }`,
+			ucStub: func(uc *mock.MockUseCase) {
+				uc.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).Return(&domain.Articles{Total: 0}, nil)
+			},
+			code: http.StatusOK,
+			want: `{"data":{"articles":{"totalCount":0}}}`,
+		},
+		{
+			name:  "team feed",
+			query: `{ team(id: "Hull City") { feed(first: 1) { edges { node { title } } } } }`,
+			ucStub: func(uc *mock.MockUseCase) {
+				uc.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, params domain.ListParams) (*domain.Articles, error) {
+						assert.Equal(t, "Hull City", params.Filter.TeamID)
+						require.NotNil(t, params.Filter.IsPublished)
+						assert.True(t, *params.Filter.IsPublished)
+
+						return &domain.Articles{Total: 1, Articles: []*domain.Article{first}}, nil
+					})
+			},
+			code: http.StatusOK,
+			want: `{"data":{"team":{"feed":{"edges":[{"node":{"title":"first"}}]}}}}`,
+		},
+		{
+			name:  "articles by id are read at once",
+			query: `{ a: article(id: "1") { title } b: article(id: "2") { title } c: article(id: "4") { title } }`,
+			ucStub: func(uc *mock.MockUseCase) {
+				uc.EXPECT().GetByIDs(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, ids []string) ([]*domain.Article, error) {
+						assert.ElementsMatch(t, []string{"1", "2", "4"}, ids)
+
+						return []*domain.Article{first, second}, nil
+					})
+			},
+			code: http.StatusOK,
+			want: `{"data":{"a":{"title":"first"},"b":{"title":"second"},"c":null}}`,
+		},
+		{
+			name:  "articles of matches are read at once",
+			query: `{ a: article(id: "1") { match { id articles { title } } } b: article(id: "2") { match { id articles { title } } } }`,
+			ucStub: func(uc *mock.MockUseCase) {
+				uc.EXPECT().GetByIDs(gomock.Any(), gomock.Any()).Times(1).Return([]*domain.Article{first, second}, nil)
+				uc.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, params domain.ListParams) (*domain.Articles, error) {
+						assert.ElementsMatch(t, []string{"g1", "g2"}, params.Filter.OptaMatchIDs)
+
+						return &domain.Articles{Total: 3, Articles: []*domain.Article{first, second, third}}, nil
+					})
+			},
+			code: http.StatusOK,
+			want: `{"data":{` +
+				`"a":{"match":{"id":"g1","articles":[{"title":"first"},{"title":"third"}]}},` +
+				`"b":{"match":{"id":"g2","articles":[{"title":"second"}]}}}}`,
+		},
+		{
+			name:  "article by slug not found",
+			query: `{ article(slug: "missing") { title } }`,
+			ucStub: func(uc *mock.MockUseCase) {
+				uc.EXPECT().GetBySlug(gomock.Any(), "missing").Times(1).Return(nil, errors.New("no documents in result"))
+			},
+			code: http.StatusOK,
+			want: `{"data":{"article":null}}`,
+		},
+		{
+			name:  "article by slug error",
+			query: `{ article(slug: "hall") { title } }`,
+			ucStub: func(uc *mock.MockUseCase) {
+				uc.EXPECT().GetBySlug(gomock.Any(), "hall").Times(1).Return(nil, errors.New("generic error"))
+			},
+			code: http.StatusOK,
+			want: `{"errors":[{"message":"generic error","path":["article"]}],"data":{"article":null}}`,
+		},
+		{
+			name:  "search",
+			query: `{ search(query: "hall", first: 1) { totalCount edges { cursor score highlights { field fragments } node { title } } pageInfo { hasNextPage } } }`,
+			ucStub: func(uc *mock.MockUseCase) {
+				uc.EXPECT().Search(gomock.Any(), gomock.Any()).Times(1).Return(&domain.SearchResults{
+					Total: 2,
+					Hits: []*domain.SearchHit{{
+						Article:    first,
+						Score:      1.5,
+						Highlights: map[string][]string{"title": {"<mark>hall</mark>"}},
+					}},
+				}, nil)
+			},
+			code: http.StatusOK,
+			want: `{"data":{"search":{"totalCount":2,"edges":[` +
+				`{"cursor":"` + encodeOffset(0) + `","score":1.5,"highlights":[{"field":"title","fragments":["<mark>hall</mark>"]}],"node":{"title":"first"}}],` +
+				`"pageInfo":{"hasNextPage":true}}}}`,
+		},
+		{
+			name:   "first and last",
+			query:  `{ articles(first: 1, last: 1, before: "x") { totalCount } }`,
+			ucStub: func(uc *mock.MockUseCase) {},
+			code:   http.StatusOK,
+			want:   `{"errors":[{"message":"first and last are mutually exclusive","path":["articles"]}],"data":null}`,
+		},
+		{
+			name:   "too deep",
+			query:  `{ articles { edges { node { team { feed { edges { node { team { feed { totalCount } } } } } } } } } }`,
+			ucStub: func(uc *mock.MockUseCase) {},
+			code:   http.StatusOK,
+			want:   `exceeds max depth 5`,
+		},
+		{
+			name:   "no query",
+			ucStub: func(uc *mock.MockUseCase) {},
+			code:   http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := mock.NewMockUseCase(ctrl)
+			tc.ucStub(uc)
+
+			var req *http.Request
+			if tc.method == http.MethodGet {
+				req = httptest.NewRequest(http.MethodGet, "/graphql?query="+url.QueryEscape(tc.query), nil)
+			} else {
+				body, _ := json.Marshal(request{Query: tc.query})
+				req = httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body)))
+			}
+
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+
+			require.NoError(t, NewGraphQLHandler(getConfig(), getLogger(), uc, nil).Query()(c))
+			assert.Equal(t, tc.code, rec.Code)
+
+			if tc.want == "" {
+				return
+			}
+
+			if strings.HasPrefix(tc.want, "{") {
+				assert.JSONEq(t, tc.want, rec.Body.String())
+			} else {
+				assert.Contains(t, rec.Body.String(), tc.want)
+			}
+		})
+	}
+}
+
+func TestGraphQLHandler_Subscription(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	events := make(chan *domain.ArticleEvent, 2)
+	events <- &domain.ArticleEvent{ID: 1, Type: domain.EventCreated, Article: &domain.Article{ID: "1", TeamID: "Leeds", Title: "first"}}
+	events <- &domain.ArticleEvent{ID: 2, Type: domain.EventUpdated, Article: &domain.Article{ID: "2", TeamID: "Hull City", Title: "second"}}
+	close(events)
+
+	ev := mock.NewMockEvents(ctrl)
+	ev.EXPECT().Subscribe(gomock.Any()).Times(1).Return(events)
+
+	body, _ := json.Marshal(request{Query: `subscription { articleEvents(filter: {teamId: "Hull City"}) { id type article { title } } }`})
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body)))
+	req.Header.Set(echo.HeaderAccept, mimeEventStream)
+
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	require.NoError(t, NewGraphQLHandler(getConfig(), getLogger(), mock.NewMockUseCase(ctrl), ev).Query()(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, mimeEventStream, rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(t,
+		"event: next\ndata: {\"data\":{\"articleEvents\":{\"id\":\"2\",\"type\":\"UPDATED\",\"article\":{\"title\":\"second\"}}}}\n\n"+
+			"event: complete\ndata:\n\n",
+		rec.Body.String(),
+	)
+}
+
+func TestDecodeOffset(t *testing.T) {
+	offset, err := decodeOffset(encodeOffset(42))
+	require.NoError(t, err)
+	assert.Equal(t, 42, offset)
+
+	_, err = decodeOffset(domain.NewCursor(domain.DefaultSort, &domain.Article{ID: "1"}, false).Encode())
+	assert.ErrorIs(t, err, domain.ErrInvalidCursor)
+}
+
+func getConfig() *config.Config {
+	return &config.Config{GraphQL: config.GraphQLConfig{MaxDepth: 5}}
+}
+
+func getLogger() logger.Logger {
+	cfg := &config.Config{}
+
+	l := &logrus.Logger{
+		Out:          io.Discard,
+		Hooks:        make(logrus.LevelHooks),
+		ReportCaller: false,
+		ExitFunc:     os.Exit,
+		Level:        logrus.InfoLevel,
+		Formatter:    &logrus.JSONFormatter{},
+	}
+
+	return logger.New(cfg, l)
+}
@@ -0,0 +1,117 @@
+package graphql
+
+import (
+	"context"
+	"time"
+
+	"github.com/graph-gophers/dataloader/v7"
+
+	"github.com/KarolosLykos/sportsnews/domain"
+	"github.com/KarolosLykos/sportsnews/internal/article"
+)
+
+// loaderWait is how long loaders collect the keys of a query before they read them at once.
+const loaderWait = 2 * time.Millisecond
+
+type loadersKey struct{}
+
+// loaders batch the reads of a request, so that a query reads the articles it refers to by id, and
+// those of the matches it refers to, in one call each instead of one per reference.
+type loaders struct {
+	articles *dataloader.Loader[string, *domain.Article]
+	matches  *dataloader.Loader[string, []*domain.Article]
+}
+
+func newLoaders(uc article.UseCase) *loaders {
+	return &loaders{
+		articles: dataloader.NewBatchedLoader(
+			loadArticles(uc),
+			dataloader.WithWait[string, *domain.Article](loaderWait),
+		),
+		matches: dataloader.NewBatchedLoader(
+			loadMatches(uc),
+			dataloader.WithWait[string, []*domain.Article](loaderWait),
+		),
+	}
+}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// loadArticles reads the articles of the ids. Unknown ids load nil.
+func loadArticles(uc article.UseCase) dataloader.BatchFunc[string, *domain.Article] {
+	return func(ctx context.Context, ids []string) []*dataloader.Result[*domain.Article] {
+		results := make([]*dataloader.Result[*domain.Article], len(ids))
+
+		articles, err := uc.GetByIDs(ctx, ids)
+		if err != nil {
+			for i := range results {
+				results[i] = &dataloader.Result[*domain.Article]{Error: err}
+			}
+
+			return results
+		}
+
+		byID := make(map[string]*domain.Article, len(articles))
+		for _, a := range articles {
+			byID[a.ID] = a
+		}
+
+		for i, id := range ids {
+			results[i] = &dataloader.Result[*domain.Article]{Data: byID[id]}
+		}
+
+		return results
+	}
+}
+
+// loadMatches reads the articles of the matches, newest first, a page of every match at a time.
+func loadMatches(uc article.UseCase) dataloader.BatchFunc[string, []*domain.Article] {
+	return func(ctx context.Context, ids []string) []*dataloader.Result[[]*domain.Article] {
+		results := make([]*dataloader.Result[[]*domain.Article], len(ids))
+
+		byMatch := make(map[string][]*domain.Article, len(ids))
+		params := domain.ListParams{
+			Filter: domain.ArticleFilter{OptaMatchIDs: ids},
+			Sort:   domain.DefaultSort,
+			Limit:  domain.MaxPageSize,
+		}
+
+		for {
+			page, err := uc.List(ctx, params)
+			if err != nil {
+				for i := range results {
+					results[i] = &dataloader.Result[[]*domain.Article]{Error: err}
+				}
+
+				return results
+			}
+
+			for _, a := range page.Articles {
+				byMatch[a.OptaMatchID] = append(byMatch[a.OptaMatchID], a)
+			}
+
+			if page.Next == nil {
+				break
+			}
+
+			params.Cursor = page.Next
+		}
+
+		for i, id := range ids {
+			articles := byMatch[id]
+			if articles == nil {
+				articles = make([]*domain.Article, 0)
+			}
+
+			results[i] = &dataloader.Result[[]*domain.Article]{Data: articles}
+		}
+
+		return results
+	}
+}
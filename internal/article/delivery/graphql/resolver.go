@@ -0,0 +1,512 @@
+package graphql
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	graphqlgo "github.com/graph-gophers/graphql-go"
+
+	"github.com/KarolosLykos/sportsnews/domain"
+	"github.com/KarolosLykos/sportsnews/internal/article"
+)
+
+// resolver is the root of the schema. Every read goes through the article use case.
+type resolver struct {
+	uc     article.UseCase
+	events article.Events
+}
+
+type filterInput struct {
+	TeamID         *graphqlgo.ID
+	Types          *[]string
+	TypeMatch      *string
+	PublishedSince *graphqlgo.Time
+	PublishedUntil *graphqlgo.Time
+	IsPublished    *bool
+	MatchID        *graphqlgo.ID
+	HasVideo       *bool
+}
+
+// pageArgs select a page of a connection: the first articles after a cursor, or the last ones before it.
+type pageArgs struct {
+	Filter *filterInput
+	Sort   *string
+	First  *int32
+	After  *string
+	Last   *int32
+	Before *string
+}
+
+func (r *resolver) Article(ctx context.Context, args struct {
+	ID   *graphqlgo.ID
+	Slug *string
+}) (*articleResolver, error) {
+	var (
+		a   *domain.Article
+		err error
+	)
+
+	switch {
+	case args.ID != nil && args.Slug != nil:
+		return nil, errors.New("id and slug are mutually exclusive")
+	case args.ID != nil:
+		a, err = loadersFrom(ctx).articles.Load(ctx, string(*args.ID))()
+	case args.Slug != nil:
+		if a, err = r.uc.GetBySlug(ctx, *args.Slug); isNotFound(err) {
+			return nil, nil
+		}
+	default:
+		return nil, errors.New("id or slug is required")
+	}
+
+	if err != nil || a == nil {
+		return nil, err
+	}
+
+	return &articleResolver{r: r, a: a}, nil
+}
+
+func (r *resolver) Articles(ctx context.Context, args pageArgs) (*connectionResolver, error) {
+	filter, err := args.Filter.toDomain()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.connection(ctx, args, filter)
+}
+
+func (r *resolver) Search(ctx context.Context, args struct {
+	Query  string
+	Filter *filterInput
+	Fuzzy  *bool
+	First  *int32
+	After  *string
+}) (*searchConnectionResolver, error) {
+	filter, err := args.Filter.toDomain()
+	if err != nil {
+		return nil, err
+	}
+
+	params := domain.SearchParams{Query: args.Query, Filter: filter, Fuzzy: args.Fuzzy != nil && *args.Fuzzy}
+	if args.First != nil {
+		params.Limit = int(*args.First)
+	}
+
+	if args.After != nil {
+		offset, err := decodeOffset(*args.After)
+		if err != nil {
+			return nil, err
+		}
+
+		params.Offset = offset + 1
+	}
+
+	if err = params.Validate(); err != nil {
+		return nil, err
+	}
+
+	results, err := r.uc.Search(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &searchConnectionResolver{r: r, results: results}, nil
+}
+
+func (r *resolver) Team(args struct{ ID graphqlgo.ID }) *teamResolver {
+	return &teamResolver{r: r, id: string(args.ID)}
+}
+
+func (r *resolver) Match(args struct{ ID graphqlgo.ID }) *matchResolver {
+	return &matchResolver{r: r, id: string(args.ID)}
+}
+
+// ArticleEvents sends the events of the articles that pass the filter, until ctx is done or the
+// subscriber falls behind.
+func (r *resolver) ArticleEvents(ctx context.Context, args struct{ Filter *filterInput }) (<-chan *eventResolver, error) {
+	filter, err := args.Filter.toDomain()
+	if err != nil {
+		return nil, err
+	}
+
+	events := r.events.Subscribe(ctx)
+	out := make(chan *eventResolver)
+
+	go func() {
+		defer close(out)
+
+		for e := range events {
+			if !filter.Matches(e.Article) {
+				continue
+			}
+
+			select {
+			case out <- &eventResolver{r: r, e: e}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// connection returns the page of the filtered articles that args select.
+func (r *resolver) connection(ctx context.Context, args pageArgs, filter domain.ArticleFilter) (*connectionResolver, error) {
+	params, err := args.listParams(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := r.uc.List(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// The use case applies the default sort, which the cursors of the edges are made for.
+	if params.Sort == nil {
+		params = params.Normalize()
+	}
+
+	return &connectionResolver{r: r, page: page, sort: params.Sort}, nil
+}
+
+// listParams returns the list of the page, validated.
+func (args pageArgs) listParams(filter domain.ArticleFilter) (domain.ListParams, error) {
+	params := domain.ListParams{Filter: filter}
+
+	switch {
+	case args.First != nil && args.Last != nil:
+		return params, errors.New("first and last are mutually exclusive")
+	case args.After != nil && args.Before != nil:
+		return params, errors.New("after and before are mutually exclusive")
+	case args.Last != nil && args.Before == nil:
+		return params, errors.New("last requires before")
+	case args.First != nil:
+		params.Limit = int(*args.First)
+	case args.Last != nil:
+		params.Limit = int(*args.Last)
+	}
+
+	var err error
+	if args.Sort != nil {
+		if params.Sort, err = domain.ParseSort(*args.Sort); err != nil {
+			return params, err
+		}
+	}
+
+	cursor := args.After
+	if args.Before != nil {
+		cursor = args.Before
+	}
+
+	if cursor != nil {
+		if params.Cursor, err = domain.DecodeCursor(*cursor); err != nil {
+			return params, err
+		}
+
+		params.Cursor.Before = args.Before != nil
+
+		if params.Sort != nil && params.Sort.String() != params.Cursor.Sort.String() {
+			return params, errors.New("cursor was made for another sort")
+		}
+	}
+
+	return params, filter.Validate()
+}
+
+func (f *filterInput) toDomain() (domain.ArticleFilter, error) {
+	filter := domain.ArticleFilter{}
+	if f == nil {
+		return filter, nil
+	}
+
+	if f.TeamID != nil {
+		filter.TeamID = string(*f.TeamID)
+	}
+
+	if f.Types != nil {
+		filter.Types = *f.Types
+	}
+
+	if f.TypeMatch != nil {
+		filter.TypeMatch = domain.TypeMatch(strings.ToLower(*f.TypeMatch))
+	}
+
+	if f.PublishedSince != nil {
+		filter.PublishedSince = &f.PublishedSince.Time
+	}
+
+	if f.PublishedUntil != nil {
+		filter.PublishedUntil = &f.PublishedUntil.Time
+	}
+
+	if f.MatchID != nil {
+		filter.OptaMatchID = string(*f.MatchID)
+	}
+
+	filter.IsPublished = f.IsPublished
+	filter.HasVideo = f.HasVideo
+
+	return filter, filter.Validate()
+}
+
+type teamResolver struct {
+	r  *resolver
+	id string
+}
+
+func (t *teamResolver) ID() graphqlgo.ID {
+	return graphqlgo.ID(t.id)
+}
+
+// Feed returns the articles of the team, published ones unless the filter says otherwise.
+func (t *teamResolver) Feed(ctx context.Context, args pageArgs) (*connectionResolver, error) {
+	filter, err := args.Filter.toDomain()
+	if err != nil {
+		return nil, err
+	}
+
+	filter.TeamID = t.id
+
+	if filter.IsPublished == nil {
+		published := true
+		filter.IsPublished = &published
+	}
+
+	return t.r.connection(ctx, args, filter)
+}
+
+type matchResolver struct {
+	r  *resolver
+	id string
+}
+
+func (m *matchResolver) ID() graphqlgo.ID {
+	return graphqlgo.ID(m.id)
+}
+
+func (m *matchResolver) Articles(ctx context.Context) ([]*articleResolver, error) {
+	articles, err := loadersFrom(ctx).matches.Load(ctx, m.id)()
+	if err != nil {
+		return nil, err
+	}
+
+	return m.r.articleResolvers(articles), nil
+}
+
+type articleResolver struct {
+	r *resolver
+	a *domain.Article
+}
+
+func (r *resolver) articleResolvers(articles []*domain.Article) []*articleResolver {
+	resolvers := make([]*articleResolver, 0, len(articles))
+	for _, a := range articles {
+		resolvers = append(resolvers, &articleResolver{r: r, a: a})
+	}
+
+	return resolvers
+}
+
+func (r *articleResolver) ID() graphqlgo.ID          { return graphqlgo.ID(r.a.ID) }
+func (r *articleResolver) ArticleID() string         { return r.a.ArticleID }
+func (r *articleResolver) Provider() string          { return r.a.Provider }
+func (r *articleResolver) Slug() string              { return r.a.Slug }
+func (r *articleResolver) Title() string             { return r.a.Title }
+func (r *articleResolver) Types() []string           { return nonNil(r.a.Type) }
+func (r *articleResolver) Teaser() string            { return r.a.Teaser }
+func (r *articleResolver) Subtitle() string          { return r.a.Subtitle }
+func (r *articleResolver) Content() string           { return r.a.Content }
+func (r *articleResolver) BodyText() string          { return r.a.BodyText }
+func (r *articleResolver) URL() string               { return r.a.URL }
+func (r *articleResolver) ImageURL() string          { return r.a.ImageURL }
+func (r *articleResolver) GalleryURLs() []string     { return nonNil(r.a.GalleryURLs) }
+func (r *articleResolver) VideoURL() string          { return r.a.VideoURL }
+func (r *articleResolver) IsPublished() bool         { return r.a.IsPublished }
+func (r *articleResolver) Published() graphqlgo.Time { return graphqlgo.Time{Time: r.a.Published} }
+func (r *articleResolver) Updated() graphqlgo.Time   { return graphqlgo.Time{Time: r.a.Updated} }
+func (r *articleResolver) Team() *teamResolver       { return &teamResolver{r: r.r, id: r.a.TeamID} }
+func (r *articleResolver) Popularity() int32         { return clamp(r.a.Popularity) }
+
+func (r *articleResolver) Match() *matchResolver {
+	if r.a.OptaMatchID == "" {
+		return nil
+	}
+
+	return &matchResolver{r: r.r, id: r.a.OptaMatchID}
+}
+
+type connectionResolver struct {
+	r    *resolver
+	page *domain.Articles
+	sort domain.Sort
+}
+
+func (c *connectionResolver) Edges() []*edgeResolver {
+	edges := make([]*edgeResolver, 0, len(c.page.Articles))
+	for _, a := range c.page.Articles {
+		edges = append(edges, &edgeResolver{r: c.r, a: a, cursor: domain.NewCursor(c.sort, a, false).Encode()})
+	}
+
+	return edges
+}
+
+func (c *connectionResolver) PageInfo() *pageInfoResolver {
+	info := &pageInfoResolver{hasNext: c.page.Next != nil, hasPrev: c.page.Prev != nil}
+
+	if n := len(c.page.Articles); n > 0 {
+		start := domain.NewCursor(c.sort, c.page.Articles[0], false).Encode()
+		end := domain.NewCursor(c.sort, c.page.Articles[n-1], false).Encode()
+		info.start, info.end = &start, &end
+	}
+
+	return info
+}
+
+func (c *connectionResolver) TotalCount() int32 {
+	return clamp(c.page.Total)
+}
+
+type edgeResolver struct {
+	r      *resolver
+	a      *domain.Article
+	cursor string
+}
+
+func (e *edgeResolver) Cursor() string         { return e.cursor }
+func (e *edgeResolver) Node() *articleResolver { return &articleResolver{r: e.r, a: e.a} }
+
+type searchConnectionResolver struct {
+	r       *resolver
+	results *domain.SearchResults
+}
+
+func (c *searchConnectionResolver) Edges() []*searchEdgeResolver {
+	edges := make([]*searchEdgeResolver, 0, len(c.results.Hits))
+	for i, hit := range c.results.Hits {
+		edges = append(edges, &searchEdgeResolver{r: c.r, hit: hit, cursor: encodeOffset(c.results.Offset + i)})
+	}
+
+	return edges
+}
+
+func (c *searchConnectionResolver) PageInfo() *pageInfoResolver {
+	info := &pageInfoResolver{
+		hasNext: int64(c.results.Offset+len(c.results.Hits)) < c.results.Total,
+		hasPrev: c.results.Offset > 0,
+	}
+
+	if n := len(c.results.Hits); n > 0 {
+		start, end := encodeOffset(c.results.Offset), encodeOffset(c.results.Offset+n-1)
+		info.start, info.end = &start, &end
+	}
+
+	return info
+}
+
+func (c *searchConnectionResolver) TotalCount() int32 {
+	return clamp(c.results.Total)
+}
+
+type searchEdgeResolver struct {
+	r      *resolver
+	hit    *domain.SearchHit
+	cursor string
+}
+
+func (e *searchEdgeResolver) Cursor() string { return e.cursor }
+func (e *searchEdgeResolver) Score() float64 { return e.hit.Score }
+func (e *searchEdgeResolver) Node() *articleResolver {
+	return &articleResolver{r: e.r, a: e.hit.Article}
+}
+
+func (e *searchEdgeResolver) Highlights() []*highlightResolver {
+	fields := make([]string, 0, len(e.hit.Highlights))
+	for field := range e.hit.Highlights {
+		fields = append(fields, field)
+	}
+
+	sort.Strings(fields)
+
+	highlights := make([]*highlightResolver, 0, len(fields))
+	for _, field := range fields {
+		highlights = append(highlights, &highlightResolver{field: field, fragments: e.hit.Highlights[field]})
+	}
+
+	return highlights
+}
+
+type highlightResolver struct {
+	field     string
+	fragments []string
+}
+
+func (h *highlightResolver) Field() string       { return h.field }
+func (h *highlightResolver) Fragments() []string { return nonNil(h.fragments) }
+
+type pageInfoResolver struct {
+	hasNext, hasPrev bool
+	start, end       *string
+}
+
+func (p *pageInfoResolver) HasNextPage() bool     { return p.hasNext }
+func (p *pageInfoResolver) HasPreviousPage() bool { return p.hasPrev }
+func (p *pageInfoResolver) StartCursor() *string  { return p.start }
+func (p *pageInfoResolver) EndCursor() *string    { return p.end }
+
+type eventResolver struct {
+	r *resolver
+	e *domain.ArticleEvent
+}
+
+func (r *eventResolver) ID() graphqlgo.ID          { return graphqlgo.ID(strconv.FormatInt(r.e.ID, 10)) }
+func (r *eventResolver) Type() string              { return strings.ToUpper(string(r.e.Type)) }
+func (r *eventResolver) Time() graphqlgo.Time      { return graphqlgo.Time{Time: r.e.Time} }
+func (r *eventResolver) Article() *articleResolver { return &articleResolver{r: r.r, a: r.e.Article} }
+
+// encodeOffset returns the cursor of a search hit, which is its position in the results.
+func encodeOffset(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("offset:" + strconv.Itoa(offset)))
+}
+
+func decodeOffset(cursor string) (int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, fmt.Errorf("%w:%v", domain.ErrInvalidCursor, err)
+	}
+
+	offset, err := strconv.Atoi(strings.TrimPrefix(string(b), "offset:"))
+	if err != nil || offset < 0 || !strings.HasPrefix(string(b), "offset:") {
+		return 0, fmt.Errorf("%w:not a search cursor", domain.ErrInvalidCursor)
+	}
+
+	return offset, nil
+}
+
+func isNotFound(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "no documents in result")
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return make([]string, 0)
+	}
+
+	return values
+}
+
+// clamp converts to a GraphQL Int, which has 32 bits.
+func clamp(n int64) int32 {
+	if n > math.MaxInt32 {
+		return math.MaxInt32
+	}
+
+	return int32(n)
+}
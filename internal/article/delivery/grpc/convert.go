@@ -0,0 +1,127 @@
+package grpc
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	articlev1 "github.com/KarolosLykos/sportsnews/api/article/v1"
+	"github.com/KarolosLykos/sportsnews/domain"
+)
+
+var (
+	typeMatches = map[articlev1.TypeMatch]domain.TypeMatch{
+		articlev1.TypeMatch_TYPE_MATCH_UNSPECIFIED: "",
+		articlev1.TypeMatch_TYPE_MATCH_ANY:         domain.TypeMatchAny,
+		articlev1.TypeMatch_TYPE_MATCH_ALL:         domain.TypeMatchAll,
+	}
+
+	eventTypes = map[domain.EventType]articlev1.EventType{
+		domain.EventCreated:   articlev1.EventType_EVENT_TYPE_CREATED,
+		domain.EventUpdated:   articlev1.EventType_EVENT_TYPE_UPDATED,
+		domain.EventWithdrawn: articlev1.EventType_EVENT_TYPE_WITHDRAWN,
+	}
+)
+
+func toArticle(a *domain.Article) *articlev1.Article {
+	return &articlev1.Article{
+		Id:          a.ID,
+		ArticleId:   a.ArticleID,
+		Provider:    a.Provider,
+		Slug:        a.Slug,
+		TeamId:      a.TeamID,
+		ClubUrl:     a.ClubURL,
+		OptaMatchId: a.OptaMatchID,
+		Title:       a.Title,
+		Types:       a.Type,
+		Teaser:      a.Teaser,
+		Content:     a.Content,
+		Url:         a.URL,
+		ImageUrl:    a.ImageURL,
+		GalleryUrls: a.GalleryURLs,
+		VideoUrl:    a.VideoURL,
+		BodyText:    a.BodyText,
+		Subtitle:    a.Subtitle,
+		IsPublished: a.IsPublished,
+		Published:   timestamppb.New(a.Published),
+		Updated:     timestamppb.New(a.Updated),
+		Popularity:  a.Popularity,
+	}
+}
+
+func toEvent(e *domain.ArticleEvent) *articlev1.ArticleEvent {
+	return &articlev1.ArticleEvent{
+		Id:      e.ID,
+		Type:    eventTypes[e.Type],
+		Time:    timestamppb.New(e.Time),
+		Article: toArticle(e.Article),
+	}
+}
+
+func toSearchResponse(r *domain.SearchResults) *articlev1.SearchArticlesResponse {
+	res := &articlev1.SearchArticlesResponse{
+		Total:  r.Total,
+		Hits:   make([]*articlev1.SearchHit, 0, len(r.Hits)),
+		Limit:  int32(r.Limit),
+		Offset: int32(r.Offset),
+	}
+
+	for _, hit := range r.Hits {
+		h := &articlev1.SearchHit{Article: toArticle(hit.Article), Score: hit.Score}
+
+		if len(hit.Highlights) > 0 {
+			h.Highlights = make(map[string]*articlev1.Fragments, len(hit.Highlights))
+			for field, fragments := range hit.Highlights {
+				h.Highlights[field] = &articlev1.Fragments{Fragments: fragments}
+			}
+		}
+
+		res.Hits = append(res.Hits, h)
+	}
+
+	if len(r.Facets) > 0 {
+		res.Facets = make(map[string]*articlev1.Facets, len(r.Facets))
+		for name, facets := range r.Facets {
+			f := &articlev1.Facets{Facets: make([]*articlev1.Facet, 0, len(facets))}
+			for _, facet := range facets {
+				f.Facets = append(f.Facets, &articlev1.Facet{Value: facet.Value, Count: int64(facet.Count)})
+			}
+
+			res.Facets[name] = f
+		}
+	}
+
+	return res
+}
+
+// fromFilter returns the filter of the request, validated. A nil filter does not filter.
+func fromFilter(f *articlev1.ArticleFilter) (domain.ArticleFilter, error) {
+	filter := domain.ArticleFilter{
+		TeamID:      f.GetTeamId(),
+		Types:       f.GetTypes(),
+		OptaMatchID: f.GetOptaMatchId(),
+	}
+
+	typeMatch, ok := typeMatches[f.GetTypeMatch()]
+	if !ok {
+		// Unknown values fail the validation below.
+		typeMatch = domain.TypeMatch(f.GetTypeMatch().String())
+	}
+
+	filter.TypeMatch = typeMatch
+
+	if f.GetPublishedSince() != nil {
+		since := f.GetPublishedSince().AsTime()
+		filter.PublishedSince = &since
+	}
+
+	if f.GetPublishedUntil() != nil {
+		until := f.GetPublishedUntil().AsTime()
+		filter.PublishedUntil = &until
+	}
+
+	if f != nil {
+		filter.IsPublished = f.IsPublished
+		filter.HasVideo = f.HasVideo
+	}
+
+	return filter, filter.Validate()
+}
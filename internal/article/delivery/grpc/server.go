@@ -0,0 +1,203 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	articlev1 "github.com/KarolosLykos/sportsnews/api/article/v1"
+	"github.com/KarolosLykos/sportsnews/domain"
+	"github.com/KarolosLykos/sportsnews/internal/article"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+type articleServer struct {
+	articlev1.UnimplementedArticleServiceServer
+
+	logger logger.Logger
+	uc     article.UseCase
+	events article.Events
+}
+
+func NewArticleServer(logger logger.Logger, uc article.UseCase, events article.Events) *articleServer {
+	return &articleServer{
+		logger: logger,
+		uc:     uc,
+		events: events,
+	}
+}
+
+func (s *articleServer) GetArticle(ctx context.Context, req *articlev1.GetArticleRequest) (*articlev1.GetArticleResponse, error) {
+	var (
+		a   *domain.Article
+		err error
+	)
+
+	switch key := req.GetKey().(type) {
+	case *articlev1.GetArticleRequest_Id:
+		a, err = s.uc.GetByID(ctx, key.Id)
+	case *articlev1.GetArticleRequest_Slug:
+		a, err = s.uc.GetBySlug(ctx, key.Slug)
+	case *articlev1.GetArticleRequest_ProviderArticleId:
+		a, err = s.uc.GetByArticleID(ctx, key.ProviderArticleId.GetProvider(), key.ProviderArticleId.GetArticleId())
+	default:
+		return nil, status.Error(codes.InvalidArgument, "id, slug or provider article id is required")
+	}
+
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &articlev1.GetArticleResponse{Article: toArticle(a)}, nil
+}
+
+// ListArticles sends the articles a page at a time, following the cursor of each page.
+func (s *articleServer) ListArticles(req *articlev1.ListArticlesRequest, stream articlev1.ArticleService_ListArticlesServer) error {
+	filter, err := fromFilter(req.GetFilter())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	params := domain.ListParams{Filter: filter}
+	if req.GetSort() != "" {
+		if params.Sort, err = domain.ParseSort(req.GetSort()); err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	limit := int(req.GetLimit())
+	if limit < 0 {
+		return status.Error(codes.InvalidArgument, "limit must not be negative")
+	}
+
+	for sent := 0; ; {
+		params.Limit = domain.MaxPageSize
+		if limit > 0 && limit-sent < params.Limit {
+			params.Limit = limit - sent
+		}
+
+		page, err := s.uc.List(stream.Context(), params)
+		if err != nil {
+			return toStatus(err)
+		}
+
+		for _, a := range page.Articles {
+			if err = stream.Send(&articlev1.ListArticlesResponse{Article: toArticle(a)}); err != nil {
+				return err
+			}
+		}
+
+		sent += len(page.Articles)
+		if page.Next == nil || (limit > 0 && sent >= limit) {
+			return nil
+		}
+
+		params.Cursor = page.Next
+	}
+}
+
+func (s *articleServer) SearchArticles(ctx context.Context, req *articlev1.SearchArticlesRequest) (*articlev1.SearchArticlesResponse, error) {
+	filter, err := fromFilter(req.GetFilter())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	params := domain.SearchParams{
+		Query:  req.GetQuery(),
+		Filter: filter,
+		Fuzzy:  req.GetFuzzy(),
+		Limit:  int(req.GetLimit()),
+		Offset: int(req.GetOffset()),
+	}
+
+	if err = params.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	results, err := s.uc.Search(ctx, params)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toSearchResponse(results), nil
+}
+
+// WatchArticles sends the recent events after the last event id first, then the live ones. A client
+// that falls too far behind is sent Unavailable, and resumes with the id of the last event it received.
+func (s *articleServer) WatchArticles(req *articlev1.WatchArticlesRequest, stream articlev1.ArticleService_WatchArticlesServer) error {
+	filter, err := fromFilter(req.GetFilter())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	ctx := stream.Context()
+	lastID := req.GetLastEventId()
+
+	// Subscribe before reading the replay buffer, so that no event falls in between.
+	live := s.events.Subscribe(ctx)
+
+	var replay []*domain.ArticleEvent
+	if lastID > 0 {
+		if replay, err = s.events.Replay(ctx, lastID); err != nil {
+			return toStatus(err)
+		}
+	}
+
+	send := func(e *domain.ArticleEvent) error {
+		if e.ID <= lastID {
+			return nil
+		}
+
+		lastID = e.ID
+		if !filter.Matches(e.Article) {
+			return nil
+		}
+
+		return stream.Send(&articlev1.WatchArticlesResponse{Event: toEvent(e)})
+	}
+
+	for _, e := range replay {
+		if err = send(e); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case e, ok := <-live:
+			if !ok {
+				if ctx.Err() != nil {
+					return nil
+				}
+
+				return status.Error(codes.Unavailable, "watch fell behind, resume from the last event id")
+			}
+
+			if err = send(e); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// toStatus maps the errors of the use case to gRPC status codes, like the HTTP handlers map them to
+// status codes.
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case strings.Contains(err.Error(), "no documents in result"):
+		return status.Error(codes.NotFound, err.Error())
+	case strings.Contains(err.Error(), "provided hex string is not a valid ObjectID"):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return status.Error(codes.Internal, err.Error())
+}
@@ -0,0 +1,349 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	articlev1 "github.com/KarolosLykos/sportsnews/api/article/v1"
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+	mock "github.com/KarolosLykos/sportsnews/internal/article/mock"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+func TestArticleServer_GetArticle(t *testing.T) {
+	testArticle := &domain.Article{ID: "6405f896a019b8815f6892c7", TeamID: "Hull City", Title: "Hall", Type: []string{"Academy"}}
+
+	tt := []struct {
+		name   string
+		req    *articlev1.GetArticleRequest
+		ucStub func(uc *mock.MockUseCase)
+		code   codes.Code
+	}{
+		{
+			name: "by id",
+			req:  &articlev1.GetArticleRequest{Key: &articlev1.GetArticleRequest_Id{Id: testArticle.ID}},
+			ucStub: func(uc *mock.MockUseCase) {
+				uc.EXPECT().GetByID(gomock.Any(), testArticle.ID).Times(1).Return(testArticle, nil)
+			},
+			code: codes.OK,
+		},
+		{
+			name: "by slug",
+			req:  &articlev1.GetArticleRequest{Key: &articlev1.GetArticleRequest_Slug{Slug: "hall"}},
+			ucStub: func(uc *mock.MockUseCase) {
+				uc.EXPECT().GetBySlug(gomock.Any(), "hall").Times(1).Return(testArticle, nil)
+			},
+			code: codes.OK,
+		},
+		{
+			name: "by provider article id",
+			req: &articlev1.GetArticleRequest{Key: &articlev1.GetArticleRequest_ProviderArticleId{
+				ProviderArticleId: &articlev1.ProviderArticleID{Provider: domain.HullCityProvider, ArticleId: "1"},
+			}},
+			ucStub: func(uc *mock.MockUseCase) {
+				uc.EXPECT().GetByArticleID(gomock.Any(), domain.HullCityProvider, "1").Times(1).Return(testArticle, nil)
+			},
+			code: codes.OK,
+		},
+		{
+			name: "not found",
+			req:  &articlev1.GetArticleRequest{Key: &articlev1.GetArticleRequest_Id{Id: testArticle.ID}},
+			ucStub: func(uc *mock.MockUseCase) {
+				uc.EXPECT().GetByID(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("no documents in result"))
+			},
+			code: codes.NotFound,
+		},
+		{
+			name: "internal",
+			req:  &articlev1.GetArticleRequest{Key: &articlev1.GetArticleRequest_Slug{Slug: "hall"}},
+			ucStub: func(uc *mock.MockUseCase) {
+				uc.EXPECT().GetBySlug(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("generic error"))
+			},
+			code: codes.Internal,
+		},
+		{
+			name:   "no key",
+			req:    &articlev1.GetArticleRequest{},
+			ucStub: func(uc *mock.MockUseCase) {},
+			code:   codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := mock.NewMockUseCase(ctrl)
+			tc.ucStub(uc)
+
+			client := newClient(t, uc, nil)
+
+			res, err := client.GetArticle(context.Background(), tc.req)
+			require.Equal(t, tc.code, status.Code(err), err)
+
+			if tc.code == codes.OK {
+				assert.Equal(t, testArticle.ID, res.GetArticle().GetId())
+				assert.Equal(t, testArticle.Type, res.GetArticle().GetTypes())
+			}
+		})
+	}
+}
+
+func TestArticleServer_ListArticles(t *testing.T) {
+	articles := make([]*domain.Article, 0, 150)
+	for i := 0; i < 150; i++ {
+		articles = append(articles, &domain.Article{ID: strconv.Itoa(i), TeamID: "Hull City"})
+	}
+
+	next := &domain.Cursor{Sort: domain.DefaultSort, ID: "z"}
+
+	tt := []struct {
+		name   string
+		req    *articlev1.ListArticlesRequest
+		ucStub func(uc *mock.MockUseCase)
+		sent   int
+		code   codes.Code
+	}{
+		{
+			name: "every page",
+			req:  &articlev1.ListArticlesRequest{Filter: &articlev1.ArticleFilter{TeamId: "Hull City", TypeMatch: articlev1.TypeMatch_TYPE_MATCH_ALL}},
+			ucStub: func(uc *mock.MockUseCase) {
+				gomock.InOrder(
+					uc.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).
+						DoAndReturn(func(_ context.Context, params domain.ListParams) (*domain.Articles, error) {
+							assert.Equal(t, domain.MaxPageSize, params.Limit)
+							assert.Equal(t, "Hull City", params.Filter.TeamID)
+							assert.Equal(t, domain.TypeMatchAll, params.Filter.TypeMatch)
+							assert.Nil(t, params.Cursor)
+
+							return &domain.Articles{Articles: articles[:100], Next: next}, nil
+						}),
+					uc.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).
+						DoAndReturn(func(_ context.Context, params domain.ListParams) (*domain.Articles, error) {
+							assert.Equal(t, next, params.Cursor)
+
+							return &domain.Articles{Articles: articles[100:]}, nil
+						}),
+				)
+			},
+			sent: 150,
+			code: codes.OK,
+		},
+		{
+			name: "limit",
+			req:  &articlev1.ListArticlesRequest{Sort: "-popularity", Limit: 120},
+			ucStub: func(uc *mock.MockUseCase) {
+				gomock.InOrder(
+					uc.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).
+						DoAndReturn(func(_ context.Context, params domain.ListParams) (*domain.Articles, error) {
+							assert.Equal(t, domain.MaxPageSize, params.Limit)
+							assert.Equal(t, "-popularity", params.Sort.String())
+
+							return &domain.Articles{Articles: articles[:100], Next: next}, nil
+						}),
+					uc.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).
+						DoAndReturn(func(_ context.Context, params domain.ListParams) (*domain.Articles, error) {
+							assert.Equal(t, 20, params.Limit)
+
+							return &domain.Articles{Articles: articles[100:120], Next: next}, nil
+						}),
+				)
+			},
+			sent: 120,
+			code: codes.OK,
+		},
+		{
+			name:   "invalid sort",
+			req:    &articlev1.ListArticlesRequest{Sort: "-nope"},
+			ucStub: func(uc *mock.MockUseCase) {},
+			code:   codes.InvalidArgument,
+		},
+		{
+			name:   "invalid type match",
+			req:    &articlev1.ListArticlesRequest{Filter: &articlev1.ArticleFilter{TypeMatch: 7}},
+			ucStub: func(uc *mock.MockUseCase) {},
+			code:   codes.InvalidArgument,
+		},
+		{
+			name: "use case error",
+			req:  &articlev1.ListArticlesRequest{},
+			ucStub: func(uc *mock.MockUseCase) {
+				uc.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("generic error"))
+			},
+			code: codes.Internal,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := mock.NewMockUseCase(ctrl)
+			tc.ucStub(uc)
+
+			stream, err := newClient(t, uc, nil).ListArticles(context.Background(), tc.req)
+			require.NoError(t, err)
+
+			sent := 0
+			for {
+				if _, err = stream.Recv(); err != nil {
+					break
+				}
+
+				sent++
+			}
+
+			if tc.code == codes.OK {
+				assert.ErrorIs(t, err, io.EOF)
+			} else {
+				assert.Equal(t, tc.code, status.Code(err), err)
+			}
+
+			assert.Equal(t, tc.sent, sent)
+		})
+	}
+}
+
+func TestArticleServer_SearchArticles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := mock.NewMockUseCase(ctrl)
+	uc.EXPECT().Search(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, params domain.SearchParams) (*domain.SearchResults, error) {
+			assert.Equal(t, "hall", params.Query)
+			assert.True(t, params.Fuzzy)
+			assert.Equal(t, 5, params.Limit)
+
+			return &domain.SearchResults{
+				Total: 1,
+				Hits: []*domain.SearchHit{{
+					Article:    &domain.Article{ID: "1"},
+					Score:      2.5,
+					Highlights: map[string][]string{"title": {"<mark>Hall</mark>"}},
+				}},
+				Limit:  5,
+				Facets: map[string]domain.Facets{"teamId": {{Value: "Hull City", Count: 1}}},
+			}, nil
+		})
+
+	client := newClient(t, uc, nil)
+
+	res, err := client.SearchArticles(context.Background(), &articlev1.SearchArticlesRequest{Query: "hall", Fuzzy: true, Limit: 5})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), res.GetTotal())
+	require.Len(t, res.GetHits(), 1)
+	assert.Equal(t, 2.5, res.GetHits()[0].GetScore())
+	assert.Equal(t, []string{"<mark>Hall</mark>"}, res.GetHits()[0].GetHighlights()["title"].GetFragments())
+	assert.Equal(t, int64(1), res.GetFacets()["teamId"].GetFacets()[0].GetCount())
+
+	_, err = client.SearchArticles(context.Background(), &articlev1.SearchArticlesRequest{Query: " "})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestArticleServer_WatchArticles(t *testing.T) {
+	at := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+	event := func(id int64, typ domain.EventType, teamID string) *domain.ArticleEvent {
+		return &domain.ArticleEvent{ID: id, Type: typ, Time: at, Article: &domain.Article{ID: "1", TeamID: teamID}}
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	live := make(chan *domain.ArticleEvent, 3)
+	live <- event(5, domain.EventUpdated, "Hull City")
+	live <- event(6, domain.EventCreated, "Leeds")
+	live <- event(7, domain.EventWithdrawn, "Hull City")
+	// The subscriber fell behind.
+	close(live)
+
+	events := mock.NewMockEvents(ctrl)
+	events.EXPECT().Subscribe(gomock.Any()).Times(1).Return(live)
+	events.EXPECT().Replay(gomock.Any(), int64(3)).Times(1).
+		Return([]*domain.ArticleEvent{event(4, domain.EventCreated, "Hull City"), event(5, domain.EventUpdated, "Hull City")}, nil)
+
+	stream, err := newClient(t, nil, events).WatchArticles(context.Background(), &articlev1.WatchArticlesRequest{
+		Filter:      &articlev1.ArticleFilter{TeamId: "Hull City"},
+		LastEventId: 3,
+	})
+	require.NoError(t, err)
+
+	var got []*articlev1.ArticleEvent
+	for {
+		res, err := stream.Recv()
+		if err != nil {
+			assert.Equal(t, codes.Unavailable, status.Code(err), err)
+			break
+		}
+
+		got = append(got, res.GetEvent())
+	}
+
+	require.Len(t, got, 3)
+	assert.Equal(t, int64(4), got[0].GetId())
+	assert.Equal(t, articlev1.EventType_EVENT_TYPE_CREATED, got[0].GetType())
+	assert.Equal(t, int64(5), got[1].GetId())
+	assert.Equal(t, int64(7), got[2].GetId())
+	assert.Equal(t, articlev1.EventType_EVENT_TYPE_WITHDRAWN, got[2].GetType())
+	assert.Equal(t, timestamppb.New(at).AsTime(), got[2].GetTime().AsTime())
+}
+
+// newClient serves the article server over an in-memory connection.
+func newClient(t *testing.T, uc *mock.MockUseCase, events *mock.MockEvents) articlev1.ArticleServiceClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	articlev1.RegisterArticleServiceServer(srv, NewArticleServer(getLogger(), uc, events))
+
+	go func() { _ = srv.Serve(lis) }()
+
+	conn, err := grpc.Dial(
+		"bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		conn.Close()
+		srv.Stop()
+	})
+
+	return articlev1.NewArticleServiceClient(conn)
+}
+
+func getLogger() logger.Logger {
+	cfg := &config.Config{}
+
+	l := &logrus.Logger{
+		Out:          io.Discard,
+		Hooks:        make(logrus.LevelHooks),
+		ReportCaller: false,
+		ExitFunc:     os.Exit,
+		Level:        logrus.InfoLevel,
+		Formatter:    &logrus.JSONFormatter{},
+	}
+
+	return logger.New(cfg, l)
+}
@@ -0,0 +1,47 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/KarolosLykos/sportsnews/internal/article"
+	httperrors "github.com/KarolosLykos/sportsnews/internal/utils/http_errors"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+type adminHandler struct {
+	logger logger.Logger
+	uc     article.UseCase
+}
+
+func NewAdminHandler(logger logger.Logger, uc article.UseCase) *adminHandler {
+	return &adminHandler{
+		logger: logger,
+		uc:     uc,
+	}
+}
+
+// Hide hides the article from every read. The response holds the hidden article.
+func (h *adminHandler) Hide() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		a, err := h.uc.Hide(c.Request().Context(), c.Param("id"))
+		if err != nil {
+			return httperrors.ErrorResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, a.ToRest())
+	}
+}
+
+// PurgeCache empties the article cache.
+func (h *adminHandler) PurgeCache() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		result, err := h.uc.PurgeCache(c.Request().Context())
+		if err != nil {
+			return httperrors.ErrorResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, result.ToRest())
+	}
+}
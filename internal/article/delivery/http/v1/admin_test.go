@@ -0,0 +1,82 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/sportsnews/domain"
+	mock "github.com/KarolosLykos/sportsnews/internal/article/mock"
+)
+
+func TestAdminHandler_Hide(t *testing.T) {
+	tt := []struct {
+		name string
+		stub func(uc *mock.MockUseCase)
+		code int
+	}{
+		{
+			name: "hidden",
+			stub: func(uc *mock.MockUseCase) {
+				uc.EXPECT().Hide(gomock.Any(), "6405f896a019b8815f6892c7").Times(1).
+					Return(&domain.Article{ID: "6405f896a019b8815f6892c7", Hidden: true}, nil)
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "not found",
+			stub: func(uc *mock.MockUseCase) {
+				uc.EXPECT().Hide(gomock.Any(), "6405f896a019b8815f6892c7").Times(1).
+					Return(nil, errors.New("usecase: hide:repository: hide:mongo: no documents in result"))
+			},
+			code: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := mock.NewMockUseCase(ctrl)
+			tc.stub(uc)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/articles/6405f896a019b8815f6892c7/hide", nil)
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("6405f896a019b8815f6892c7")
+
+			require.NoError(t, NewAdminHandler(getLogger(), uc).Hide()(c))
+			assert.Equal(t, tc.code, rec.Code, rec.Body.String())
+		})
+	}
+}
+
+func TestAdminHandler_PurgeCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := mock.NewMockUseCase(ctrl)
+	uc.EXPECT().PurgeCache(gomock.Any()).Times(1).Return(&domain.PurgeResult{Purged: 12}, nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/admin/cache", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	require.NoError(t, NewAdminHandler(getLogger(), uc).PurgeCache()(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	res := &domain.PurgeResultRest{}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(res))
+
+	assert.Equal(t, "success", res.Status)
+	assert.Equal(t, &domain.PurgeResult{Purged: 12}, res.Data)
+}
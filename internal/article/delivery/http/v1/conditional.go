@@ -0,0 +1,112 @@
+package v1
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+)
+
+const (
+	headerETag        = "ETag"
+	headerIfNoneMatch = "If-None-Match"
+)
+
+// respond writes the body with an ETag hashed from it, a Last-Modified time unless modified is zero
+// and the Cache-Control header unless it is empty. The format of the body may be negotiated from the
+// Accept header, so responses vary by it. A conditional request whose validators match is answered
+// with 304 Not Modified instead.
+func respond(c echo.Context, contentType, cacheControl string, modified time.Time, body []byte) error {
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	header := c.Response().Header()
+	header.Set(headerETag, etag)
+	header.Add(echo.HeaderVary, echo.HeaderAccept)
+
+	if !modified.IsZero() {
+		header.Set(echo.HeaderLastModified, modified.UTC().Format(http.TimeFormat))
+	}
+
+	if cacheControl != "" {
+		header.Set(echo.HeaderCacheControl, cacheControl)
+	}
+
+	if notModified(c.Request(), etag, modified) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	return c.Blob(http.StatusOK, contentType, body)
+}
+
+// cacheControl returns the Cache-Control header configured as value. With authentication enabled,
+// responses depend on the api key of the request, so they are private to keep shared caches from
+// serving them to requests without a key.
+func cacheControl(cfg *config.Config, value string) string {
+	if value == "" || !cfg.Auth.Enabled {
+		return value
+	}
+
+	directives := []string{"private"}
+
+	for _, d := range strings.Split(value, ",") {
+		d = strings.TrimSpace(d)
+
+		switch name := strings.ToLower(strings.SplitN(d, "=", 2)[0]); name {
+		case "", "public", "private", "s-maxage":
+			continue
+		}
+
+		directives = append(directives, d)
+	}
+
+	return strings.Join(directives, ", ")
+}
+
+// notModified reports whether the validators of a conditional request match the response.
+// If-None-Match takes precedence over If-Modified-Since, as in RFC 7232.
+func notModified(r *http.Request, etag string, modified time.Time) bool {
+	if match := r.Header.Get(headerIfNoneMatch); match != "" {
+		for _, tag := range strings.Split(match, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == "*" || tag == etag {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	since, err := http.ParseTime(r.Header.Get(echo.HeaderIfModifiedSince))
+	if err != nil || modified.IsZero() {
+		return false
+	}
+
+	// Last-Modified has a precision of a second.
+	return !modified.Truncate(time.Second).After(since)
+}
+
+// lastModified returns the latest update time of the articles. Articles stored before update
+// times were recorded count as updated when published.
+func lastModified(articles ...*domain.Article) time.Time {
+	var latest time.Time
+
+	for _, a := range articles {
+		updated := a.Updated
+		if updated.IsZero() {
+			updated = a.Published
+		}
+
+		if updated.After(latest) {
+			latest = updated
+		}
+	}
+
+	return latest
+}
@@ -0,0 +1,174 @@
+package v1
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+	mock "github.com/KarolosLykos/sportsnews/internal/article/mock"
+)
+
+func TestArticleHandler_Conditional(t *testing.T) {
+	log := getLogger()
+	cfg := &config.Config{HTTP: config.HTTP{ArticleCacheControl: "public, max-age=300"}}
+
+	updated := time.Date(2023, 3, 1, 10, 0, 0, 500, time.UTC)
+	a := &domain.Article{ID: "6406083ea019b8815f689907", Title: "Tigers win the derby", Updated: updated}
+
+	// The ETag of the article, read from a first unconditional response.
+	etag := func(t *testing.T) string {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		uc := mock.NewMockUseCase(ctrl)
+		uc.EXPECT().GetByID(gomock.Any(), a.ID).Times(1).Return(a, nil)
+
+		rec := httptest.NewRecorder()
+		c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+		c.SetParamNames("id")
+		c.SetParamValues(a.ID)
+
+		require.NoError(t, NewArticleHandler(cfg, log, uc).GetByID()(c))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		assert.Equal(t, "Wed, 01 Mar 2023 10:00:00 GMT", rec.Header().Get(echo.HeaderLastModified))
+		assert.Equal(t, "public, max-age=300", rec.Header().Get(echo.HeaderCacheControl))
+		assert.Equal(t, echo.HeaderAccept, rec.Header().Get(echo.HeaderVary))
+
+		return rec.Header().Get(headerETag)
+	}(t)
+
+	require.NotEmpty(t, etag)
+
+	tt := []struct {
+		name   string
+		header map[string]string
+		code   int
+	}{
+		{name: "unconditional", code: http.StatusOK},
+		{name: "matching etag", header: map[string]string{headerIfNoneMatch: etag}, code: http.StatusNotModified},
+		{name: "weak etag in a list", header: map[string]string{headerIfNoneMatch: `"other", W/` + etag}, code: http.StatusNotModified},
+		{name: "any etag", header: map[string]string{headerIfNoneMatch: "*"}, code: http.StatusNotModified},
+		{name: "other etag", header: map[string]string{headerIfNoneMatch: `"other"`}, code: http.StatusOK},
+		{
+			name:   "not modified since",
+			header: map[string]string{echo.HeaderIfModifiedSince: "Wed, 01 Mar 2023 10:00:00 GMT"},
+			code:   http.StatusNotModified,
+		},
+		{
+			name:   "modified since",
+			header: map[string]string{echo.HeaderIfModifiedSince: "Wed, 01 Mar 2023 09:59:59 GMT"},
+			code:   http.StatusOK,
+		},
+		{
+			name:   "etag takes precedence",
+			header: map[string]string{headerIfNoneMatch: `"other"`, echo.HeaderIfModifiedSince: "Wed, 01 Mar 2023 10:00:00 GMT"},
+			code:   http.StatusOK,
+		},
+		{
+			name:   "invalid date",
+			header: map[string]string{echo.HeaderIfModifiedSince: "yesterday"},
+			code:   http.StatusOK,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := mock.NewMockUseCase(ctrl)
+			uc.EXPECT().GetByID(gomock.Any(), a.ID).Times(1).Return(a, nil)
+
+			h := NewArticleHandler(cfg, log, uc)
+			e := echo.New()
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			for k, v := range tc.header {
+				req.Header.Set(k, v)
+			}
+
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(a.ID)
+
+			get := h.GetByID()
+			require.NoError(t, get(c))
+			assert.Equal(t, tc.code, rec.Code)
+			assert.Equal(t, etag, rec.Header().Get(headerETag))
+
+			if tc.code == http.StatusNotModified {
+				assert.Empty(t, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestArticleHandler_List_Conditional(t *testing.T) {
+	page := &domain.Articles{Total: 1, Limit: 1, Articles: []*domain.Article{
+		{ID: "6406083ea019b8815f689907", Title: "Tigers win the derby", Updated: time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)},
+	}}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := mock.NewMockUseCase(ctrl)
+	uc.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).Return(page, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/articles", nil)
+	req.Header.Set(echo.HeaderIfModifiedSince, "Wed, 01 Mar 2023 10:00:00 GMT")
+
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	require.NoError(t, NewArticleHandler(&config.Config{}, getLogger(), uc).List()(c))
+
+	// A page is only validated by its ETag, since an article leaving it does not change the latest update.
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get(echo.HeaderLastModified))
+	assert.NotEmpty(t, rec.Header().Get(headerETag))
+}
+
+func TestCacheControl(t *testing.T) {
+	tt := []struct {
+		name     string
+		enabled  bool
+		value    string
+		expected string
+	}{
+		{name: "authentication disabled", value: "public, max-age=300", expected: "public, max-age=300"},
+		{name: "public", enabled: true, value: "public, max-age=300", expected: "private, max-age=300"},
+		{name: "shared max age", enabled: true, value: "public, max-age=60, s-maxage=600", expected: "private, max-age=60"},
+		{name: "private", enabled: true, value: "private, no-cache", expected: "private, no-cache"},
+		{name: "without visibility", enabled: true, value: "max-age=300", expected: "private, max-age=300"},
+		{name: "empty", enabled: true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &config.Config{}
+			cfg.Auth.Enabled = tc.enabled
+
+			assert.Equal(t, tc.expected, cacheControl(cfg, tc.value))
+		})
+	}
+}
+
+func TestLastModified(t *testing.T) {
+	published := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.True(t, lastModified().IsZero())
+	assert.Equal(t, published.Add(2*time.Hour), lastModified(
+		&domain.Article{Published: published, Updated: published.Add(time.Hour)},
+		&domain.Article{Published: published.Add(2 * time.Hour)},
+	))
+}
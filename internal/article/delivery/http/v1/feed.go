@@ -0,0 +1,117 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+	apikeyv1 "github.com/KarolosLykos/sportsnews/internal/apikey/delivery/http/v1"
+	"github.com/KarolosLykos/sportsnews/internal/article"
+	httperrors "github.com/KarolosLykos/sportsnews/internal/utils/http_errors"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+// feedTypes are the content types of the feeds, by extension.
+var feedTypes = map[string]string{
+	".rss":  "application/rss+xml; charset=utf-8",
+	".atom": "application/atom+xml; charset=utf-8",
+	".json": "application/feed+json; charset=utf-8",
+}
+
+type feedHandler struct {
+	cfg    *config.Config
+	logger logger.Logger
+	uc     article.UseCase
+}
+
+func NewFeedHandler(cfg *config.Config, logger logger.Logger, uc article.UseCase) *feedHandler {
+	return &feedHandler{
+		cfg:    cfg,
+		logger: logger,
+		uc:     uc,
+	}
+}
+
+// Feed returns the latest published articles of a team as RSS, Atom or JSON Feed, by the extension
+// of the feed parameter. Articles can be restricted to some categories, repeated or comma separated.
+func (h *feedHandler) Feed() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		name, err := url.PathUnescape(c.Param("feed"))
+		if err != nil {
+			return httperrors.ErrorResponse(c, fmt.Errorf("%w:%v", httperrors.ErrBadRequest, err))
+		}
+
+		ext := path.Ext(name)
+		teamID := strings.TrimSuffix(name, ext)
+
+		contentType, ok := feedTypes[ext]
+		if !ok || teamID == "" {
+			return httperrors.ErrorResponse(c, fmt.Errorf("%w:unknown feed %q", httperrors.ErrNotFound, name))
+		}
+
+		params, err := h.feedParams(c, teamID)
+		if err != nil {
+			return httperrors.ErrorResponse(c, fmt.Errorf("%w:%v", httperrors.ErrBadRequest, err))
+		}
+
+		articles, err := h.uc.List(c.Request().Context(), params)
+		if err != nil {
+			return httperrors.ErrorResponse(c, err)
+		}
+
+		feed := &domain.Feed{
+			Title:    teamID + " news",
+			Link:     h.cfg.HTTP.PublicURL + "/api/v1/articles?teamId=" + url.QueryEscape(teamID),
+			URL:      h.cfg.HTTP.PublicURL + apikeyv1.WithoutKey(c.Request().URL).RequestURI(),
+			Updated:  lastModified(articles.Articles...),
+			Articles: articles.Articles,
+			ArticleURL: func(a *domain.Article) string {
+				return h.cfg.HTTP.PublicURL + "/api/v1/articles/" + a.ID
+			},
+		}
+
+		var body []byte
+
+		switch ext {
+		case ".rss":
+			body, err = encodeXML(feed.ToRSS())
+		case ".atom":
+			body, err = encodeXML(feed.ToAtom())
+		default:
+			body, err = json.Marshal(feed.ToJSONFeed())
+		}
+
+		if err != nil {
+			return httperrors.ErrorResponse(c, err)
+		}
+
+		// Feeds are sent without Last-Modified, like pages: an article that leaves the feed does not
+		// make its latest update any later, so only the ETag tells the feed changed.
+		return respond(c, contentType, cacheControl(h.cfg, h.cfg.HTTP.FeedCacheControl), time.Time{}, body)
+	}
+}
+
+// feedParams selects the latest published articles of the team, in the categories of the query.
+func (h *feedHandler) feedParams(c echo.Context, teamID string) (domain.ListParams, error) {
+	published := true
+	params := domain.ListParams{
+		Filter: domain.ArticleFilter{TeamID: teamID, IsPublished: &published},
+		Sort:   domain.DefaultSort,
+	}
+
+	var err error
+	if params.Limit, err = queryInt(c, "limit"); err != nil {
+		return params, err
+	}
+
+	params.Filter.Types = queryList(c, "category")
+
+	return params, params.Filter.Validate()
+}
@@ -0,0 +1,170 @@
+package v1
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+	mock "github.com/KarolosLykos/sportsnews/internal/article/mock"
+)
+
+func TestFeedHandler_Feed(t *testing.T) {
+	log := getLogger()
+	cfg := &config.Config{HTTP: config.HTTP{PublicURL: "https://news.example.com", FeedCacheControl: "public, max-age=300"}}
+
+	published := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+	articles := &domain.Articles{Articles: []*domain.Article{
+		{
+			ID:        "6406083ea019b8815f689907",
+			Title:     "Tigers win the derby",
+			Teaser:    "A late goal settles it",
+			Content:   "<p>A late goal settles the derby.</p>",
+			URL:       "https://www.wearehullcity.co.uk/news/derby",
+			ImageURL:  "https://www.wearehullcity.co.uk/image/derby.jpg",
+			VideoURL:  "https://www.wearehullcity.co.uk/video/derby.mp4",
+			Type:      []string{"Match Report"},
+			Published: published,
+			Updated:   published.Add(time.Hour),
+		},
+	}}
+
+	yes := true
+
+	filter := domain.ArticleFilter{TeamID: "Hull City", IsPublished: &yes}
+
+	tt := []struct {
+		name        string
+		feed        string
+		query       string
+		filter      domain.ArticleFilter
+		code        int
+		contentType string
+		check       func(t *testing.T, body []byte)
+	}{
+		{
+			name:        "rss",
+			feed:        "Hull%20City.rss",
+			filter:      filter,
+			code:        http.StatusOK,
+			contentType: "application/rss+xml; charset=utf-8",
+			check: func(t *testing.T, body []byte) {
+				rss := &domain.RSS{}
+				require.NoError(t, xml.Unmarshal(body, rss))
+				assert.Equal(t, "2.0", rss.Version)
+				assert.Equal(t, "Hull City news", rss.Channel.Title)
+				assert.Equal(t, "Wed, 01 Mar 2023 11:00:00 +0000", rss.Channel.LastBuildDate)
+
+				require.Len(t, rss.Channel.Items, 1)
+				item := rss.Channel.Items[0]
+				assert.Equal(t, "Wed, 01 Mar 2023 10:00:00 +0000", item.PubDate)
+				assert.Equal(t, "https://news.example.com/api/v1/articles/6406083ea019b8815f689907", item.GUID.Value)
+				assert.Equal(t, "A late goal settles it", item.Description)
+				assert.Equal(t, []string{"Match Report"}, item.Categories)
+				assert.Equal(t, []domain.RSSEnclosure{
+					{URL: "https://www.wearehullcity.co.uk/image/derby.jpg", Type: "image/jpeg"},
+					{URL: "https://www.wearehullcity.co.uk/video/derby.mp4", Type: "video/mp4"},
+				}, item.Enclosures)
+			},
+		},
+		{
+			name:        "atom with categories",
+			feed:        "Hull%20City.atom",
+			query:       "?category=Academy,News&category=Video",
+			filter:      domain.ArticleFilter{TeamID: "Hull City", IsPublished: &yes, Types: []string{"Academy", "News", "Video"}},
+			code:        http.StatusOK,
+			contentType: "application/atom+xml; charset=utf-8",
+			check: func(t *testing.T, body []byte) {
+				atom := &domain.Atom{}
+				require.NoError(t, xml.Unmarshal(body, atom))
+				assert.Equal(t, "2023-03-01T11:00:00Z", atom.Updated)
+
+				require.Len(t, atom.Entries, 1)
+				entry := atom.Entries[0]
+				assert.Equal(t, "2023-03-01T10:00:00Z", entry.Published)
+				assert.Equal(t, "2023-03-01T11:00:00Z", entry.Updated)
+				assert.Equal(t, "html", entry.Content.Type)
+				assert.Equal(t, "<p>A late goal settles the derby.</p>", entry.Content.Value)
+				assert.Contains(t, entry.Links, domain.AtomLink{Href: "https://www.wearehullcity.co.uk/video/derby.mp4", Rel: "enclosure", Type: "video/mp4"})
+			},
+		},
+		{
+			name:        "json feed",
+			feed:        "Hull%20City.json",
+			query:       "?apiKey=snk_1",
+			filter:      filter,
+			code:        http.StatusOK,
+			contentType: "application/feed+json; charset=utf-8",
+			check: func(t *testing.T, body []byte) {
+				feed := &domain.JSONFeed{}
+				require.NoError(t, json.Unmarshal(body, feed))
+				assert.Equal(t, "https://jsonfeed.org/version/1.1", feed.Version)
+				assert.Equal(t, "https://news.example.com/feeds/Hull%20City.json", feed.FeedURL)
+
+				require.Len(t, feed.Items, 1)
+				item := feed.Items[0]
+				assert.Equal(t, "https://www.wearehullcity.co.uk/image/derby.jpg", item.Image)
+				assert.Equal(t, []*domain.JSONFeedAttachment{
+					{URL: "https://www.wearehullcity.co.uk/video/derby.mp4", MimeType: "video/mp4"},
+				}, item.Attachments)
+			},
+		},
+		{
+			name: "unknown format",
+			feed: "Hull%20City.yaml",
+			code: http.StatusNotFound,
+		},
+		{
+			name: "no team",
+			feed: ".rss",
+			code: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := mock.NewMockUseCase(ctrl)
+			if tc.code == http.StatusOK {
+				uc.EXPECT().List(gomock.Any(), domain.ListParams{Filter: tc.filter, Sort: domain.DefaultSort}).Times(1).
+					Return(articles, nil)
+			}
+
+			h := NewFeedHandler(cfg, log, uc)
+			e := echo.New()
+
+			req := httptest.NewRequest(http.MethodGet, "/feeds/"+tc.feed+tc.query, nil)
+
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("feed")
+			c.SetParamValues(tc.feed)
+
+			feed := h.Feed()
+			require.NoError(t, feed(c))
+			assert.Equal(t, tc.code, rec.Code)
+
+			if tc.check == nil {
+				return
+			}
+
+			assert.Equal(t, tc.contentType, rec.Header().Get(echo.HeaderContentType))
+			assert.Equal(t, "public, max-age=300", rec.Header().Get(echo.HeaderCacheControl))
+			assert.NotEmpty(t, rec.Header().Get(headerETag))
+			assert.Empty(t, rec.Header().Get(echo.HeaderLastModified))
+
+			tc.check(t, rec.Body.Bytes())
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"mime"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/KarolosLykos/sportsnews/domain"
+	httperrors "github.com/KarolosLykos/sportsnews/internal/utils/http_errors"
+)
+
+// format is an output format of the articles.
+type format string
+
+const (
+	formatJSON   format = "json"
+	formatXML    format = "xml"
+	formatCSV    format = "csv"
+	formatNDJSON format = "ndjson"
+)
+
+const (
+	mimeTextCSV = "text/csv"
+	mimeNDJSON  = "application/x-ndjson"
+
+	headerTotalCount = "X-Total-Count"
+	headerLink       = "Link"
+)
+
+// contentTypes are the content types sent with each format.
+var contentTypes = map[format]string{
+	formatJSON:   echo.MIMEApplicationJSONCharsetUTF8,
+	formatXML:    echo.MIMEApplicationXMLCharsetUTF8,
+	formatCSV:    mimeTextCSV + "; charset=utf-8",
+	formatNDJSON: mimeNDJSON,
+}
+
+// mediaTypes are the media types of an Accept header that select each format.
+var mediaTypes = map[string]format{
+	"*/*":                    formatJSON,
+	"application/*":          formatJSON,
+	echo.MIMEApplicationJSON: formatJSON,
+	echo.MIMEApplicationXML:  formatXML,
+	echo.MIMETextXML:         formatXML,
+	mimeTextCSV:              formatCSV,
+	mimeNDJSON:               formatNDJSON,
+	"application/ndjson":     formatNDJSON,
+}
+
+// negotiate returns the format of the response: the format query parameter if any, or else the
+// media type the Accept header prefers. JSON is the default.
+func negotiate(c echo.Context) (format, error) {
+	if f := format(c.QueryParam("format")); f != "" {
+		if _, ok := contentTypes[f]; !ok {
+			return "", fmt.Errorf("%w:unknown format %q", httperrors.ErrNotAcceptable, f)
+		}
+
+		return f, nil
+	}
+
+	accept := c.Request().Header.Get(echo.HeaderAccept)
+	if strings.TrimSpace(accept) == "" {
+		return formatJSON, nil
+	}
+
+	var (
+		best  format
+		bestQ float64
+	)
+
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(mediaRange)
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+
+		if f, ok := mediaTypes[mediaType]; ok && q > bestQ {
+			best, bestQ = f, q
+		}
+	}
+
+	if best == "" {
+		return "", fmt.Errorf("%w:none of %q", httperrors.ErrNotAcceptable, accept)
+	}
+
+	return best, nil
+}
+
+// encodeArticle returns the article in the format. NDJSON and CSV leave out the response status.
+func encodeArticle(f format, a *domain.Article) ([]byte, error) {
+	switch f {
+	case formatXML:
+		return encodeXML(a.ToRest())
+	case formatCSV:
+		return encodeCSV(nil, a)
+	case formatNDJSON:
+		b, err := json.Marshal(a)
+		if err != nil {
+			return nil, err
+		}
+
+		return append(b, '\n'), nil
+	}
+
+	return json.Marshal(a.ToRest())
+}
+
+// encodePage returns the page of articles restricted to the fields in the format, with the links
+// to the neighbouring pages. CSV has no room for metadata, so the total and the links are sent in the
+// X-Total-Count and Link headers instead.
+func encodePage(c echo.Context, f format, page *domain.Articles, fields domain.Fields) ([]byte, error) {
+	res := page.ToRest()
+	res.Metadata.Links = links(c.Request().URL, res.Metadata)
+
+	switch f {
+	case formatCSV:
+		header := c.Response().Header()
+		header.Set(headerTotalCount, strconv.FormatInt(page.Total, 10))
+
+		if l := linkHeader(res.Metadata.Links); l != "" {
+			header.Set(headerLink, l)
+		}
+
+		return encodeCSV(fields, page.Articles...)
+	case formatXML:
+		if fields != nil {
+			res.Data = make([]*domain.Article, 0, len(page.Articles))
+			for _, a := range page.Articles {
+				res.Data = append(res.Data, fields.Keep(a))
+			}
+		}
+
+		return encodeXML(res)
+	}
+
+	if fields == nil {
+		return json.Marshal(res)
+	}
+
+	partial, err := page.ToPartialRest(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	partial.Metadata.Links = res.Metadata.Links
+
+	return json.Marshal(partial)
+}
+
+func encodeXML(v interface{}) ([]byte, error) {
+	b, err := xml.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), b...), nil
+}
+
+// encodeCSV returns a header row with the names of the fields and a row for each article.
+func encodeCSV(fields domain.Fields, articles ...*domain.Article) ([]byte, error) {
+	var buf bytes.Buffer
+
+	w := csv.NewWriter(&buf)
+	if err := w.Write(fields.Names()); err != nil {
+		return nil, err
+	}
+
+	for _, a := range articles {
+		if err := w.Write(fields.Record(a)); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+
+	return buf.Bytes(), w.Error()
+}
+
+// linkHeader returns the Link header of the links to the neighbouring pages, empty when there are none.
+func linkHeader(l domain.Links) string {
+	rels := make([]string, 0, 2)
+
+	if l.Next != "" {
+		rels = append(rels, fmt.Sprintf(`<%s>; rel="next"`, l.Next))
+	}
+
+	if l.Prev != "" {
+		rels = append(rels, fmt.Sprintf(`<%s>; rel="prev"`, l.Prev))
+	}
+
+	return strings.Join(rels, ", ")
+}
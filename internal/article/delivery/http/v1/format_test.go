@@ -0,0 +1,317 @@
+package v1
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+	mock "github.com/KarolosLykos/sportsnews/internal/article/mock"
+	httperrors "github.com/KarolosLykos/sportsnews/internal/utils/http_errors"
+)
+
+func TestNegotiate(t *testing.T) {
+	tt := []struct {
+		name   string
+		query  string
+		accept string
+		want   format
+		err    error
+	}{
+		{name: "default", want: formatJSON},
+		{name: "any", accept: "*/*", want: formatJSON},
+		{name: "xml", accept: "application/xml", want: formatXML},
+		{name: "text xml", accept: "text/xml", want: formatXML},
+		{name: "csv", accept: "text/csv", want: formatCSV},
+		{name: "ndjson", accept: "application/x-ndjson", want: formatNDJSON},
+		{name: "quality", accept: "application/xml;q=0.5, text/csv;q=0.8, */*;q=0.1", want: formatCSV},
+		{name: "unsupported types are skipped", accept: "text/html, application/xml;q=0.9", want: formatXML},
+		{name: "format wins", query: "?format=csv", accept: "application/xml", want: formatCSV},
+		{name: "unsupported", accept: "text/html, image/png", err: httperrors.ErrNotAcceptable},
+		{name: "unknown format", query: "?format=yaml", err: httperrors.ErrNotAcceptable},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/"+tc.query, nil)
+			req.Header.Set(echo.HeaderAccept, tc.accept)
+
+			f, err := negotiate(echo.New().NewContext(req, httptest.NewRecorder()))
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, f)
+		})
+	}
+}
+
+func TestArticleHandler_GetByID_Formats(t *testing.T) {
+	log := getLogger()
+
+	a := &domain.Article{
+		ID:        "6406083ea019b8815f689907",
+		Title:     "Tigers win the derby",
+		Type:      []string{"Match Report", "News"},
+		Published: time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	tt := []struct {
+		name        string
+		accept      string
+		code        int
+		contentType string
+		check       func(t *testing.T, body string)
+	}{
+		{
+			name:        "xml",
+			accept:      "application/xml",
+			code:        http.StatusOK,
+			contentType: echo.MIMEApplicationXMLCharsetUTF8,
+			check: func(t *testing.T, body string) {
+				res := &domain.ArticleRest{}
+				require.NoError(t, xml.Unmarshal([]byte(body), res))
+				assert.Equal(t, "success", res.Status)
+				assert.Equal(t, a.Title, res.Data.Title)
+				assert.Equal(t, a.Type, res.Data.Type)
+				assert.True(t, a.Published.Equal(res.Data.Published))
+			},
+		},
+		{
+			name:        "csv",
+			accept:      "text/csv",
+			code:        http.StatusOK,
+			contentType: "text/csv; charset=utf-8",
+			check: func(t *testing.T, body string) {
+				records, err := csv.NewReader(strings.NewReader(body)).ReadAll()
+				require.NoError(t, err)
+				require.Len(t, records, 2)
+
+				row := map[string]string{}
+				for i, name := range records[0] {
+					row[name] = records[1][i]
+				}
+
+				assert.NotContains(t, records[0], "-")
+				assert.Equal(t, a.ID, row["id"])
+				assert.Equal(t, "Match Report|News", row["type"])
+				assert.Equal(t, "2023-03-01T10:00:00Z", row["published"])
+				assert.Equal(t, "", row["updated"])
+			},
+		},
+		{
+			name:        "ndjson",
+			accept:      "application/x-ndjson",
+			code:        http.StatusOK,
+			contentType: mimeNDJSON,
+			check: func(t *testing.T, body string) {
+				assert.True(t, strings.HasSuffix(body, "}\n"))
+				assert.Equal(t, 1, strings.Count(body, "\n"))
+			},
+		},
+		{
+			name:   "not acceptable",
+			accept: "text/html",
+			code:   http.StatusNotAcceptable,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := mock.NewMockUseCase(ctrl)
+			if tc.code == http.StatusOK {
+				uc.EXPECT().GetByID(gomock.Any(), a.ID).Times(1).Return(a, nil)
+			}
+
+			h := NewArticleHandler(&config.Config{}, log, uc)
+			e := echo.New()
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(echo.HeaderAccept, tc.accept)
+
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(a.ID)
+
+			get := h.GetByID()
+			require.NoError(t, get(c))
+			assert.Equal(t, tc.code, rec.Code)
+
+			if tc.check != nil {
+				assert.Equal(t, tc.contentType, rec.Header().Get(echo.HeaderContentType))
+				tc.check(t, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestArticleHandler_List_Formats(t *testing.T) {
+	log := getLogger()
+
+	articles := &domain.Articles{
+		Total: 3,
+		Limit: 2,
+		Articles: []*domain.Article{
+			{ID: "3", Title: "Third", Content: "<p>third</p>"},
+			{ID: "2", Title: "Second, with a comma", Content: "<p>second</p>"},
+		},
+		Next: &domain.Cursor{Sort: domain.DefaultSort, Values: []interface{}{time.Time{}}, ID: "2"},
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		uc := mock.NewMockUseCase(ctrl)
+		uc.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).Return(articles, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/articles?format=csv&fields=title&limit=2", nil)
+		rec := httptest.NewRecorder()
+
+		require.NoError(t, NewArticleHandler(&config.Config{}, log, uc).List()(echo.New().NewContext(req, rec)))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "3", rec.Header().Get(headerTotalCount))
+		assert.Contains(t, rec.Header().Get(headerLink), `rel="next"`)
+
+		records, err := csv.NewReader(rec.Body).ReadAll()
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{{"id", "title"}, {"3", "Third"}, {"2", "Second, with a comma"}}, records)
+	})
+
+	t.Run("xml", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		uc := mock.NewMockUseCase(ctrl)
+		uc.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).Return(articles, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/articles?view=summary", nil)
+		req.Header.Set(echo.HeaderAccept, "application/xml")
+		rec := httptest.NewRecorder()
+
+		require.NoError(t, NewArticleHandler(&config.Config{}, log, uc).List()(echo.New().NewContext(req, rec)))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "<content>")
+
+		res := &domain.ArticlesRest{}
+		require.NoError(t, xml.Unmarshal(rec.Body.Bytes(), res))
+		assert.Equal(t, int64(3), res.Metadata.Total)
+		assert.NotEmpty(t, res.Metadata.Links.Next)
+		require.Len(t, res.Data, 2)
+		assert.Equal(t, "Third", res.Data[0].Title)
+	})
+
+	t.Run("not acceptable", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/articles", nil)
+		req.Header.Set(echo.HeaderAccept, "application/pdf")
+		rec := httptest.NewRecorder()
+
+		require.NoError(t, NewArticleHandler(&config.Config{}, log, mock.NewMockUseCase(ctrl)).List()(echo.New().NewContext(req, rec)))
+		assert.Equal(t, http.StatusNotAcceptable, rec.Code)
+	})
+}
+
+func TestArticleHandler_List_Stream(t *testing.T) {
+	log := getLogger()
+
+	next := &domain.Cursor{Sort: domain.DefaultSort, Values: []interface{}{time.Time{}}, ID: "2"}
+
+	tt := []struct {
+		name  string
+		query string
+		stub  func(uc *mock.MockUseCase)
+		code  int
+		lines []string
+	}{
+		{
+			name:  "every page",
+			query: "?format=ndjson&teamId=Hull+City&fields=title&offset=5",
+			stub: func(uc *mock.MockUseCase) {
+				filter := domain.ArticleFilter{TeamID: "Hull City"}
+				fields := domain.Fields{"id", "title"}
+
+				gomock.InOrder(
+					uc.EXPECT().List(gomock.Any(), domain.ListParams{Filter: filter, Fields: fields, Limit: domain.MaxPageSize, Offset: 5}).
+						Times(1).Return(&domain.Articles{Articles: []*domain.Article{{ID: "3", Title: "c"}, {ID: "2", Title: "b"}}, Next: next}, nil),
+					uc.EXPECT().List(gomock.Any(), domain.ListParams{Filter: filter, Fields: fields, Limit: domain.MaxPageSize, Cursor: next}).
+						Times(1).Return(&domain.Articles{Articles: []*domain.Article{{ID: "1", Title: "a"}}}, nil),
+				)
+			},
+			code:  http.StatusOK,
+			lines: []string{`{"id":"3","title":"c"}`, `{"id":"2","title":"b"}`, `{"id":"1","title":"a"}`},
+		},
+		{
+			name:  "error on a later page",
+			query: "?format=ndjson&fields=title",
+			stub: func(uc *mock.MockUseCase) {
+				gomock.InOrder(
+					uc.EXPECT().List(gomock.Any(), gomock.Any()).
+						Times(1).Return(&domain.Articles{Articles: []*domain.Article{{ID: "3", Title: "c"}}, Next: next}, nil),
+					uc.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("something went wrong")),
+				)
+			},
+			code:  http.StatusOK,
+			lines: []string{`{"id":"3","title":"c"}`},
+		},
+		{
+			name:  "error on the first page",
+			query: "?format=ndjson",
+			stub: func(uc *mock.MockUseCase) {
+				uc.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("something went wrong"))
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := mock.NewMockUseCase(ctrl)
+			tc.stub(uc)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/articles"+tc.query, nil)
+			rec := httptest.NewRecorder()
+
+			require.NoError(t, NewArticleHandler(&config.Config{}, log, uc).List()(echo.New().NewContext(req, rec)))
+			assert.Equal(t, tc.code, rec.Code)
+
+			if tc.code != http.StatusOK {
+				return
+			}
+
+			assert.Equal(t, mimeNDJSON, rec.Header().Get(echo.HeaderContentType))
+
+			lines := make([]string, 0)
+			for s := bufio.NewScanner(rec.Body); s.Scan(); {
+				lines = append(lines, s.Text())
+				assert.True(t, json.Valid(s.Bytes()))
+			}
+
+			assert.Equal(t, tc.lines, lines)
+		})
+	}
+}
@@ -1,22 +1,36 @@
 package v1
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+	apikeyv1 "github.com/KarolosLykos/sportsnews/internal/apikey/delivery/http/v1"
 	"github.com/KarolosLykos/sportsnews/internal/article"
 	httperrors "github.com/KarolosLykos/sportsnews/internal/utils/http_errors"
 	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
 )
 
+const dateLayout = "2006-01-02"
+
 type articleHandler struct {
+	cfg    *config.Config
 	logger logger.Logger
 	uc     article.UseCase
 }
 
-func NewArticleHandler(logger logger.Logger, uc article.UseCase) *articleHandler {
+func NewArticleHandler(cfg *config.Config, logger logger.Logger, uc article.UseCase) *articleHandler {
 	return &articleHandler{
+		cfg:    cfg,
 		logger: logger,
 		uc:     uc,
 	}
@@ -24,6 +38,11 @@ func NewArticleHandler(logger logger.Logger, uc article.UseCase) *articleHandler
 
 func (h *articleHandler) GetByID() echo.HandlerFunc {
 	return func(c echo.Context) error {
+		f, err := negotiate(c)
+		if err != nil {
+			return httperrors.ErrorResponse(c, err)
+		}
+
 		id := c.Param("id")
 
 		a, err := h.uc.GetByID(c.Request().Context(), id)
@@ -31,17 +50,353 @@ func (h *articleHandler) GetByID() echo.HandlerFunc {
 			return httperrors.ErrorResponse(c, err)
 		}
 
-		return c.JSON(http.StatusOK, a.ToRest())
+		return h.respondArticle(c, f, a)
+	}
+}
+
+// GetByArticleID returns the article with the id given to it by the provider.
+func (h *articleHandler) GetByArticleID() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		f, err := negotiate(c)
+		if err != nil {
+			return httperrors.ErrorResponse(c, err)
+		}
+
+		a, err := h.uc.GetByArticleID(c.Request().Context(), c.Param("provider"), c.Param("articleID"))
+		if err != nil {
+			return httperrors.ErrorResponse(c, err)
+		}
+
+		return h.respondArticle(c, f, a)
+	}
+}
+
+func (h *articleHandler) GetBySlug() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		f, err := negotiate(c)
+		if err != nil {
+			return httperrors.ErrorResponse(c, err)
+		}
+
+		a, err := h.uc.GetBySlug(c.Request().Context(), c.Param("slug"))
+		if err != nil {
+			return httperrors.ErrorResponse(c, err)
+		}
+
+		return h.respondArticle(c, f, a)
 	}
 }
 
+// List returns a page of the filtered articles. Pages are selected with limit and either offset or cursor.
 func (h *articleHandler) List() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		articles, err := h.uc.List(c.Request().Context())
+		f, err := negotiate(c)
+		if err != nil {
+			return httperrors.ErrorResponse(c, err)
+		}
+
+		params, err := listParams(c)
+		if err != nil {
+			return httperrors.ErrorResponse(c, fmt.Errorf("%w:%v", httperrors.ErrBadRequest, err))
+		}
+
+		if f == formatNDJSON {
+			return h.stream(c, params)
+		}
+
+		articles, err := h.uc.List(c.Request().Context(), params)
+		if err != nil {
+			return httperrors.ErrorResponse(c, err)
+		}
+
+		body, err := encodePage(c, f, articles, params.Fields)
+		if err != nil {
+			return httperrors.ErrorResponse(c, err)
+		}
+
+		// Pages are sent without Last-Modified: an article that leaves the page does not make the
+		// latest update of the page any later, so only the ETag tells the page changed.
+		return respond(c, contentTypes[f], cacheControl(h.cfg, h.cfg.HTTP.ListCacheControl), time.Time{}, body)
+	}
+}
+
+// stream writes every article of the list from the position of params as NDJSON, reading a page
+// at a time and flushing it, so that large lists are neither held in memory nor limited to a page.
+func (h *articleHandler) stream(c echo.Context, params domain.ListParams) error {
+	ctx := c.Request().Context()
+	params.Limit = domain.MaxPageSize
+
+	page, err := h.uc.List(ctx, params)
+	if err != nil {
+		return httperrors.ErrorResponse(c, err)
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, contentTypes[formatNDJSON])
+	res.Header().Add(echo.HeaderVary, echo.HeaderAccept)
+
+	if value := cacheControl(h.cfg, h.cfg.HTTP.ListCacheControl); value != "" {
+		res.Header().Set(echo.HeaderCacheControl, value)
+	}
+
+	res.WriteHeader(http.StatusOK)
+	enc := json.NewEncoder(res)
+
+	for {
+		for _, a := range page.Articles {
+			var line interface{} = a
+			if params.Fields != nil {
+				if line, err = params.Fields.Select(a); err != nil {
+					h.logger.Warn(ctx, err, "could not stream articles")
+					return nil
+				}
+			}
+
+			if err = enc.Encode(line); err != nil {
+				h.logger.Warn(ctx, err, "could not stream articles")
+				return nil
+			}
+		}
+
+		res.Flush()
+
+		if page.Next == nil {
+			return nil
+		}
+
+		params.Offset, params.Cursor = 0, page.Next
+		if page, err = h.uc.List(ctx, params); err != nil {
+			h.logger.Warn(ctx, err, "could not stream articles")
+			return nil
+		}
+	}
+}
+
+func (h *articleHandler) respondArticle(c echo.Context, f format, a *domain.Article) error {
+	body, err := encodeArticle(f, a)
+	if err != nil {
+		return httperrors.ErrorResponse(c, err)
+	}
+
+	return respond(c, contentTypes[f], cacheControl(h.cfg, h.cfg.HTTP.ArticleCacheControl), lastModified(a), body)
+}
+
+// Search returns a page of the filtered articles matching q, most relevant first.
+func (h *articleHandler) Search() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		params, err := searchParams(c)
+		if err != nil {
+			return httperrors.ErrorResponse(c, fmt.Errorf("%w:%v", httperrors.ErrBadRequest, err))
+		}
+
+		results, err := h.uc.Search(c.Request().Context(), params)
 		if err != nil {
 			return httperrors.ErrorResponse(c, err)
 		}
 
-		return c.JSON(http.StatusOK, articles.ToRest())
+		return c.JSON(http.StatusOK, results.ToRest())
+	}
+}
+
+// searchParams reads the search of the request from its query.
+func searchParams(c echo.Context) (domain.SearchParams, error) {
+	var (
+		params = domain.SearchParams{Query: c.QueryParam("q")}
+		err    error
+	)
+
+	if params.Limit, err = queryInt(c, "limit"); err != nil {
+		return params, err
+	}
+
+	if params.Offset, err = queryInt(c, "offset"); err != nil {
+		return params, err
+	}
+
+	fuzzy, err := queryBool(c, "fuzzy")
+	if err != nil {
+		return params, err
+	}
+
+	params.Fuzzy = fuzzy != nil && *fuzzy
+
+	if params.Filter, err = articleFilter(c); err != nil {
+		return params, err
+	}
+
+	return params, params.Validate()
+}
+
+// listParams reads the page of the request from its query.
+func listParams(c echo.Context) (domain.ListParams, error) {
+	var (
+		params domain.ListParams
+		err    error
+	)
+
+	if params.Limit, err = queryInt(c, "limit"); err != nil {
+		return params, err
+	}
+
+	if params.Offset, err = queryInt(c, "offset"); err != nil {
+		return params, err
+	}
+
+	if s := c.QueryParam("sort"); s != "" {
+		if params.Sort, err = domain.ParseSort(s); err != nil {
+			return params, err
+		}
+	}
+
+	if s := c.QueryParam("cursor"); s != "" {
+		if params.Offset > 0 {
+			return params, errors.New("offset and cursor are mutually exclusive")
+		}
+
+		if params.Cursor, err = domain.DecodeCursor(s); err != nil {
+			return params, err
+		}
+
+		if params.Sort != nil && params.Sort.String() != params.Cursor.Sort.String() {
+			return params, errors.New("cursor was made for another sort")
+		}
+	}
+
+	if params.Fields, err = domain.ParseFields(c.QueryParam("fields"), c.QueryParam("view")); err != nil {
+		return params, err
+	}
+
+	if params.Filter, err = articleFilter(c); err != nil {
+		return params, err
+	}
+
+	return params, params.Filter.Validate()
+}
+
+// articleFilter reads the article filter from the query. Types may be repeated or comma separated.
+func articleFilter(c echo.Context) (domain.ArticleFilter, error) {
+	var err error
+
+	f := domain.ArticleFilter{
+		TeamID:      c.QueryParam("teamId"),
+		TypeMatch:   domain.TypeMatch(c.QueryParam("typeMatch")),
+		OptaMatchID: c.QueryParam("optaMatchId"),
+	}
+
+	f.Types = queryList(c, "type")
+
+	if f.PublishedSince, err = queryTime(c, "publishedSince", false); err != nil {
+		return f, err
+	}
+
+	if f.PublishedUntil, err = queryTime(c, "publishedUntil", true); err != nil {
+		return f, err
+	}
+
+	if f.IsPublished, err = queryBool(c, "isPublished"); err != nil {
+		return f, err
+	}
+
+	if f.HasVideo, err = queryBool(c, "hasVideo"); err != nil {
+		return f, err
+	}
+
+	return f, nil
+}
+
+// queryList reads a query parameter that may be repeated or comma separated, nil when missing.
+func queryList(c echo.Context, name string) []string {
+	var values []string
+
+	for _, v := range c.QueryParams()[name] {
+		for _, s := range strings.Split(v, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				values = append(values, s)
+			}
+		}
+	}
+
+	return values
+}
+
+// queryInt reads a non negative integer query parameter, zero when missing.
+func queryInt(c echo.Context, name string) (int, error) {
+	s := c.QueryParam(name)
+	if s == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%s must be a non negative integer", name)
+	}
+
+	return n, nil
+}
+
+// queryBool reads an optional boolean query parameter.
+func queryBool(c echo.Context, name string) (*bool, error) {
+	s := c.QueryParam(name)
+	if s == "" {
+		return nil, nil
+	}
+
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be a boolean", name)
+	}
+
+	return &b, nil
+}
+
+// queryTime reads an optional RFC 3339 time or date query parameter. A date stands for the
+// start of the day, or for the start of the next day when end is set, so that it is included
+// by an exclusive upper bound.
+func queryTime(c echo.Context, name string, end bool) (*time.Time, error) {
+	s := c.QueryParam(name)
+	if s == "" {
+		return nil, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return &t, nil
+	}
+
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be an RFC 3339 time or a date", name)
+	}
+
+	if end {
+		t = t.AddDate(0, 0, 1)
+	}
+
+	return &t, nil
+}
+
+// links returns the links of the page, keeping any other query parameter of the request but its
+// API key.
+func links(u *url.URL, m domain.Metadata) domain.Links {
+	u = apikeyv1.WithoutKey(u)
+
+	link := func(cursor string) string {
+		q := u.Query()
+		q.Del("offset")
+		q.Set("limit", strconv.Itoa(m.Limit))
+		q.Set("cursor", cursor)
+
+		return u.Path + "?" + q.Encode()
+	}
+
+	l := domain.Links{Self: u.RequestURI()}
+
+	if m.NextCursor != "" {
+		l.Next = link(m.NextCursor)
 	}
+
+	if m.PrevCursor != "" {
+		l.Prev = link(m.PrevCursor)
+	}
+
+	return l
 }
@@ -1,6 +1,7 @@
 package v1
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
@@ -8,6 +9,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/labstack/echo/v4"
@@ -78,7 +80,7 @@ func TestArticleHandler_GetByID(t *testing.T) {
 			uc := mock.NewMockUseCase(ctrl)
 
 			tc.stub(uc)
-			h := NewArticleHandler(log, uc)
+			h := NewArticleHandler(&config.Config{}, log, uc)
 			e := echo.New()
 
 			req := httptest.NewRequest(http.MethodGet, "/api/v1/articles/"+tc.id, nil)
@@ -108,11 +110,104 @@ func TestArticleHandler_GetByID(t *testing.T) {
 	}
 }
 
+func TestArticleHandler_GetBySlugAndArticleID(t *testing.T) {
+	log := getLogger()
+
+	a := &domain.Article{
+		ID:        "6406083ea019b8815f689907",
+		ArticleID: "123",
+		Provider:  domain.HullCityProvider,
+		Slug:      "tigers-win-the-derby-123",
+	}
+
+	tt := []struct {
+		name    string
+		handler func(h *articleHandler) echo.HandlerFunc
+		params  []string
+		values  []string
+		stub    func(uc *mock.MockUseCase)
+		code    int
+	}{
+		{
+			name:    "by slug",
+			handler: (*articleHandler).GetBySlug,
+			params:  []string{"slug"},
+			values:  []string{a.Slug},
+			stub: func(uc *mock.MockUseCase) {
+				uc.EXPECT().GetBySlug(gomock.Any(), a.Slug).Times(1).Return(a, nil)
+			},
+			code: http.StatusOK,
+		},
+		{
+			name:    "slug not found",
+			handler: (*articleHandler).GetBySlug,
+			params:  []string{"slug"},
+			values:  []string{"unknown"},
+			stub: func(uc *mock.MockUseCase) {
+				uc.EXPECT().GetBySlug(gomock.Any(), "unknown").Times(1).Return(nil, errors.New("no documents in result"))
+			},
+			code: http.StatusNotFound,
+		},
+		{
+			name:    "by article id",
+			handler: (*articleHandler).GetByArticleID,
+			params:  []string{"provider", "articleID"},
+			values:  []string{a.Provider, a.ArticleID},
+			stub: func(uc *mock.MockUseCase) {
+				uc.EXPECT().GetByArticleID(gomock.Any(), a.Provider, a.ArticleID).Times(1).Return(a, nil)
+			},
+			code: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := mock.NewMockUseCase(ctrl)
+
+			tc.stub(uc)
+			h := NewArticleHandler(&config.Config{}, log, uc)
+			e := echo.New()
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames(tc.params...)
+			c.SetParamValues(tc.values...)
+
+			get := tc.handler(h)
+			require.NoError(t, get(c))
+			assert.Equal(t, tc.code, rec.Code)
+
+			if rec.Code != http.StatusOK {
+				return
+			}
+
+			res := &domain.ArticleRest{}
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(res))
+			assert.Equal(t, a, res.Data)
+		})
+	}
+}
+
 func TestArticleHandler_List(t *testing.T) {
 	log := getLogger()
 
+	next := domain.NewCursor(domain.DefaultSort, &domain.Article{
+		ID:        "6406083ea019b8815f689909",
+		Published: time.Date(2023, 3, 6, 14, 30, 0, 0, time.UTC),
+	}, false)
+	prev := domain.NewCursor(domain.DefaultSort, &domain.Article{
+		ID:        "6406083ea019b8815f689907",
+		Published: time.Date(2023, 3, 7, 14, 30, 0, 0, time.UTC),
+	}, true)
+
 	a := &domain.Articles{
-		Total: 3,
+		Total: 10,
+		Limit: 3,
 		Articles: []*domain.Article{
 			{
 				ID:        "6406083ea019b8815f689907",
@@ -120,37 +215,105 @@ func TestArticleHandler_List(t *testing.T) {
 				TeamID:    "team",
 			},
 			{
-				ID:        "6406083ea019b8815f689907",
+				ID:        "6406083ea019b8815f689908",
 				ArticleID: "2",
 				TeamID:    "team",
 			},
 			{
-				ID:        "6406083ea019b8815f689907",
+				ID:        "6406083ea019b8815f689909",
 				ArticleID: "3",
 				TeamID:    "team",
 			},
 		},
+		Next: next,
+		Prev: prev,
 	}
 
 	tt := []struct {
-		name string
-		stub func(uc *mock.MockUseCase)
-		code int
-		err  error
+		name  string
+		query string
+		self  string
+		stub  func(uc *mock.MockUseCase)
+		code  int
+		err   error
 	}{
 		{
 			name: "internal server error",
 			stub: func(uc *mock.MockUseCase) {
-				uc.EXPECT().List(gomock.Any()).Times(1).
+				uc.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).
 					Return(nil, errors.New("something went wrong"))
 			},
 			code: http.StatusInternalServerError,
 			err:  httperrors.ErrInternal,
 		},
 		{
-			name: "ok",
+			name:  "invalid limit",
+			query: "?limit=abc",
+			stub:  func(uc *mock.MockUseCase) {},
+			code:  http.StatusBadRequest,
+			err:   httperrors.ErrBadRequest,
+		},
+		{
+			name:  "negative offset",
+			query: "?offset=-1",
+			stub:  func(uc *mock.MockUseCase) {},
+			code:  http.StatusBadRequest,
+			err:   httperrors.ErrBadRequest,
+		},
+		{
+			name:  "invalid cursor",
+			query: "?cursor=abc",
+			stub:  func(uc *mock.MockUseCase) {},
+			code:  http.StatusBadRequest,
+			err:   httperrors.ErrBadRequest,
+		},
+		{
+			name:  "offset and cursor",
+			query: "?offset=3&cursor=" + next.Encode(),
+			stub:  func(uc *mock.MockUseCase) {},
+			code:  http.StatusBadRequest,
+			err:   httperrors.ErrBadRequest,
+		},
+		{
+			name:  "invalid sort",
+			query: "?sort=-teamId",
+			stub:  func(uc *mock.MockUseCase) {},
+			code:  http.StatusBadRequest,
+			err:   httperrors.ErrBadRequest,
+		},
+		{
+			name:  "cursor of another sort",
+			query: "?sort=title&cursor=" + next.Encode(),
+			stub:  func(uc *mock.MockUseCase) {},
+			code:  http.StatusBadRequest,
+			err:   httperrors.ErrBadRequest,
+		},
+		{
+			name:  "ok",
+			query: "?limit=3&offset=3",
+			stub: func(uc *mock.MockUseCase) {
+				uc.EXPECT().List(gomock.Any(), domain.ListParams{Limit: 3, Offset: 3}).Times(1).
+					Return(a, nil)
+			},
+			code: http.StatusOK,
+			err:  nil,
+		},
+		{
+			name:  "ok with api key",
+			query: "?apiKey=snk_1&limit=3&offset=3",
+			self:  "/api/v1/articles?limit=3&offset=3",
+			stub: func(uc *mock.MockUseCase) {
+				uc.EXPECT().List(gomock.Any(), domain.ListParams{Limit: 3, Offset: 3}).Times(1).
+					Return(a, nil)
+			},
+			code: http.StatusOK,
+			err:  nil,
+		},
+		{
+			name:  "ok cursor",
+			query: "?limit=3&cursor=" + next.Encode(),
 			stub: func(uc *mock.MockUseCase) {
-				uc.EXPECT().List(gomock.Any()).Times(1).
+				uc.EXPECT().List(gomock.Any(), domain.ListParams{Limit: 3, Cursor: next}).Times(1).
 					Return(a, nil)
 			},
 			code: http.StatusOK,
@@ -166,33 +329,378 @@ func TestArticleHandler_List(t *testing.T) {
 			uc := mock.NewMockUseCase(ctrl)
 
 			tc.stub(uc)
-			h := NewArticleHandler(log, uc)
+			h := NewArticleHandler(&config.Config{}, log, uc)
 			e := echo.New()
 
-			req := httptest.NewRequest(http.MethodGet, "/api/v1/articles/", nil)
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/articles"+tc.query, nil)
 
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
 
 			list := h.List()
 			err := list(c)
+			require.NoError(t, err)
+			assert.Equal(t, tc.code, rec.Code)
 
-			if err != nil && tc.err != nil {
-				assert.Equal(t, tc.code, rec.Code)
-			} else {
-				require.NoError(t, err)
-				assert.Equal(t, tc.code, rec.Code)
+			if tc.err != nil {
+				res := &httperrors.RestErr{}
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(res))
+				assert.Equal(t, tc.err.Error(), res.Err)
 
-				if rec.Code == http.StatusOK {
-					res := &domain.ArticlesRest{}
-					err := json.NewDecoder(rec.Body).Decode(res)
-					require.NoError(t, err)
+				return
+			}
 
-					assert.Equal(t, "success", res.Status)
-					assert.Equal(t, a.Total, res.Metadata.Total)
-					assert.Equal(t, a.Articles, res.Data)
-				}
+			res := &domain.ArticlesRest{}
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(res))
+
+			assert.Equal(t, "success", res.Status)
+			assert.Equal(t, a.Total, res.Metadata.Total)
+			assert.Equal(t, a.Articles, res.Data)
+			assert.Equal(t, next.Encode(), res.Metadata.NextCursor)
+			assert.Equal(t, prev.Encode(), res.Metadata.PrevCursor)
+			self := tc.self
+			if self == "" {
+				self = "/api/v1/articles" + tc.query
 			}
+
+			assert.Equal(t, self, res.Metadata.Links.Self)
+			assert.Equal(t, "/api/v1/articles?cursor="+next.Encode()+"&limit=3", res.Metadata.Links.Next)
+			assert.Equal(t, "/api/v1/articles?cursor="+prev.Encode()+"&limit=3", res.Metadata.Links.Prev)
+		})
+	}
+}
+
+func TestArticleHandler_List_FilterSort(t *testing.T) {
+	log := getLogger()
+
+	yes, no := true, false
+	since := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2023, 3, 8, 0, 0, 0, 0, time.UTC)
+	sinceTime := time.Date(2023, 3, 1, 12, 30, 0, 0, time.UTC)
+
+	tt := []struct {
+		name   string
+		query  string
+		filter domain.ArticleFilter
+		sort   domain.Sort
+		code   int
+	}{
+		{
+			name:  "team and types",
+			query: "?teamId=Hull+City&type=Academy,Video&type=News&typeMatch=all",
+			filter: domain.ArticleFilter{
+				TeamID:    "Hull City",
+				Types:     []string{"Academy", "Video", "News"},
+				TypeMatch: domain.TypeMatchAll,
+			},
+			code: http.StatusOK,
+		},
+		{
+			name:   "dates",
+			query:  "?publishedSince=2023-03-01&publishedUntil=2023-03-07",
+			filter: domain.ArticleFilter{PublishedSince: &since, PublishedUntil: &until},
+			code:   http.StatusOK,
+		},
+		{
+			name:   "rfc 3339",
+			query:  "?publishedSince=2023-03-01T12:30:00Z",
+			filter: domain.ArticleFilter{PublishedSince: &sinceTime},
+			code:   http.StatusOK,
+		},
+		{
+			name:   "flags and match",
+			query:  "?isPublished=true&hasVideo=false&optaMatchId=g2322054",
+			filter: domain.ArticleFilter{IsPublished: &yes, HasVideo: &no, OptaMatchID: "g2322054"},
+			code:   http.StatusOK,
+		},
+		{
+			name:  "invalid type match",
+			query: "?type=Academy&typeMatch=some",
+			code:  http.StatusBadRequest,
+		},
+		{
+			name:  "invalid date",
+			query: "?publishedSince=yesterday",
+			code:  http.StatusBadRequest,
+		},
+		{
+			name:  "empty range",
+			query: "?publishedSince=2023-03-08&publishedUntil=2023-03-01",
+			code:  http.StatusBadRequest,
+		},
+		{
+			name:  "invalid boolean",
+			query: "?hasVideo=maybe",
+			code:  http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := mock.NewMockUseCase(ctrl)
+			if tc.code == http.StatusOK {
+				uc.EXPECT().List(gomock.Any(), domain.ListParams{Filter: tc.filter, Sort: tc.sort}).Times(1).
+					Return(&domain.Articles{Articles: []*domain.Article{}}, nil)
+			}
+
+			h := NewArticleHandler(&config.Config{}, log, uc)
+			e := echo.New()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/articles"+tc.query, nil)
+
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			list := h.List()
+			require.NoError(t, list(c))
+			assert.Equal(t, tc.code, rec.Code)
+		})
+	}
+}
+
+func TestArticleHandler_List_Fields(t *testing.T) {
+	log := getLogger()
+
+	articles := &domain.Articles{
+		Total: 1,
+		Limit: 20,
+		Articles: []*domain.Article{{
+			ID:          "6406083ea019b8815f689907",
+			Title:       "Tigers win the derby",
+			Content:     "<p>A late goal settles the derby.</p>",
+			GalleryURLs: []string{"https://example.com/1.jpg"},
+		}},
+	}
+
+	tt := []struct {
+		name   string
+		query  string
+		fields domain.Fields
+		want   []string
+		code   int
+	}{
+		{
+			name:   "fields",
+			query:  "?fields=title,published,title",
+			fields: domain.Fields{"id", "title", "published"},
+			want:   []string{"id", "title", "published"},
+			code:   http.StatusOK,
+		},
+		{
+			name:  "full view",
+			query: "?view=full",
+			code:  http.StatusOK,
+		},
+		{
+			name:  "unknown field",
+			query: "?fields=title,author",
+			code:  http.StatusBadRequest,
+		},
+		{
+			name:  "unknown view",
+			query: "?view=compact",
+			code:  http.StatusBadRequest,
+		},
+		{
+			name:  "fields and view",
+			query: "?fields=title&view=summary",
+			code:  http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := mock.NewMockUseCase(ctrl)
+			if tc.code == http.StatusOK {
+				uc.EXPECT().List(gomock.Any(), domain.ListParams{Fields: tc.fields}).Times(1).Return(articles, nil)
+			}
+
+			h := NewArticleHandler(&config.Config{}, log, uc)
+			e := echo.New()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/articles"+tc.query, nil)
+
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			list := h.List()
+			require.NoError(t, list(c))
+			assert.Equal(t, tc.code, rec.Code)
+
+			if tc.want == nil {
+				return
+			}
+
+			res := &domain.PartialArticlesRest{}
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(res))
+
+			require.Len(t, res.Data, 1)
+			assert.Len(t, res.Data[0], len(tc.want))
+
+			for _, name := range tc.want {
+				assert.Contains(t, res.Data[0], name)
+			}
+		})
+	}
+}
+
+func TestArticleHandler_List_Summary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	a := &domain.Article{ID: "6406083ea019b8815f689907", Title: "Tigers win the derby", Content: "<p>derby</p>", BodyText: "derby"}
+
+	uc := mock.NewMockUseCase(ctrl)
+	uc.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, params domain.ListParams) (*domain.Articles, error) {
+			assert.NotContains(t, params.Fields, "content")
+			assert.Contains(t, params.Fields, "title")
+
+			return &domain.Articles{Total: 1, Articles: []*domain.Article{a}}, nil
+		})
+
+	h := NewArticleHandler(&config.Config{}, getLogger(), uc)
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/articles?view=summary", nil)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	list := h.List()
+	require.NoError(t, list(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	res := &domain.PartialArticlesRest{}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(res))
+
+	require.Len(t, res.Data, 1)
+	assert.JSONEq(t, `"Tigers win the derby"`, string(res.Data[0]["title"]))
+	assert.NotContains(t, res.Data[0], "content")
+	assert.NotContains(t, res.Data[0], "bodyText")
+	assert.NotContains(t, res.Data[0], "galleryUrls")
+	assert.NotContains(t, res.Data[0], "-")
+}
+
+func TestArticleHandler_List_HiddenField(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := mock.NewMockUseCase(ctrl)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/articles?fields=-", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	require.NoError(t, NewArticleHandler(&config.Config{}, getLogger(), uc).List()(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestArticleHandler_Search(t *testing.T) {
+	log := getLogger()
+
+	results := &domain.SearchResults{
+		Total: 1,
+		Limit: 20,
+		Hits: []*domain.SearchHit{{
+			Article:    &domain.Article{ID: "6406083ea019b8815f689907", Title: "Tigers win the derby"},
+			Score:      1.5,
+			Highlights: map[string][]string{"title": {"Tigers win the <mark>derby</mark>"}},
+		}},
+	}
+
+	tt := []struct {
+		name  string
+		query string
+		stub  func(uc *mock.MockUseCase)
+		code  int
+	}{
+		{
+			name:  "missing query",
+			query: "?teamId=Hull+City",
+			stub:  func(uc *mock.MockUseCase) {},
+			code:  http.StatusBadRequest,
+		},
+		{
+			name:  "invalid filter",
+			query: "?q=derby&isPublished=maybe",
+			stub:  func(uc *mock.MockUseCase) {},
+			code:  http.StatusBadRequest,
+		},
+		{
+			name:  "internal server error",
+			query: "?q=derby",
+			stub: func(uc *mock.MockUseCase) {
+				uc.EXPECT().Search(gomock.Any(), gomock.Any()).Times(1).
+					Return(nil, errors.New("something went wrong"))
+			},
+			code: http.StatusInternalServerError,
+		},
+		{
+			name:  "ok",
+			query: "?q=derby&teamId=Hull+City&type=Academy&limit=5",
+			stub: func(uc *mock.MockUseCase) {
+				uc.EXPECT().Search(gomock.Any(), domain.SearchParams{
+					Query:  "derby",
+					Filter: domain.ArticleFilter{TeamID: "Hull City", Types: []string{"Academy"}},
+					Limit:  5,
+				}).Times(1).Return(results, nil)
+			},
+			code: http.StatusOK,
+		},
+		{
+			name:  "invalid fuzzy",
+			query: "?q=derby&fuzzy=maybe",
+			stub:  func(uc *mock.MockUseCase) {},
+			code:  http.StatusBadRequest,
+		},
+		{
+			name:  "fuzzy",
+			query: "?q=derbi&fuzzy=true",
+			stub: func(uc *mock.MockUseCase) {
+				uc.EXPECT().Search(gomock.Any(), domain.SearchParams{Query: "derbi", Fuzzy: true}).Times(1).
+					Return(results, nil)
+			},
+			code: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := mock.NewMockUseCase(ctrl)
+
+			tc.stub(uc)
+			h := NewArticleHandler(&config.Config{}, log, uc)
+			e := echo.New()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/articles/search"+tc.query, nil)
+
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			search := h.Search()
+			require.NoError(t, search(c))
+			assert.Equal(t, tc.code, rec.Code)
+
+			if rec.Code != http.StatusOK {
+				return
+			}
+
+			res := &domain.SearchResultsRest{}
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(res))
+
+			assert.Equal(t, "success", res.Status)
+			assert.Equal(t, results.Total, res.Metadata.Total)
+			assert.Equal(t, results.Hits, res.Data)
 		})
 	}
 }
@@ -0,0 +1,35 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/KarolosLykos/sportsnews/internal/article"
+	httperrors "github.com/KarolosLykos/sportsnews/internal/utils/http_errors"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+type indexHandler struct {
+	logger logger.Logger
+	index  article.Index
+}
+
+func NewIndexHandler(logger logger.Logger, index article.Index) *indexHandler {
+	return &indexHandler{
+		logger: logger,
+		index:  index,
+	}
+}
+
+// Reindex rebuilds the search index from the database.
+func (h *indexHandler) Reindex() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		result, err := h.index.Reindex(c.Request().Context())
+		if err != nil {
+			return httperrors.ErrorResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, result.ToRest())
+	}
+}
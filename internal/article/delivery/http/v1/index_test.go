@@ -0,0 +1,76 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/sportsnews/domain"
+	mock "github.com/KarolosLykos/sportsnews/internal/article/mock"
+)
+
+func TestIndexHandler_Reindex(t *testing.T) {
+	log := getLogger()
+
+	result := &domain.ReindexResult{Indexed: 42, Took: "1.5s"}
+
+	tt := []struct {
+		name string
+		stub func(idx *mock.MockIndex)
+		code int
+	}{
+		{
+			name: "internal server error",
+			stub: func(idx *mock.MockIndex) {
+				idx.EXPECT().Reindex(gomock.Any()).Times(1).Return(nil, errors.New("something went wrong"))
+			},
+			code: http.StatusInternalServerError,
+		},
+		{
+			name: "ok",
+			stub: func(idx *mock.MockIndex) {
+				idx.EXPECT().Reindex(gomock.Any()).Times(1).Return(result, nil)
+			},
+			code: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			idx := mock.NewMockIndex(ctrl)
+
+			tc.stub(idx)
+			h := NewIndexHandler(log, idx)
+			e := echo.New()
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/search/reindex", nil)
+
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			reindex := h.Reindex()
+			require.NoError(t, reindex(c))
+			assert.Equal(t, tc.code, rec.Code)
+
+			if rec.Code != http.StatusOK {
+				return
+			}
+
+			res := &domain.ReindexResultRest{}
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(res))
+
+			assert.Equal(t, "success", res.Status)
+			assert.Equal(t, result, res.Data)
+		})
+	}
+}
@@ -0,0 +1,290 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+	"github.com/KarolosLykos/sportsnews/internal/article"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+// socketReadLimit is the largest message a client may send.
+const socketReadLimit = 4096
+
+// Actions a client may send.
+const (
+	actionSubscribe   = "subscribe"
+	actionUnsubscribe = "unsubscribe"
+)
+
+// Types of the messages sent to a client.
+const (
+	messageSubscribed   = "subscribed"
+	messageUnsubscribed = "unsubscribed"
+	messageEvent        = "event"
+	messageError        = "error"
+)
+
+var errSlowConsumer = errors.New("slow consumer")
+
+// socketRequest is a message of a client. A subscription is named by its id, chosen by the client,
+// and filters the articles like the article list.
+type socketRequest struct {
+	Action    string           `json:"action"`
+	ID        string           `json:"id"`
+	TeamID    string           `json:"teamId,omitempty"`
+	Types     []string         `json:"types,omitempty"`
+	TypeMatch domain.TypeMatch `json:"typeMatch,omitempty"`
+
+	// malformed is set when the message is not a request.
+	malformed bool
+}
+
+// socketMessage is a message to a client. Events name the subscriptions they match.
+type socketMessage struct {
+	Type          string               `json:"type"`
+	ID            string               `json:"id,omitempty"`
+	Subscriptions []string             `json:"subscriptions,omitempty"`
+	Event         *domain.ArticleEvent `json:"event,omitempty"`
+	Error         string               `json:"error,omitempty"`
+}
+
+type socketHandler struct {
+	cfg      *config.Config
+	logger   logger.Logger
+	events   article.Events
+	upgrader websocket.Upgrader
+}
+
+func NewSocketHandler(cfg *config.Config, logger logger.Logger, events article.Events) *socketHandler {
+	return &socketHandler{
+		cfg:    cfg,
+		logger: logger,
+		events: events,
+		upgrader: websocket.Upgrader{
+			// The articles are public, browsers of any origin may subscribe to them.
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+	}
+}
+
+// Socket upgrades the request to a WebSocket on which the client subscribes and unsubscribes to the
+// events of teams and types at runtime. The connection is closed when the client falls behind,
+// or when it answers neither pings nor anything else in time.
+func (h *socketHandler) Socket() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		conn, err := h.upgrader.Upgrade(c.Response(), c.Request(), nil)
+		if err != nil {
+			// The upgrader has answered the request already.
+			return nil
+		}
+
+		ctx, cancel := context.WithCancel(c.Request().Context())
+		defer cancel()
+
+		s := &socket{
+			cfg:           h.cfg.Socket,
+			logger:        h.logger,
+			conn:          conn,
+			send:          make(chan *socketMessage, h.cfg.Socket.SendBuffer),
+			subscriptions: make(map[string]domain.ArticleFilter),
+		}
+
+		s.run(ctx, cancel, h.events.Subscribe(ctx))
+
+		return nil
+	}
+}
+
+// socket is a WebSocket connection. Its subscriptions are only used by run, messages are written by write.
+type socket struct {
+	cfg    config.SocketConfig
+	logger logger.Logger
+	conn   *websocket.Conn
+	send   chan *socketMessage
+
+	subscriptions map[string]domain.ArticleFilter
+}
+
+// run handles the requests of the client and sends it the events of its subscriptions, until the
+// client goes away or falls behind. The connection is closed with the reason.
+func (s *socket) run(ctx context.Context, cancel context.CancelFunc, events <-chan *domain.ArticleEvent) {
+	requests := make(chan *socketRequest)
+
+	go s.read(ctx, cancel, requests)
+	go s.write(ctx, cancel)
+
+	err := s.loop(ctx, events, requests)
+	cancel()
+
+	code, reason := websocket.CloseNormalClosure, ""
+	if errors.Is(err, errSlowConsumer) {
+		code, reason = websocket.CloseTryAgainLater, err.Error()
+	}
+
+	_ = s.conn.WriteControl(
+		websocket.CloseMessage,
+		websocket.FormatCloseMessage(code, reason),
+		time.Now().Add(s.cfg.WriteWait),
+	)
+	s.conn.Close()
+}
+
+func (s *socket) loop(ctx context.Context, events <-chan *domain.ArticleEvent, requests <-chan *socketRequest) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case e, ok := <-events:
+			if !ok {
+				return errSlowConsumer
+			}
+
+			if ids := s.matches(e.Article); len(ids) > 0 {
+				if err := s.enqueue(&socketMessage{Type: messageEvent, Subscriptions: ids, Event: e}); err != nil {
+					return err
+				}
+			}
+		case r := <-requests:
+			if err := s.enqueue(s.handle(r)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// handle applies the request of the client and returns the reply.
+func (s *socket) handle(r *socketRequest) *socketMessage {
+	if r.malformed {
+		return &socketMessage{Type: messageError, Error: "malformed request"}
+	}
+
+	if r.ID == "" {
+		return &socketMessage{Type: messageError, Error: "id is required"}
+	}
+
+	switch r.Action {
+	case actionSubscribe:
+		filter := domain.ArticleFilter{TeamID: r.TeamID, Types: r.Types, TypeMatch: r.TypeMatch}
+		if err := filter.Validate(); err != nil {
+			return &socketMessage{Type: messageError, ID: r.ID, Error: err.Error()}
+		}
+
+		if _, ok := s.subscriptions[r.ID]; !ok && len(s.subscriptions) >= s.cfg.MaxSubscriptions {
+			return &socketMessage{
+				Type:  messageError,
+				ID:    r.ID,
+				Error: fmt.Sprintf("at most %d subscriptions are allowed", s.cfg.MaxSubscriptions),
+			}
+		}
+
+		s.subscriptions[r.ID] = filter
+
+		return &socketMessage{Type: messageSubscribed, ID: r.ID}
+	case actionUnsubscribe:
+		if _, ok := s.subscriptions[r.ID]; !ok {
+			return &socketMessage{Type: messageError, ID: r.ID, Error: "unknown subscription"}
+		}
+
+		delete(s.subscriptions, r.ID)
+
+		return &socketMessage{Type: messageUnsubscribed, ID: r.ID}
+	default:
+		return &socketMessage{Type: messageError, ID: r.ID, Error: fmt.Sprintf("unknown action %q", r.Action)}
+	}
+}
+
+// matches returns the ids of the subscriptions the article matches, sorted.
+func (s *socket) matches(a *domain.Article) []string {
+	ids := make([]string, 0)
+
+	for id, filter := range s.subscriptions {
+		if filter.Matches(a) {
+			ids = append(ids, id)
+		}
+	}
+
+	sort.Strings(ids)
+
+	return ids
+}
+
+// enqueue queues the message for write, or fails when the client has fallen too far behind.
+func (s *socket) enqueue(m *socketMessage) error {
+	select {
+	case s.send <- m:
+		return nil
+	default:
+		return errSlowConsumer
+	}
+}
+
+// read passes the requests of the client to run, until the connection fails. Malformed requests are
+// answered with an error. The read deadline moves with every pong and every message.
+func (s *socket) read(ctx context.Context, cancel context.CancelFunc, requests chan<- *socketRequest) {
+	defer cancel()
+
+	s.conn.SetReadLimit(socketReadLimit)
+	_ = s.conn.SetReadDeadline(time.Now().Add(s.cfg.PongWait))
+	s.conn.SetPongHandler(func(string) error {
+		return s.conn.SetReadDeadline(time.Now().Add(s.cfg.PongWait))
+	})
+
+	for {
+		_, b, err := s.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				s.logger.Warn(ctx, err, "websocket closed")
+			}
+
+			return
+		}
+
+		_ = s.conn.SetReadDeadline(time.Now().Add(s.cfg.PongWait))
+
+		r := &socketRequest{}
+		if err = json.Unmarshal(b, r); err != nil {
+			r = &socketRequest{malformed: true}
+		}
+
+		select {
+		case requests <- r:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// write sends the queued messages and the pings to the client, until ctx is done or a write fails.
+func (s *socket) write(ctx context.Context, cancel context.CancelFunc) {
+	defer cancel()
+
+	ping := time.NewTicker(s.cfg.PingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case m := <-s.send:
+			_ = s.conn.SetWriteDeadline(time.Now().Add(s.cfg.WriteWait))
+			if err := s.conn.WriteJSON(m); err != nil {
+				return
+			}
+		case <-ping.C:
+			if err := s.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(s.cfg.WriteWait)); err != nil {
+				return
+			}
+		}
+	}
+}
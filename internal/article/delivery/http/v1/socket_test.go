@@ -0,0 +1,170 @@
+package v1
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+	mock "github.com/KarolosLykos/sportsnews/internal/article/mock"
+)
+
+func TestSocketHandler_Socket(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	events := make(chan *domain.ArticleEvent)
+	ev := mock.NewMockEvents(ctrl)
+	ev.EXPECT().Subscribe(gomock.Any()).Times(1).Return(events)
+
+	conn := dialSocket(t, ev)
+
+	send := func(r socketRequest) {
+		require.NoError(t, conn.WriteJSON(r))
+	}
+	receive := func() *socketMessage {
+		m := &socketMessage{}
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+		require.NoError(t, conn.ReadJSON(m))
+
+		return m
+	}
+	event := func(id int64, teamID string, types ...string) *domain.ArticleEvent {
+		return &domain.ArticleEvent{ID: id, Type: domain.EventCreated, Article: &domain.Article{ID: "1", TeamID: teamID, Type: types}}
+	}
+
+	send(socketRequest{Action: actionSubscribe, ID: "hull", TeamID: "Hull City"})
+	assert.Equal(t, &socketMessage{Type: messageSubscribed, ID: "hull"}, receive())
+
+	send(socketRequest{Action: actionSubscribe, ID: "videos", Types: []string{"Video"}})
+	assert.Equal(t, &socketMessage{Type: messageSubscribed, ID: "videos"}, receive())
+
+	events <- event(1, "Leeds", "News")
+	events <- event(2, "Hull City", "Video")
+
+	m := receive()
+	assert.Equal(t, messageEvent, m.Type)
+	assert.Equal(t, []string{"hull", "videos"}, m.Subscriptions)
+	assert.Equal(t, int64(2), m.Event.ID)
+
+	send(socketRequest{Action: actionUnsubscribe, ID: "hull"})
+	assert.Equal(t, &socketMessage{Type: messageUnsubscribed, ID: "hull"}, receive())
+
+	events <- event(3, "Hull City", "News")
+	events <- event(4, "Leeds", "Video")
+
+	m = receive()
+	assert.Equal(t, []string{"videos"}, m.Subscriptions)
+	assert.Equal(t, int64(4), m.Event.ID)
+
+	// The events channel is closed when the connection falls behind the other subscribers.
+	close(events)
+
+	_, _, err := conn.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseTryAgainLater))
+}
+
+func TestSocketHandler_Socket_Errors(t *testing.T) {
+	tt := []struct {
+		name    string
+		request string
+		reply   *socketMessage
+	}{
+		{
+			name:    "malformed",
+			request: `{"action":`,
+			reply:   &socketMessage{Type: messageError, Error: "malformed request"},
+		},
+		{
+			name:    "no id",
+			request: `{"action":"subscribe"}`,
+			reply:   &socketMessage{Type: messageError, Error: "id is required"},
+		},
+		{
+			name:    "unknown action",
+			request: `{"action":"resume","id":"a"}`,
+			reply:   &socketMessage{Type: messageError, ID: "a", Error: `unknown action "resume"`},
+		},
+		{
+			name:    "invalid filter",
+			request: `{"action":"subscribe","id":"a","typeMatch":"some"}`,
+			reply:   &socketMessage{Type: messageError, ID: "a", Error: `invalid filter:typeMatch must be "any" or "all"`},
+		},
+		{
+			name:    "unknown subscription",
+			request: `{"action":"unsubscribe","id":"a"}`,
+			reply:   &socketMessage{Type: messageError, ID: "a", Error: "unknown subscription"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ev := mock.NewMockEvents(ctrl)
+			ev.EXPECT().Subscribe(gomock.Any()).Times(1).Return(make(chan *domain.ArticleEvent))
+
+			conn := dialSocket(t, ev)
+			require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(tc.request)))
+
+			m := &socketMessage{}
+			require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+			require.NoError(t, conn.ReadJSON(m))
+			assert.Equal(t, tc.reply, m)
+		})
+	}
+}
+
+func TestSocket_Handle_MaxSubscriptions(t *testing.T) {
+	s := &socket{
+		cfg:           config.SocketConfig{MaxSubscriptions: 1},
+		subscriptions: make(map[string]domain.ArticleFilter),
+	}
+
+	assert.Equal(t, messageSubscribed, s.handle(&socketRequest{Action: actionSubscribe, ID: "a"}).Type)
+	// Replacing the filter of a subscription does not count against the limit.
+	assert.Equal(t, messageSubscribed, s.handle(&socketRequest{Action: actionSubscribe, ID: "a", TeamID: "Hull City"}).Type)
+	assert.Equal(t, "Hull City", s.subscriptions["a"].TeamID)
+
+	m := s.handle(&socketRequest{Action: actionSubscribe, ID: "b"})
+	assert.Equal(t, &socketMessage{Type: messageError, ID: "b", Error: "at most 1 subscriptions are allowed"}, m)
+}
+
+func TestSocket_Enqueue(t *testing.T) {
+	s := &socket{send: make(chan *socketMessage, 1)}
+
+	assert.NoError(t, s.enqueue(&socketMessage{Type: messageEvent}))
+	assert.ErrorIs(t, s.enqueue(&socketMessage{Type: messageEvent}), errSlowConsumer)
+}
+
+// dialSocket serves the socket handler and connects to it.
+func dialSocket(t *testing.T, ev *mock.MockEvents) *websocket.Conn {
+	cfg := &config.Config{Socket: config.SocketConfig{
+		SendBuffer:       8,
+		MaxSubscriptions: 10,
+		PingInterval:     time.Minute,
+		PongWait:         time.Minute,
+		WriteWait:        time.Second,
+	}}
+
+	e := echo.New()
+	e.GET("/socket", NewSocketHandler(cfg, getLogger(), ev).Socket())
+
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/socket", nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
@@ -0,0 +1,157 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+	"github.com/KarolosLykos/sportsnews/internal/article"
+	httperrors "github.com/KarolosLykos/sportsnews/internal/utils/http_errors"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+const (
+	mimeEventStream   = "text/event-stream"
+	headerLastEventID = "Last-Event-ID"
+)
+
+type streamHandler struct {
+	cfg    *config.Config
+	logger logger.Logger
+	events article.Events
+}
+
+func NewStreamHandler(cfg *config.Config, logger logger.Logger, events article.Events) *streamHandler {
+	return &streamHandler{
+		cfg:    cfg,
+		logger: logger,
+		events: events,
+	}
+}
+
+// Stream sends the events of the articles of the team and types of the query as Server-Sent Events,
+// until the client goes away. A client that resumes with a Last-Event-ID header, or a lastEventId
+// query parameter, first receives the recent events it missed. Comments are sent as heartbeats
+// so that idle connections are not closed by proxies.
+func (h *streamHandler) Stream() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		filter, lastID, err := streamParams(c)
+		if err != nil {
+			return httperrors.ErrorResponse(c, fmt.Errorf("%w:%v", httperrors.ErrBadRequest, err))
+		}
+
+		ctx := c.Request().Context()
+
+		// Subscribe before reading the replay buffer, so that no event falls in between.
+		live := h.events.Subscribe(ctx)
+
+		var replay []*domain.ArticleEvent
+		if lastID > 0 {
+			if replay, err = h.events.Replay(ctx, lastID); err != nil {
+				return httperrors.ErrorResponse(c, err)
+			}
+		}
+
+		res := c.Response()
+		res.Header().Set(echo.HeaderContentType, mimeEventStream)
+		res.Header().Set(echo.HeaderCacheControl, "no-cache")
+		res.Header().Set("X-Accel-Buffering", "no")
+		res.WriteHeader(http.StatusOK)
+		res.Flush()
+
+		send := func(e *domain.ArticleEvent) error {
+			if e.ID <= lastID {
+				return nil
+			}
+
+			lastID = e.ID
+			if !filter.Matches(e.Article) {
+				return nil
+			}
+
+			return writeEvent(res, e)
+		}
+
+		for _, e := range replay {
+			if err = send(e); err != nil {
+				return nil
+			}
+		}
+
+		heartbeat := time.NewTicker(h.cfg.Stream.Heartbeat)
+		defer heartbeat.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case e, ok := <-live:
+				if !ok {
+					return nil
+				}
+
+				if err = send(e); err != nil {
+					return nil
+				}
+			case <-heartbeat.C:
+				if _, err = fmt.Fprint(res, ": heartbeat\n\n"); err != nil {
+					return nil
+				}
+
+				res.Flush()
+			}
+		}
+	}
+}
+
+// streamParams reads the filter of the stream and the id of the last event the client saw.
+func streamParams(c echo.Context) (domain.ArticleFilter, int64, error) {
+	filter := domain.ArticleFilter{
+		TeamID:    c.QueryParam("teamId"),
+		Types:     queryList(c, "type"),
+		TypeMatch: domain.TypeMatch(c.QueryParam("typeMatch")),
+	}
+
+	if err := filter.Validate(); err != nil {
+		return filter, 0, err
+	}
+
+	last := c.Request().Header.Get(headerLastEventID)
+	if last == "" {
+		last = c.QueryParam("lastEventId")
+	}
+
+	if last == "" {
+		return filter, 0, nil
+	}
+
+	lastID, err := strconv.ParseInt(last, 10, 64)
+	if err != nil || lastID < 0 {
+		return filter, 0, errors.New("last event id must be a non negative integer")
+	}
+
+	return filter, lastID, nil
+}
+
+// writeEvent writes the event in the Server-Sent Events format and flushes it.
+func writeEvent(res *echo.Response, e *domain.ArticleEvent) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	if _, err = fmt.Fprintf(res, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data); err != nil {
+		return err
+	}
+
+	res.Flush()
+
+	return nil
+}
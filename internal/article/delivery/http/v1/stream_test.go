@@ -0,0 +1,136 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+	mock "github.com/KarolosLykos/sportsnews/internal/article/mock"
+)
+
+func TestStreamHandler_Stream(t *testing.T) {
+	log := getLogger()
+	cfg := &config.Config{Stream: config.StreamConfig{Heartbeat: time.Minute}}
+
+	at := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+	event := func(id int64, typ domain.EventType, teamID string) *domain.ArticleEvent {
+		return &domain.ArticleEvent{ID: id, Type: typ, Time: at, Article: &domain.Article{ID: "1", TeamID: teamID}}
+	}
+
+	tt := []struct {
+		name        string
+		query       string
+		lastEventID string
+		replay      []*domain.ArticleEvent
+		replayErr   error
+		live        []*domain.ArticleEvent
+		code        int
+		ids         []string
+	}{
+		{
+			name: "live",
+			live: []*domain.ArticleEvent{event(1, domain.EventCreated, "t1"), event(2, domain.EventUpdated, "t1")},
+			code: http.StatusOK,
+			ids:  []string{"id: 1\nevent: created", "id: 2\nevent: updated"},
+		},
+		{
+			name:  "team filter",
+			query: "?teamId=t2",
+			live:  []*domain.ArticleEvent{event(1, domain.EventCreated, "t1"), event(2, domain.EventWithdrawn, "t2")},
+			code:  http.StatusOK,
+			ids:   []string{"id: 2\nevent: withdrawn"},
+		},
+		{
+			name:        "resume from header",
+			lastEventID: "3",
+			replay:      []*domain.ArticleEvent{event(4, domain.EventCreated, "t1"), event(5, domain.EventUpdated, "t1")},
+			live:        []*domain.ArticleEvent{event(5, domain.EventUpdated, "t1"), event(6, domain.EventUpdated, "t1")},
+			code:        http.StatusOK,
+			ids:         []string{"id: 4\nevent: created", "id: 5\nevent: updated", "id: 6\nevent: updated"},
+		},
+		{
+			name:   "resume from query",
+			query:  "?lastEventId=4",
+			replay: []*domain.ArticleEvent{event(5, domain.EventCreated, "t1")},
+			code:   http.StatusOK,
+			ids:    []string{"id: 5\nevent: created"},
+		},
+		{
+			name:      "replay error",
+			query:     "?lastEventId=4",
+			replayErr: errors.New("something went wrong"),
+			code:      http.StatusInternalServerError,
+		},
+		{
+			name:        "invalid last event id",
+			lastEventID: "abc",
+			code:        http.StatusBadRequest,
+		},
+		{
+			name:  "invalid type match",
+			query: "?typeMatch=some",
+			code:  http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			live := make(chan *domain.ArticleEvent, len(tc.live))
+			for _, e := range tc.live {
+				live <- e
+			}
+			close(live)
+
+			ev := mock.NewMockEvents(ctrl)
+			if tc.code != http.StatusBadRequest {
+				ev.EXPECT().Subscribe(gomock.Any()).Times(1).Return(live)
+			}
+
+			if tc.replay != nil || tc.replayErr != nil {
+				ev.EXPECT().Replay(gomock.Any(), gomock.Any()).Times(1).Return(tc.replay, tc.replayErr)
+			}
+
+			h := NewStreamHandler(cfg, log, ev)
+			e := echo.New()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/articles/stream"+tc.query, nil)
+			if tc.lastEventID != "" {
+				req.Header.Set(headerLastEventID, tc.lastEventID)
+			}
+
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			stream := h.Stream()
+			require.NoError(t, stream(c))
+			assert.Equal(t, tc.code, rec.Code)
+
+			if tc.code != http.StatusOK {
+				return
+			}
+
+			assert.Equal(t, mimeEventStream, rec.Header().Get(echo.HeaderContentType))
+			assert.Equal(t, "no-cache", rec.Header().Get(echo.HeaderCacheControl))
+
+			body := rec.Body.String()
+			for _, id := range tc.ids {
+				assert.Contains(t, body, id+"\ndata: {")
+			}
+
+			assert.Equal(t, len(tc.ids), strings.Count(body, "\nevent: "))
+		})
+	}
+}
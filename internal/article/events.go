@@ -0,0 +1,18 @@
+package article
+
+import (
+	"context"
+
+	"github.com/KarolosLykos/sportsnews/domain"
+)
+
+// Events carries article events from ingestion to the subscribers of every replica.
+type Events interface {
+	// Publish numbers the event and sends it to every subscriber.
+	Publish(ctx context.Context, event *domain.ArticleEvent) error
+	// Subscribe returns the events published from now on. The channel is closed when ctx is done,
+	// or earlier when the subscriber falls behind.
+	Subscribe(ctx context.Context) <-chan *domain.ArticleEvent
+	// Replay returns the recent events published after the event with the id, oldest first.
+	Replay(ctx context.Context, after int64) ([]*domain.ArticleEvent, error)
+}
@@ -0,0 +1,200 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+// subscriberBuffer is the number of events a subscriber may fall behind before it is dropped.
+const subscriberBuffer = 64
+
+var (
+	ErrPublish = errors.New("events: publish")
+	ErrReplay  = errors.New("events: replay")
+	ErrDecode  = errors.New("events: decode")
+)
+
+// publish numbers an event, keeps it in the bounded replay buffer and publishes it, atomically,
+// so that events are buffered and delivered in the order of their ids. Messages are the id
+// followed by a colon and the JSON of the event.
+var publish = redis.NewScript(`
+local id = redis.call("INCR", KEYS[1])
+local message = id .. ":" .. ARGV[1]
+redis.call("LPUSH", KEYS[2], message)
+redis.call("LTRIM", KEYS[2], 0, tonumber(ARGV[2]) - 1)
+redis.call("PUBLISH", KEYS[3], message)
+return id
+`)
+
+// redisEvents publishes events through Redis pub/sub and fans them out to the subscribers of
+// this replica. Recent events are kept in a Redis list for the subscribers that resume.
+type redisEvents struct {
+	cfg    *config.Config
+	logger logger.Logger
+	client *redis.Client
+
+	mu          sync.Mutex
+	subscribers map[chan *domain.ArticleEvent]struct{}
+}
+
+func NewRedisEvents(cfg *config.Config, logger logger.Logger, client *redis.Client) *redisEvents {
+	return &redisEvents{
+		cfg:         cfg,
+		logger:      logger,
+		client:      client,
+		subscribers: make(map[chan *domain.ArticleEvent]struct{}),
+	}
+}
+
+func (r *redisEvents) Publish(ctx context.Context, event *domain.ArticleEvent) error {
+	b, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("%w:%v", ErrPublish, err)
+	}
+
+	keys := []string{r.key("seq"), r.key("buffer"), r.key("channel")}
+
+	id, err := publish.Run(ctx, r.client, keys, b, r.cfg.Stream.ReplaySize).Int64()
+	if err != nil {
+		return fmt.Errorf("%w:%v", ErrPublish, err)
+	}
+
+	event.ID = id
+
+	return nil
+}
+
+func (r *redisEvents) Subscribe(ctx context.Context) <-chan *domain.ArticleEvent {
+	ch := make(chan *domain.ArticleEvent, subscriberBuffer)
+
+	r.mu.Lock()
+	r.subscribers[ch] = struct{}{}
+	r.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		r.unsubscribe(ch)
+	}()
+
+	return ch
+}
+
+func (r *redisEvents) Replay(ctx context.Context, after int64) ([]*domain.ArticleEvent, error) {
+	messages, err := r.client.LRange(ctx, r.key("buffer"), 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrReplay, err)
+	}
+
+	events := make([]*domain.ArticleEvent, 0)
+
+	for _, m := range messages {
+		var e *domain.ArticleEvent
+		if e, err = decode(m); err != nil {
+			return nil, fmt.Errorf("%w:%v", ErrReplay, err)
+		}
+
+		if e.ID > after {
+			events = append(events, e)
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool { return events[i].ID < events[j].ID })
+
+	return events, nil
+}
+
+// Run receives the events published by every replica and sends them to the subscribers,
+// until ctx is done.
+func (r *redisEvents) Run(ctx context.Context) {
+	pubsub := r.client.Subscribe(ctx, r.key("channel"))
+	defer pubsub.Close()
+
+	if _, err := pubsub.Receive(ctx); err != nil {
+		r.logger.Warn(ctx, err, "could not subscribe to article events")
+	}
+
+	messages := pubsub.Channel()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case m, ok := <-messages:
+			if !ok {
+				return
+			}
+
+			e, err := decode(m.Payload)
+			if err != nil {
+				r.logger.Warn(ctx, err, "could not decode article event")
+				continue
+			}
+
+			r.broadcast(e)
+		}
+	}
+}
+
+// broadcast sends the event to every subscriber. A subscriber whose buffer is full is dropped,
+// it can resume from the replay buffer.
+func (r *redisEvents) broadcast(e *domain.ArticleEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for ch := range r.subscribers {
+		select {
+		case ch <- e:
+		default:
+			delete(r.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+func (r *redisEvents) unsubscribe(ch chan *domain.ArticleEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.subscribers[ch]; ok {
+		delete(r.subscribers, ch)
+		close(ch)
+	}
+}
+
+func (r *redisEvents) key(name string) string {
+	return fmt.Sprintf("%s:events:%s", r.cfg.Redis.KeyPrefix, name)
+}
+
+// decode returns the event of a message written by the publish script.
+func decode(message string) (*domain.ArticleEvent, error) {
+	id, payload, ok := strings.Cut(message, ":")
+	if !ok {
+		return nil, fmt.Errorf("%w:no id", ErrDecode)
+	}
+
+	n, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrDecode, err)
+	}
+
+	e := &domain.ArticleEvent{}
+	if err = json.Unmarshal([]byte(payload), e); err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrDecode, err)
+	}
+
+	e.ID = n
+
+	return e, nil
+}
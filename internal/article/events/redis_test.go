@@ -0,0 +1,168 @@
+package events
+
+import (
+	"context"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+func TestRedisEvents_Replay(t *testing.T) {
+	r, _ := newRedisEvents(t, 3)
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		e := &domain.ArticleEvent{Type: domain.EventCreated, Article: &domain.Article{ID: "1"}}
+		require.NoError(t, r.Publish(ctx, e))
+		assert.Equal(t, int64(i+1), e.ID)
+	}
+
+	tt := []struct {
+		name  string
+		after int64
+		ids   []int64
+	}{
+		{name: "bounded", after: 0, ids: []int64{3, 4, 5}},
+		{name: "after", after: 3, ids: []int64{4, 5}},
+		{name: "up to date", after: 5, ids: []int64{}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			events, err := r.Replay(ctx, tc.after)
+			require.NoError(t, err)
+
+			ids := make([]int64, 0, len(events))
+			for _, e := range events {
+				ids = append(ids, e.ID)
+				assert.Equal(t, domain.EventCreated, e.Type)
+				assert.Equal(t, "1", e.Article.ID)
+			}
+
+			assert.Equal(t, tc.ids, ids)
+		})
+	}
+}
+
+func TestRedisEvents_Run(t *testing.T) {
+	r, mr := newRedisEvents(t, 10)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	first, second := r.Subscribe(ctx), r.Subscribe(ctx)
+
+	go r.Run(ctx)
+	require.Eventually(t, func() bool {
+		return mr.PubSubNumSub(r.key("channel"))[r.key("channel")] == 1
+	}, time.Second, 10*time.Millisecond)
+
+	e := &domain.ArticleEvent{Type: domain.EventUpdated, Article: &domain.Article{ID: "1"}}
+	require.NoError(t, r.Publish(ctx, e))
+
+	for _, ch := range []<-chan *domain.ArticleEvent{first, second} {
+		select {
+		case got := <-ch:
+			assert.Equal(t, e.ID, got.ID)
+			assert.Equal(t, domain.EventUpdated, got.Type)
+		case <-time.After(time.Second):
+			t.Fatal("event not received")
+		}
+	}
+}
+
+func TestRedisEvents_Unsubscribe(t *testing.T) {
+	r, _ := newRedisEvents(t, 10)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := r.Subscribe(ctx)
+	cancel()
+
+	select {
+	case _, ok := <-ch:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("subscriber not closed")
+	}
+}
+
+func TestRedisEvents_SlowSubscriber(t *testing.T) {
+	r, _ := newRedisEvents(t, 10)
+	ch := r.Subscribe(context.Background())
+
+	for i := 0; i <= subscriberBuffer; i++ {
+		r.broadcast(&domain.ArticleEvent{ID: int64(i + 1)})
+	}
+
+	received := 0
+	for range ch {
+		received++
+	}
+
+	assert.Equal(t, subscriberBuffer, received)
+}
+
+func TestDecode(t *testing.T) {
+	tt := []struct {
+		name    string
+		message string
+		id      int64
+		err     bool
+	}{
+		{name: "valid", message: `7:{"type":"created","article":{"id":"1"}}`, id: 7},
+		{name: "no id", message: `{"type":"created"}`, err: true},
+		{name: "invalid id", message: `x:{"type":"created"}`, err: true},
+		{name: "invalid json", message: `7:{`, err: true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			e, err := decode(tc.message)
+			if tc.err {
+				assert.ErrorIs(t, err, ErrDecode)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.id, e.ID)
+		})
+	}
+}
+
+func newRedisEvents(t *testing.T, replaySize int64) (*redisEvents, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	cfg := &config.Config{}
+	cfg.Redis.KeyPrefix = "test"
+	cfg.Stream.ReplaySize = replaySize
+
+	return NewRedisEvents(cfg, getLogger(), client), mr
+}
+
+func getLogger() logger.Logger {
+	cfg := &config.Config{}
+
+	l := &logrus.Logger{
+		Out:          io.Discard,
+		Hooks:        make(logrus.LevelHooks),
+		ReportCaller: false,
+		ExitFunc:     os.Exit,
+		Level:        logrus.InfoLevel,
+		Formatter:    &logrus.JSONFormatter{},
+	}
+
+	return logger.New(cfg, l)
+}
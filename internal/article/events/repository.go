@@ -0,0 +1,99 @@
+package events
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/KarolosLykos/sportsnews/domain"
+	"github.com/KarolosLykos/sportsnews/internal/article"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+// publishingRepository is an article.Repository that publishes an event for each upsert that
+// changes an article and for each delete. The other operations go to the wrapped repository.
+type publishingRepository struct {
+	article.Repository
+	logger logger.Logger
+	events article.Events
+}
+
+func NewPublishingRepository(logger logger.Logger, repository article.Repository, events article.Events) *publishingRepository {
+	return &publishingRepository{
+		Repository: repository,
+		logger:     logger,
+		events:     events,
+	}
+}
+
+// Upsert stores the article and publishes its change. The stored article is read first, so that
+// drafts are not sent and an article is created for subscribers when it is first published. A
+// publishing failure is logged only, subscribers see the article again with its next change.
+func (r *publishingRepository) Upsert(ctx context.Context, a *domain.Article) (*domain.Article, domain.Change, error) {
+	wasVisible := r.visible(ctx, a.Provider, a.ArticleID)
+
+	a, change, err := r.Repository.Upsert(ctx, a)
+	if err != nil {
+		return nil, "", err
+	}
+
+	r.publish(ctx, domain.NewArticleEvent(a, change, wasVisible, time.Now()))
+
+	return a, change, nil
+}
+
+// Delete deletes the article and publishes its withdrawal when subscribers saw it. A publishing
+// failure is logged only.
+func (r *publishingRepository) Delete(ctx context.Context, provider, articleID string) (*domain.Article, error) {
+	a, err := r.Repository.Delete(ctx, provider, articleID)
+	if err != nil {
+		return nil, err
+	}
+
+	withdrawn := *a
+	withdrawn.IsPublished = false
+
+	r.publish(ctx, domain.NewArticleEvent(&withdrawn, domain.ChangeUpdated, a.Visible(), time.Now()))
+
+	return a, nil
+}
+
+// Hide hides the article and publishes its withdrawal when it was published. A publishing failure
+// is logged only.
+func (r *publishingRepository) Hide(ctx context.Context, id string) (*domain.Article, error) {
+	a, err := r.Repository.Hide(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.publish(ctx, domain.NewArticleEvent(a, domain.ChangeUpdated, a.IsPublished, time.Now()))
+
+	return a, nil
+}
+
+// visible tells whether subscribers see the stored article. When it cannot be read for another
+// reason than being missing or hidden, it is taken as seen, so that its change is still sent.
+func (r *publishingRepository) visible(ctx context.Context, provider, articleID string) bool {
+	stored, err := r.Repository.GetByArticleID(ctx, provider, articleID)
+	switch {
+	case err == nil:
+		return stored.Visible()
+	case strings.Contains(err.Error(), "no documents in result"):
+		return false
+	}
+
+	r.logger.Warnf(ctx, err, "could not read article with id: %s of provider: %s", articleID, provider)
+
+	return true
+}
+
+// publish publishes the event, when there is one.
+func (r *publishingRepository) publish(ctx context.Context, event *domain.ArticleEvent) {
+	if event == nil {
+		return
+	}
+
+	if err := r.events.Publish(ctx, event); err != nil {
+		r.logger.Warnf(ctx, err, "could not publish %s event of article with id: %s", event.Type, event.Article.ID)
+	}
+}
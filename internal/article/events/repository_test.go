@@ -0,0 +1,288 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/KarolosLykos/sportsnews/domain"
+	mock "github.com/KarolosLykos/sportsnews/internal/article/mock"
+)
+
+func TestPublishingRepository_Upsert(t *testing.T) {
+	published := &domain.Article{ID: "1", ArticleID: "123", IsPublished: true}
+	withdrawn := &domain.Article{ID: "1", ArticleID: "123"}
+	notFound := errors.New("mongo/repository: get by article id:mongo: no documents in result")
+
+	tt := []struct {
+		name    string
+		article *domain.Article
+		stub    func(repo *mock.MockRepository, ev *mock.MockEvents, a *domain.Article)
+		change  domain.Change
+		err     bool
+	}{
+		{
+			name:    "repository error",
+			article: published,
+			stub: func(repo *mock.MockRepository, ev *mock.MockEvents, a *domain.Article) {
+				repo.EXPECT().GetByArticleID(gomock.Any(), a.Provider, a.ArticleID).Times(1).Return(published, nil)
+				repo.EXPECT().Upsert(gomock.Any(), a).Times(1).Return(nil, domain.Change(""), errors.New("something went wrong"))
+			},
+			err: true,
+		},
+		{
+			name:    "unchanged",
+			article: published,
+			stub: func(repo *mock.MockRepository, ev *mock.MockEvents, a *domain.Article) {
+				repo.EXPECT().GetByArticleID(gomock.Any(), a.Provider, a.ArticleID).Times(1).Return(published, nil)
+				repo.EXPECT().Upsert(gomock.Any(), a).Times(1).Return(a, domain.ChangeUnchanged, nil)
+			},
+			change: domain.ChangeUnchanged,
+		},
+		{
+			name:    "created",
+			article: published,
+			stub: func(repo *mock.MockRepository, ev *mock.MockEvents, a *domain.Article) {
+				repo.EXPECT().GetByArticleID(gomock.Any(), a.Provider, a.ArticleID).Times(1).Return(nil, notFound)
+				repo.EXPECT().Upsert(gomock.Any(), a).Times(1).Return(a, domain.ChangeCreated, nil)
+				ev.EXPECT().Publish(gomock.Any(), eventType(domain.EventCreated)).Times(1).Return(nil)
+			},
+			change: domain.ChangeCreated,
+		},
+		{
+			name:    "created draft",
+			article: withdrawn,
+			stub: func(repo *mock.MockRepository, ev *mock.MockEvents, a *domain.Article) {
+				repo.EXPECT().GetByArticleID(gomock.Any(), a.Provider, a.ArticleID).Times(1).Return(nil, notFound)
+				repo.EXPECT().Upsert(gomock.Any(), a).Times(1).Return(a, domain.ChangeCreated, nil)
+			},
+			change: domain.ChangeCreated,
+		},
+		{
+			name:    "draft published",
+			article: published,
+			stub: func(repo *mock.MockRepository, ev *mock.MockEvents, a *domain.Article) {
+				repo.EXPECT().GetByArticleID(gomock.Any(), a.Provider, a.ArticleID).Times(1).Return(withdrawn, nil)
+				repo.EXPECT().Upsert(gomock.Any(), a).Times(1).Return(a, domain.ChangeUpdated, nil)
+				ev.EXPECT().Publish(gomock.Any(), eventType(domain.EventCreated)).Times(1).Return(nil)
+			},
+			change: domain.ChangeUpdated,
+		},
+		{
+			name:    "draft edited",
+			article: withdrawn,
+			stub: func(repo *mock.MockRepository, ev *mock.MockEvents, a *domain.Article) {
+				repo.EXPECT().GetByArticleID(gomock.Any(), a.Provider, a.ArticleID).Times(1).Return(withdrawn, nil)
+				repo.EXPECT().Upsert(gomock.Any(), a).Times(1).Return(a, domain.ChangeUpdated, nil)
+			},
+			change: domain.ChangeUpdated,
+		},
+		{
+			name:    "updated",
+			article: published,
+			stub: func(repo *mock.MockRepository, ev *mock.MockEvents, a *domain.Article) {
+				repo.EXPECT().GetByArticleID(gomock.Any(), a.Provider, a.ArticleID).Times(1).Return(published, nil)
+				repo.EXPECT().Upsert(gomock.Any(), a).Times(1).Return(a, domain.ChangeUpdated, nil)
+				ev.EXPECT().Publish(gomock.Any(), eventType(domain.EventUpdated)).Times(1).Return(nil)
+			},
+			change: domain.ChangeUpdated,
+		},
+		{
+			name:    "stored article read error",
+			article: published,
+			stub: func(repo *mock.MockRepository, ev *mock.MockEvents, a *domain.Article) {
+				repo.EXPECT().GetByArticleID(gomock.Any(), a.Provider, a.ArticleID).Times(1).Return(nil, errors.New("something went wrong"))
+				repo.EXPECT().Upsert(gomock.Any(), a).Times(1).Return(a, domain.ChangeUpdated, nil)
+				ev.EXPECT().Publish(gomock.Any(), eventType(domain.EventUpdated)).Times(1).Return(nil)
+			},
+			change: domain.ChangeUpdated,
+		},
+		{
+			name:    "withdrawn",
+			article: withdrawn,
+			stub: func(repo *mock.MockRepository, ev *mock.MockEvents, a *domain.Article) {
+				repo.EXPECT().GetByArticleID(gomock.Any(), a.Provider, a.ArticleID).Times(1).Return(published, nil)
+				repo.EXPECT().Upsert(gomock.Any(), a).Times(1).Return(a, domain.ChangeUpdated, nil)
+				ev.EXPECT().Publish(gomock.Any(), eventType(domain.EventWithdrawn)).Times(1).Return(nil)
+			},
+			change: domain.ChangeUpdated,
+		},
+		{
+			name:    "publish error",
+			article: published,
+			stub: func(repo *mock.MockRepository, ev *mock.MockEvents, a *domain.Article) {
+				repo.EXPECT().GetByArticleID(gomock.Any(), a.Provider, a.ArticleID).Times(1).Return(published, nil)
+				repo.EXPECT().Upsert(gomock.Any(), a).Times(1).Return(a, domain.ChangeUpdated, nil)
+				ev.EXPECT().Publish(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("something went wrong"))
+			},
+			change: domain.ChangeUpdated,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockRepository(ctrl)
+			ev := mock.NewMockEvents(ctrl)
+
+			tc.stub(repo, ev, tc.article)
+			r := NewPublishingRepository(getLogger(), repo, ev)
+
+			_, change, err := r.Upsert(context.Background(), tc.article)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.change, change)
+		})
+	}
+}
+
+func TestPublishingRepository_Delete(t *testing.T) {
+	a := &domain.Article{ID: "1", ArticleID: "123", Provider: domain.EditorialProvider, IsPublished: true}
+	draft := &domain.Article{ID: "1", ArticleID: "123", Provider: domain.EditorialProvider}
+
+	tt := []struct {
+		name    string
+		article *domain.Article
+		stub    func(repo *mock.MockRepository, ev *mock.MockEvents)
+		err     bool
+	}{
+		{
+			name: "repository error",
+			stub: func(repo *mock.MockRepository, ev *mock.MockEvents) {
+				repo.EXPECT().Delete(gomock.Any(), domain.EditorialProvider, "123").Times(1).Return(nil, errors.New("something went wrong"))
+			},
+			err: true,
+		},
+		{
+			name:    "withdrawn",
+			article: a,
+			stub: func(repo *mock.MockRepository, ev *mock.MockEvents) {
+				repo.EXPECT().Delete(gomock.Any(), domain.EditorialProvider, "123").Times(1).Return(a, nil)
+				ev.EXPECT().Publish(gomock.Any(), eventType(domain.EventWithdrawn)).Times(1).
+					Do(func(_ interface{}, e *domain.ArticleEvent) {
+						assert.False(t, e.Article.IsPublished)
+					}).Return(nil)
+			},
+		},
+		{
+			name:    "draft",
+			article: draft,
+			stub: func(repo *mock.MockRepository, ev *mock.MockEvents) {
+				repo.EXPECT().Delete(gomock.Any(), domain.EditorialProvider, "123").Times(1).Return(draft, nil)
+			},
+		},
+		{
+			name:    "publish error",
+			article: a,
+			stub: func(repo *mock.MockRepository, ev *mock.MockEvents) {
+				repo.EXPECT().Delete(gomock.Any(), domain.EditorialProvider, "123").Times(1).Return(a, nil)
+				ev.EXPECT().Publish(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("something went wrong"))
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockRepository(ctrl)
+			ev := mock.NewMockEvents(ctrl)
+
+			tc.stub(repo, ev)
+			r := NewPublishingRepository(getLogger(), repo, ev)
+
+			deleted, err := r.Delete(context.Background(), domain.EditorialProvider, "123")
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.article, deleted)
+			assert.Equal(t, tc.article == a, deleted.IsPublished)
+		})
+	}
+}
+
+// eventType matches the events of the given type.
+type eventType domain.EventType
+
+func (t eventType) Matches(x interface{}) bool {
+	e, ok := x.(*domain.ArticleEvent)
+	return ok && e.Type == domain.EventType(t)
+}
+
+func (t eventType) String() string {
+	return "is " + string(t) + " event"
+}
+
+func TestPublishingRepository_Hide(t *testing.T) {
+	a := &domain.Article{ID: "1", ArticleID: "123", Provider: domain.HullCityProvider, IsPublished: true, Hidden: true}
+	draft := &domain.Article{ID: "1", ArticleID: "123", Provider: domain.HullCityProvider, Hidden: true}
+
+	tt := []struct {
+		name string
+		stub func(repo *mock.MockRepository, ev *mock.MockEvents)
+		err  bool
+	}{
+		{
+			name: "repository error",
+			stub: func(repo *mock.MockRepository, ev *mock.MockEvents) {
+				repo.EXPECT().Hide(gomock.Any(), "1").Times(1).Return(nil, errors.New("something went wrong"))
+			},
+			err: true,
+		},
+		{
+			name: "withdrawn",
+			stub: func(repo *mock.MockRepository, ev *mock.MockEvents) {
+				repo.EXPECT().Hide(gomock.Any(), "1").Times(1).Return(a, nil)
+				ev.EXPECT().Publish(gomock.Any(), eventType(domain.EventWithdrawn)).Times(1).Return(nil)
+			},
+		},
+		{
+			name: "draft",
+			stub: func(repo *mock.MockRepository, ev *mock.MockEvents) {
+				repo.EXPECT().Hide(gomock.Any(), "1").Times(1).Return(draft, nil)
+			},
+		},
+		{
+			name: "publish error",
+			stub: func(repo *mock.MockRepository, ev *mock.MockEvents) {
+				repo.EXPECT().Hide(gomock.Any(), "1").Times(1).Return(a, nil)
+				ev.EXPECT().Publish(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("something went wrong"))
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockRepository(ctrl)
+			ev := mock.NewMockEvents(ctrl)
+
+			tc.stub(repo, ev)
+			r := NewPublishingRepository(getLogger(), repo, ev)
+
+			hidden, err := r.Hide(context.Background(), "1")
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, hidden.Hidden)
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package article
+
+import (
+	"context"
+
+	"github.com/KarolosLykos/sportsnews/domain"
+)
+
+// Index is a search index of the articles, kept up to date by ingestion.
+type Index interface {
+	Index(ctx context.Context, articles ...*domain.Article) error
+	Delete(ctx context.Context, ids ...string) error
+	Search(ctx context.Context, params domain.SearchParams) (*domain.SearchResults, error)
+	Reindex(ctx context.Context) (*domain.ReindexResult, error)
+}
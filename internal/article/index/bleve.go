@@ -0,0 +1,530 @@
+package index
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/keyword"
+	"github.com/blevesearch/bleve/v2/analysis/lang/en"
+	"github.com/blevesearch/bleve/v2/mapping"
+	htmlformat "github.com/blevesearch/bleve/v2/search/highlight/format/html"
+	"github.com/blevesearch/bleve/v2/search/query"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+	"github.com/KarolosLykos/sportsnews/internal/article"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+// facetSize is the number of teams and types counted by a search.
+const facetSize = 20
+
+var (
+	ErrOpen    = errors.New("index: open")
+	ErrIndex   = errors.New("index: index")
+	ErrDelete  = errors.New("index: delete")
+	ErrSearch  = errors.New("index: search")
+	ErrReindex = errors.New("index: reindex")
+)
+
+// textFields are the searchable fields with their weight, as in the Mongo text index.
+var textFields = []struct {
+	name   string
+	weight float64
+}{
+	{"title", 10},
+	{"subtitle", 5},
+	{"teaser", 3},
+	{"content", 1},
+}
+
+var tags = regexp.MustCompile(`<[^>]*>`)
+
+// document is the indexed form of an article. Source holds the article itself,
+// stored but not indexed, so that searches do not go back to Mongo.
+type document struct {
+	Title       string    `json:"title"`
+	Subtitle    string    `json:"subtitle"`
+	Teaser      string    `json:"teaser"`
+	Content     string    `json:"content"`
+	TeamID      string    `json:"teamId"`
+	Type        []string  `json:"type"`
+	Published   time.Time `json:"published"`
+	IsPublished bool      `json:"isPublished"`
+	OptaMatchID string    `json:"optaMatchId"`
+	HasVideo    bool      `json:"hasVideo"`
+	Source      string    `json:"source"`
+}
+
+func newDocument(a *domain.Article) (*document, error) {
+	source, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+
+	return &document{
+		Title:       a.Title,
+		Subtitle:    a.Subtitle,
+		Teaser:      a.Teaser,
+		Content:     strings.Join(strings.Fields(html.UnescapeString(tags.ReplaceAllString(a.Content, " "))), " "),
+		TeamID:      a.TeamID,
+		Type:        a.Type,
+		Published:   a.Published,
+		IsPublished: a.IsPublished,
+		OptaMatchID: a.OptaMatchID,
+		HasVideo:    a.VideoURL != "",
+		Source:      string(source),
+	}, nil
+}
+
+// bleveIndex is an article.Index stored in a directory, running inside the process.
+type bleveIndex struct {
+	logger    logger.Logger
+	index     bleve.Index
+	source    article.Repository
+	fuzziness int
+
+	// indexed holds the ids of the articles indexed while a reindex runs, nil otherwise, so that
+	// the reindex keeps the articles indexed meanwhile.
+	mu      sync.Mutex
+	indexed map[string]struct{}
+}
+
+// NewBleveIndex opens the index stored at cfg.Search.IndexPath, creating it when missing.
+// Reindex reads the articles from source.
+func NewBleveIndex(cfg *config.Config, logger logger.Logger, source article.Repository) (*bleveIndex, error) {
+	idx, err := bleve.Open(cfg.Search.IndexPath)
+	if errors.Is(err, bleve.ErrorIndexPathDoesNotExist) {
+		idx, err = bleve.New(cfg.Search.IndexPath, indexMapping())
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrOpen, err)
+	}
+
+	return &bleveIndex{
+		logger:    logger,
+		index:     idx,
+		source:    source,
+		fuzziness: cfg.Search.Fuzziness,
+	}, nil
+}
+
+func indexMapping() mapping.IndexMapping {
+	text := bleve.NewTextFieldMapping()
+	text.Analyzer = en.AnalyzerName
+	text.IncludeInAll = false
+
+	exact := bleve.NewTextFieldMapping()
+	exact.Analyzer = keyword.Name
+	exact.Store = false
+	exact.IncludeTermVectors = false
+	exact.IncludeInAll = false
+
+	date := bleve.NewDateTimeFieldMapping()
+	date.Store = false
+
+	boolean := bleve.NewBooleanFieldMapping()
+	boolean.Store = false
+
+	source := bleve.NewTextFieldMapping()
+	source.Index = false
+	source.IncludeTermVectors = false
+	source.IncludeInAll = false
+
+	doc := bleve.NewDocumentStaticMapping()
+	for _, f := range textFields {
+		doc.AddFieldMappingsAt(f.name, text)
+	}
+
+	doc.AddFieldMappingsAt("teamId", exact)
+	doc.AddFieldMappingsAt("type", exact)
+	doc.AddFieldMappingsAt("optaMatchId", exact)
+	doc.AddFieldMappingsAt("published", date)
+	doc.AddFieldMappingsAt("isPublished", boolean)
+	doc.AddFieldMappingsAt("hasVideo", boolean)
+	doc.AddFieldMappingsAt("source", source)
+
+	m := bleve.NewIndexMapping()
+	m.DefaultMapping = doc
+	m.DefaultAnalyzer = en.AnalyzerName
+
+	return m
+}
+
+// Index adds or replaces the articles in the index.
+func (b *bleveIndex) Index(_ context.Context, articles ...*domain.Article) error {
+	batch := b.index.NewBatch()
+
+	for _, a := range articles {
+		doc, err := newDocument(a)
+		if err != nil {
+			return fmt.Errorf("%w:%v", ErrIndex, err)
+		}
+
+		if err = batch.Index(a.ID, doc); err != nil {
+			return fmt.Errorf("%w:%v", ErrIndex, err)
+		}
+	}
+
+	if err := b.index.Batch(batch); err != nil {
+		return fmt.Errorf("%w:%v", ErrIndex, err)
+	}
+
+	b.mu.Lock()
+	if b.indexed != nil {
+		for _, a := range articles {
+			b.indexed[a.ID] = struct{}{}
+		}
+	}
+	b.mu.Unlock()
+
+	return nil
+}
+
+func (b *bleveIndex) Delete(_ context.Context, ids ...string) error {
+	batch := b.index.NewBatch()
+
+	for _, id := range ids {
+		batch.Delete(id)
+	}
+
+	if err := b.index.Batch(batch); err != nil {
+		return fmt.Errorf("%w:%v", ErrDelete, err)
+	}
+
+	return nil
+}
+
+// Search returns a page of the filtered articles matching the query, by descending relevance,
+// with highlighted fragments and the counts of the matching articles by team and type.
+func (b *bleveIndex) Search(ctx context.Context, params domain.SearchParams) (*domain.SearchResults, error) {
+	fuzziness := 0
+	if params.Fuzzy {
+		fuzziness = b.fuzziness
+	}
+
+	req := bleve.NewSearchRequestOptions(searchQuery(params, fuzziness), params.Limit, params.Offset, false)
+	req.Fields = []string{"source"}
+	req.SortBy([]string{"-_score", "-published"})
+	req.Highlight = bleve.NewHighlightWithStyle(htmlformat.Name)
+
+	for _, f := range textFields {
+		req.Highlight.AddField(f.name)
+	}
+
+	req.AddFacet("teamId", bleve.NewFacetRequest("teamId", facetSize))
+	req.AddFacet("type", bleve.NewFacetRequest("type", facetSize))
+
+	res, err := b.index.SearchInContext(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrSearch, err)
+	}
+
+	results := &domain.SearchResults{
+		Total:  int64(res.Total),
+		Hits:   make([]*domain.SearchHit, 0, len(res.Hits)),
+		Limit:  params.Limit,
+		Offset: params.Offset,
+		Facets: make(map[string]domain.Facets, len(res.Facets)),
+	}
+
+	for _, hit := range res.Hits {
+		source, _ := hit.Fields["source"].(string)
+
+		a := &domain.Article{}
+		if err = json.Unmarshal([]byte(source), a); err != nil {
+			return nil, fmt.Errorf("%w:%v", ErrSearch, err)
+		}
+
+		results.Hits = append(results.Hits, &domain.SearchHit{Article: a, Score: hit.Score, Highlights: hit.Fragments})
+	}
+
+	for name, facet := range res.Facets {
+		facets := make(domain.Facets, 0)
+		if facet.Terms != nil {
+			for _, term := range facet.Terms.Terms() {
+				facets = append(facets, &domain.Facet{Value: term.Term, Count: term.Count})
+			}
+		}
+
+		results.Facets[name] = facets
+	}
+
+	return results, nil
+}
+
+// Reindex indexes every article of the source, a page at a time, and removes the articles that are
+// no longer in it, such as deleted or hidden ones.
+func (b *bleveIndex) Reindex(ctx context.Context) (*domain.ReindexResult, error) {
+	start := time.Now()
+	result := &domain.ReindexResult{}
+	params := domain.ListParams{Sort: domain.DefaultSort, Limit: domain.MaxPageSize}
+
+	b.mu.Lock()
+	b.indexed = map[string]struct{}{}
+	b.mu.Unlock()
+
+	defer func() {
+		b.mu.Lock()
+		b.indexed = nil
+		b.mu.Unlock()
+	}()
+
+	for {
+		page, err := b.source.List(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("%w:%v", ErrReindex, err)
+		}
+
+		if len(page.Articles) > 0 {
+			if err = b.Index(ctx, page.Articles...); err != nil {
+				return nil, fmt.Errorf("%w:%v", ErrReindex, err)
+			}
+		}
+
+		result.Indexed += len(page.Articles)
+
+		if page.Next == nil {
+			break
+		}
+
+		params.Cursor = page.Next
+	}
+
+	stale, err := b.staleIDs()
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrReindex, err)
+	}
+
+	if len(stale) > 0 {
+		if err = b.Delete(ctx, stale...); err != nil {
+			return nil, fmt.Errorf("%w:%v", ErrReindex, err)
+		}
+	}
+
+	result.Removed = len(stale)
+	result.Took = time.Since(start).String()
+	b.logger.Infof(ctx, "reindexed %d articles and removed %d in %s", result.Indexed, result.Removed, result.Took)
+
+	return result, nil
+}
+
+// staleIDs returns the ids of the documents that were not indexed since the reindex started, reading
+// every document of the index a page at a time, in id order.
+func (b *bleveIndex) staleIDs() ([]string, error) {
+	var stale []string
+
+	req := bleve.NewSearchRequestOptions(bleve.NewMatchAllQuery(), domain.MaxPageSize, 0, false)
+	req.SortBy([]string{"_id"})
+
+	for {
+		res, err := b.index.Search(req)
+		if err != nil {
+			return nil, err
+		}
+
+		b.mu.Lock()
+		for _, hit := range res.Hits {
+			if _, ok := b.indexed[hit.ID]; !ok {
+				stale = append(stale, hit.ID)
+			}
+		}
+		b.mu.Unlock()
+
+		if len(res.Hits) < req.Size {
+			return stale, nil
+		}
+
+		req.SetSearchAfter([]string{res.Hits[len(res.Hits)-1].ID})
+	}
+}
+
+// DocCount returns the number of indexed articles.
+func (b *bleveIndex) DocCount() (uint64, error) {
+	return b.index.DocCount()
+}
+
+func (b *bleveIndex) Close() error {
+	return b.index.Close()
+}
+
+// searchQuery returns the query of the search. Like Mongo text search, the words of the query
+// match any field and are optional when the query has phrases, phrases are all required and
+// words prefixed with - exclude the articles that contain them.
+func searchQuery(params domain.SearchParams, fuzziness int) query.Query {
+	words, phrases, excluded := parseQuery(params.Query)
+
+	q := bleve.NewBooleanQuery()
+
+	if len(words) > 0 {
+		match := anyField(func(field string, weight float64) query.Query {
+			m := bleve.NewMatchQuery(strings.Join(words, " "))
+			m.SetField(field)
+			m.SetBoost(weight)
+			m.SetFuzziness(fuzziness)
+
+			return m
+		})
+
+		if len(phrases) > 0 {
+			q.AddShould(match)
+		} else {
+			q.AddMust(match)
+		}
+	}
+
+	for _, phrase := range phrases {
+		phrase := phrase
+		q.AddMust(anyField(func(field string, weight float64) query.Query {
+			m := bleve.NewMatchPhraseQuery(phrase)
+			m.SetField(field)
+			m.SetBoost(weight)
+
+			return m
+		}))
+	}
+
+	if len(words) == 0 && len(phrases) == 0 {
+		return bleve.NewMatchNoneQuery()
+	}
+
+	for _, word := range excluded {
+		word := word
+		q.AddMustNot(anyField(func(field string, _ float64) query.Query {
+			m := bleve.NewMatchQuery(word)
+			m.SetField(field)
+
+			return m
+		}))
+	}
+
+	if filters := filterQueries(params.Filter); len(filters) > 0 {
+		q.AddMust(filters...)
+	}
+
+	return q
+}
+
+// anyField returns the disjunction of the query over the searchable fields.
+func anyField(fn func(field string, weight float64) query.Query) query.Query {
+	queries := make([]query.Query, 0, len(textFields))
+	for _, f := range textFields {
+		queries = append(queries, fn(f.name, f.weight))
+	}
+
+	return bleve.NewDisjunctionQuery(queries...)
+}
+
+// filterQueries returns the queries of the filter.
+func filterQueries(f domain.ArticleFilter) []query.Query {
+	queries := make([]query.Query, 0)
+
+	term := func(field, value string) query.Query {
+		q := bleve.NewTermQuery(value)
+		q.SetField(field)
+
+		return q
+	}
+
+	boolean := func(field string, value bool) query.Query {
+		q := bleve.NewBoolFieldQuery(value)
+		q.SetField(field)
+
+		return q
+	}
+
+	if f.TeamID != "" {
+		queries = append(queries, term("teamId", f.TeamID))
+	}
+
+	if len(f.Types) > 0 {
+		types := make([]query.Query, 0, len(f.Types))
+		for _, t := range f.Types {
+			types = append(types, term("type", t))
+		}
+
+		if f.TypeMatch == domain.TypeMatchAll {
+			queries = append(queries, bleve.NewConjunctionQuery(types...))
+		} else {
+			queries = append(queries, bleve.NewDisjunctionQuery(types...))
+		}
+	}
+
+	if f.PublishedSince != nil || f.PublishedUntil != nil {
+		var since, until time.Time
+		if f.PublishedSince != nil {
+			since = *f.PublishedSince
+		}
+
+		if f.PublishedUntil != nil {
+			until = *f.PublishedUntil
+		}
+
+		inclusive, exclusive := true, false
+		q := bleve.NewDateRangeInclusiveQuery(since, until, &inclusive, &exclusive)
+		q.SetField("published")
+		queries = append(queries, q)
+	}
+
+	if f.IsPublished != nil {
+		queries = append(queries, boolean("isPublished", *f.IsPublished))
+	}
+
+	if f.OptaMatchID != "" {
+		queries = append(queries, term("optaMatchId", f.OptaMatchID))
+	}
+
+	if len(f.OptaMatchIDs) > 0 {
+		matches := make([]query.Query, 0, len(f.OptaMatchIDs))
+		for _, id := range f.OptaMatchIDs {
+			matches = append(matches, term("optaMatchId", id))
+		}
+
+		queries = append(queries, bleve.NewDisjunctionQuery(matches...))
+	}
+
+	if f.HasVideo != nil {
+		queries = append(queries, boolean("hasVideo", *f.HasVideo))
+	}
+
+	return queries
+}
+
+// parseQuery splits a text search into its words, its quoted phrases and its words prefixed with -.
+func parseQuery(q string) (words, phrases, excluded []string) {
+	parts := strings.Split(q, `"`)
+
+	for i, part := range parts {
+		// Odd parts are quoted, unless the last quote is not closed.
+		if i%2 == 1 && i < len(parts)-1 {
+			if phrase := strings.TrimSpace(part); phrase != "" {
+				phrases = append(phrases, phrase)
+			}
+
+			continue
+		}
+
+		for _, word := range strings.Fields(part) {
+			if strings.HasPrefix(word, "-") {
+				if word = strings.TrimLeft(word, "-"); word != "" {
+					excluded = append(excluded, word)
+				}
+
+				continue
+			}
+
+			words = append(words, word)
+		}
+	}
+
+	return words, phrases, excluded
+}
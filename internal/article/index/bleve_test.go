@@ -0,0 +1,224 @@
+package index
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+	mock "github.com/KarolosLykos/sportsnews/internal/article/mock"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+func TestBleveIndex_Search(t *testing.T) {
+	published := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+	yes := true
+	since := published.Add(36 * time.Hour)
+
+	articles := []*domain.Article{
+		{
+			ID:          "1",
+			Title:       "Tigers win the derby",
+			Content:     "<p>A late goal settles the Humber derby.</p>",
+			TeamID:      "Hull City",
+			Type:        []string{"Match Report"},
+			Published:   published,
+			IsPublished: true,
+		},
+		{
+			ID:          "2",
+			Title:       "Academy side beaten",
+			Teaser:      "The under 21s lose the derby",
+			TeamID:      "Hull City",
+			Type:        []string{"Academy", "Match Report"},
+			Published:   published.Add(24 * time.Hour),
+			IsPublished: true,
+			VideoURL:    "https://example.com/video",
+		},
+		{
+			ID:        "3",
+			Title:     "New signing joins the club",
+			TeamID:    "Leeds",
+			Type:      []string{"News"},
+			Published: published.Add(48 * time.Hour),
+		},
+	}
+
+	idx := newTestIndex(t, nil)
+	require.NoError(t, idx.Index(context.Background(), articles...))
+
+	tt := []struct {
+		name   string
+		params domain.SearchParams
+		want   []string
+	}{
+		{name: "word", params: domain.SearchParams{Query: "derby"}, want: []string{"1", "2"}},
+		{name: "stemmed word", params: domain.SearchParams{Query: "signings"}, want: []string{"3"}},
+		{name: "typo", params: domain.SearchParams{Query: "derbu"}, want: []string{}},
+		{name: "fuzzy typo", params: domain.SearchParams{Query: "derbu", Fuzzy: true}, want: []string{"1", "2"}},
+		{name: "phrase", params: domain.SearchParams{Query: `"humber derby"`}, want: []string{"1"}},
+		{name: "excluded word", params: domain.SearchParams{Query: "derby -academy"}, want: []string{"1"}},
+		{name: "only excluded words", params: domain.SearchParams{Query: "-derby"}, want: []string{}},
+		{
+			name:   "filter by type",
+			params: domain.SearchParams{Query: "derby", Filter: domain.ArticleFilter{Types: []string{"Academy"}}},
+			want:   []string{"2"},
+		},
+		{
+			name:   "filter by video",
+			params: domain.SearchParams{Query: "derby", Filter: domain.ArticleFilter{HasVideo: &yes}},
+			want:   []string{"2"},
+		},
+		{
+			name:   "filter by date",
+			params: domain.SearchParams{Query: "derby signing", Filter: domain.ArticleFilter{PublishedSince: &since}},
+			want:   []string{"3"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.params.Limit = domain.DefaultPageSize
+
+			res, err := idx.Search(context.Background(), tc.params)
+			require.NoError(t, err)
+
+			ids := make([]string, 0, len(res.Hits))
+			for _, hit := range res.Hits {
+				ids = append(ids, hit.ID)
+			}
+
+			assert.ElementsMatch(t, tc.want, ids)
+			assert.Equal(t, int64(len(tc.want)), res.Total)
+		})
+	}
+}
+
+func TestBleveIndex_SearchResults(t *testing.T) {
+	a := &domain.Article{
+		ID:      "1",
+		Title:   "Tigers win the derby",
+		Content: "<p>A late goal settles the <b>Humber</b> derby & more.</p>",
+		TeamID:  "Hull City",
+		Type:    []string{"Match Report", "News"},
+	}
+
+	idx := newTestIndex(t, nil)
+	require.NoError(t, idx.Index(context.Background(), a))
+
+	res, err := idx.Search(context.Background(), domain.SearchParams{Query: "derby", Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, res.Hits, 1)
+
+	hit := res.Hits[0]
+	assert.Equal(t, a, hit.Article)
+	assert.Positive(t, hit.Score)
+	assert.Equal(t, []string{"Tigers win the <mark>derby</mark>"}, hit.Highlights["title"])
+	assert.Equal(t, []string{"A late goal settles the Humber <mark>derby</mark> &amp; more."}, hit.Highlights["content"])
+
+	assert.Equal(t, domain.Facets{{Value: "Hull City", Count: 1}}, res.Facets["teamId"])
+	assert.ElementsMatch(t, domain.Facets{{Value: "Match Report", Count: 1}, {Value: "News", Count: 1}}, res.Facets["type"])
+}
+
+func TestBleveIndex_Reindex(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	next := &domain.Cursor{Sort: domain.DefaultSort, ID: "2"}
+
+	var idx *bleveIndex
+
+	repo := mock.NewMockRepository(ctrl)
+	gomock.InOrder(
+		repo.EXPECT().List(gomock.Any(), domain.ListParams{Sort: domain.DefaultSort, Limit: domain.MaxPageSize}).Times(1).
+			DoAndReturn(func(ctx context.Context, _ domain.ListParams) (*domain.Articles, error) {
+				// An article synced while the index is rebuilt is kept.
+				require.NoError(t, idx.Index(ctx, &domain.Article{ID: "7", Title: "derby"}))
+
+				return &domain.Articles{Articles: []*domain.Article{{ID: "1", Title: "derby"}, {ID: "2", Title: "derby"}}, Next: next}, nil
+			}),
+		repo.EXPECT().List(gomock.Any(), domain.ListParams{Sort: domain.DefaultSort, Limit: domain.MaxPageSize, Cursor: next}).Times(1).
+			Return(&domain.Articles{Articles: []*domain.Article{{ID: "3", Title: "derby"}}}, nil),
+	)
+
+	idx = newTestIndex(t, repo)
+
+	// The article that is no longer in the database is removed.
+	require.NoError(t, idx.Index(context.Background(), &domain.Article{ID: "9", Title: "derby"}))
+
+	res, err := idx.Reindex(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, res.Indexed)
+	assert.Equal(t, 1, res.Removed)
+
+	count, err := idx.DocCount()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), count)
+}
+
+func TestBleveIndex_Delete(t *testing.T) {
+	idx := newTestIndex(t, nil)
+	require.NoError(t, idx.Index(context.Background(), &domain.Article{ID: "1", Title: "derby"}, &domain.Article{ID: "2", Title: "derby"}))
+
+	require.NoError(t, idx.Delete(context.Background(), "1", "unknown"))
+
+	res, err := idx.Search(context.Background(), domain.SearchParams{Query: "derby", Limit: domain.DefaultPageSize})
+	require.NoError(t, err)
+	require.Len(t, res.Hits, 1)
+	assert.Equal(t, "2", res.Hits[0].ID)
+}
+
+func TestNewBleveIndex_Reopen(t *testing.T) {
+	cfg := &config.Config{Search: config.SearchConfig{IndexPath: filepath.Join(t.TempDir(), "articles.bleve")}}
+
+	idx, err := NewBleveIndex(cfg, getLogger(), nil)
+	require.NoError(t, err)
+	require.NoError(t, idx.Index(context.Background(), &domain.Article{ID: "1", Title: "derby"}))
+	require.NoError(t, idx.Close())
+
+	idx, err = NewBleveIndex(cfg, getLogger(), nil)
+	require.NoError(t, err)
+
+	defer idx.Close()
+
+	count, err := idx.DocCount()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), count)
+}
+
+func newTestIndex(t *testing.T, source *mock.MockRepository) *bleveIndex {
+	t.Helper()
+
+	cfg := &config.Config{Search: config.SearchConfig{IndexPath: filepath.Join(t.TempDir(), "articles.bleve"), Fuzziness: 1}}
+
+	idx, err := NewBleveIndex(cfg, getLogger(), source)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { idx.Close() })
+
+	return idx
+}
+
+func getLogger() logger.Logger {
+	cfg := &config.Config{}
+
+	l := &logrus.Logger{
+		Out:          io.Discard,
+		Hooks:        make(logrus.LevelHooks),
+		ReportCaller: false,
+		ExitFunc:     os.Exit,
+		Level:        logrus.InfoLevel,
+		Formatter:    &logrus.JSONFormatter{},
+	}
+
+	return logger.New(cfg, l)
+}
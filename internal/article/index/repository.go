@@ -0,0 +1,74 @@
+package index
+
+import (
+	"context"
+
+	"github.com/KarolosLykos/sportsnews/domain"
+	"github.com/KarolosLykos/sportsnews/internal/article"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+// indexedRepository is an article.Repository that updates a search index after each
+// upsert and delete and serves searches from it. The other operations go to the wrapped repository.
+type indexedRepository struct {
+	article.Repository
+	logger logger.Logger
+	index  article.Index
+}
+
+func NewIndexedRepository(logger logger.Logger, repository article.Repository, index article.Index) *indexedRepository {
+	return &indexedRepository{
+		Repository: repository,
+		logger:     logger,
+		index:      index,
+	}
+}
+
+// Upsert stores the article and indexes it when it changed, unless it is hidden. An indexing failure
+// is logged only, the article is indexed again by the next change or reindex.
+func (r *indexedRepository) Upsert(ctx context.Context, a *domain.Article) (*domain.Article, domain.Change, error) {
+	a, change, err := r.Repository.Upsert(ctx, a)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if change != domain.ChangeUnchanged && !a.Hidden {
+		if err = r.index.Index(ctx, a); err != nil {
+			r.logger.Warnf(ctx, err, "could not index article with id: %s", a.ID)
+		}
+	}
+
+	return a, change, nil
+}
+
+// Delete deletes the article and removes it from the index. A removal failure is logged only.
+func (r *indexedRepository) Delete(ctx context.Context, provider, articleID string) (*domain.Article, error) {
+	a, err := r.Repository.Delete(ctx, provider, articleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = r.index.Delete(ctx, a.ID); err != nil {
+		r.logger.Warnf(ctx, err, "could not remove article with id: %s from the index", a.ID)
+	}
+
+	return a, nil
+}
+
+// Hide hides the article and removes it from the index. A removal failure is logged only.
+func (r *indexedRepository) Hide(ctx context.Context, id string) (*domain.Article, error) {
+	a, err := r.Repository.Hide(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = r.index.Delete(ctx, a.ID); err != nil {
+		r.logger.Warnf(ctx, err, "could not remove article with id: %s from the index", a.ID)
+	}
+
+	return a, nil
+}
+
+func (r *indexedRepository) Search(ctx context.Context, params domain.SearchParams) (*domain.SearchResults, error) {
+	return r.index.Search(ctx, params)
+}
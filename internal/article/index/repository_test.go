@@ -0,0 +1,185 @@
+package index
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/KarolosLykos/sportsnews/domain"
+	mock "github.com/KarolosLykos/sportsnews/internal/article/mock"
+)
+
+func TestIndexedRepository_Upsert(t *testing.T) {
+	a := &domain.Article{ID: "1", ArticleID: "123"}
+
+	tt := []struct {
+		name   string
+		stub   func(repo *mock.MockRepository, idx *mock.MockIndex)
+		change domain.Change
+		err    bool
+	}{
+		{
+			name: "repository error",
+			stub: func(repo *mock.MockRepository, idx *mock.MockIndex) {
+				repo.EXPECT().Upsert(gomock.Any(), a).Times(1).Return(nil, domain.Change(""), errors.New("something went wrong"))
+			},
+			err: true,
+		},
+		{
+			name: "unchanged",
+			stub: func(repo *mock.MockRepository, idx *mock.MockIndex) {
+				repo.EXPECT().Upsert(gomock.Any(), a).Times(1).Return(a, domain.ChangeUnchanged, nil)
+			},
+			change: domain.ChangeUnchanged,
+		},
+		{
+			name: "created",
+			stub: func(repo *mock.MockRepository, idx *mock.MockIndex) {
+				repo.EXPECT().Upsert(gomock.Any(), a).Times(1).Return(a, domain.ChangeCreated, nil)
+				idx.EXPECT().Index(gomock.Any(), a).Times(1).Return(nil)
+			},
+			change: domain.ChangeCreated,
+		},
+		{
+			name: "index error",
+			stub: func(repo *mock.MockRepository, idx *mock.MockIndex) {
+				repo.EXPECT().Upsert(gomock.Any(), a).Times(1).Return(a, domain.ChangeUpdated, nil)
+				idx.EXPECT().Index(gomock.Any(), a).Times(1).Return(errors.New("something went wrong"))
+			},
+			change: domain.ChangeUpdated,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockRepository(ctrl)
+			idx := mock.NewMockIndex(ctrl)
+
+			tc.stub(repo, idx)
+			r := NewIndexedRepository(getLogger(), repo, idx)
+
+			_, change, err := r.Upsert(context.Background(), a)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.change, change)
+		})
+	}
+}
+
+func TestIndexedRepository_Delete(t *testing.T) {
+	a := &domain.Article{ID: "1", ArticleID: "123", Provider: domain.EditorialProvider}
+
+	tt := []struct {
+		name string
+		stub func(repo *mock.MockRepository, idx *mock.MockIndex)
+		err  bool
+	}{
+		{
+			name: "repository error",
+			stub: func(repo *mock.MockRepository, idx *mock.MockIndex) {
+				repo.EXPECT().Delete(gomock.Any(), domain.EditorialProvider, "123").Times(1).Return(nil, errors.New("something went wrong"))
+			},
+			err: true,
+		},
+		{
+			name: "deleted",
+			stub: func(repo *mock.MockRepository, idx *mock.MockIndex) {
+				repo.EXPECT().Delete(gomock.Any(), domain.EditorialProvider, "123").Times(1).Return(a, nil)
+				idx.EXPECT().Delete(gomock.Any(), "1").Times(1).Return(nil)
+			},
+		},
+		{
+			name: "index error",
+			stub: func(repo *mock.MockRepository, idx *mock.MockIndex) {
+				repo.EXPECT().Delete(gomock.Any(), domain.EditorialProvider, "123").Times(1).Return(a, nil)
+				idx.EXPECT().Delete(gomock.Any(), "1").Times(1).Return(errors.New("something went wrong"))
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockRepository(ctrl)
+			idx := mock.NewMockIndex(ctrl)
+
+			tc.stub(repo, idx)
+			r := NewIndexedRepository(getLogger(), repo, idx)
+
+			deleted, err := r.Delete(context.Background(), domain.EditorialProvider, "123")
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, a, deleted)
+		})
+	}
+}
+
+func TestIndexedRepository_Hide(t *testing.T) {
+	a := &domain.Article{ID: "1", ArticleID: "123", Provider: domain.HullCityProvider, Hidden: true}
+
+	tt := []struct {
+		name string
+		stub func(repo *mock.MockRepository, idx *mock.MockIndex)
+		err  bool
+	}{
+		{
+			name: "repository error",
+			stub: func(repo *mock.MockRepository, idx *mock.MockIndex) {
+				repo.EXPECT().Hide(gomock.Any(), "1").Times(1).Return(nil, errors.New("something went wrong"))
+			},
+			err: true,
+		},
+		{
+			name: "removed from the index",
+			stub: func(repo *mock.MockRepository, idx *mock.MockIndex) {
+				repo.EXPECT().Hide(gomock.Any(), "1").Times(1).Return(a, nil)
+				idx.EXPECT().Delete(gomock.Any(), "1").Times(1).Return(nil)
+			},
+		},
+		{
+			name: "index error",
+			stub: func(repo *mock.MockRepository, idx *mock.MockIndex) {
+				repo.EXPECT().Hide(gomock.Any(), "1").Times(1).Return(a, nil)
+				idx.EXPECT().Delete(gomock.Any(), "1").Times(1).Return(errors.New("something went wrong"))
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockRepository(ctrl)
+			idx := mock.NewMockIndex(ctrl)
+
+			tc.stub(repo, idx)
+			r := NewIndexedRepository(getLogger(), repo, idx)
+
+			hidden, err := r.Hide(context.Background(), "1")
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, a, hidden)
+		})
+	}
+}
@@ -35,6 +35,21 @@ func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
 	return m.recorder
 }
 
+// GetByArticleID mocks base method.
+func (m *MockUseCase) GetByArticleID(ctx context.Context, provider, articleID string) (*domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByArticleID", ctx, provider, articleID)
+	ret0, _ := ret[0].(*domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByArticleID indicates an expected call of GetByArticleID.
+func (mr *MockUseCaseMockRecorder) GetByArticleID(ctx, provider, articleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByArticleID", reflect.TypeOf((*MockUseCase)(nil).GetByArticleID), ctx, provider, articleID)
+}
+
 // GetByID mocks base method.
 func (m *MockUseCase) GetByID(ctx context.Context, id string) (*domain.Article, error) {
 	m.ctrl.T.Helper()
@@ -50,17 +65,92 @@ func (mr *MockUseCaseMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUseCase)(nil).GetByID), ctx, id)
 }
 
+// GetByIDs mocks base method.
+func (m *MockUseCase) GetByIDs(ctx context.Context, ids []string) ([]*domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDs", ctx, ids)
+	ret0, _ := ret[0].([]*domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDs indicates an expected call of GetByIDs.
+func (mr *MockUseCaseMockRecorder) GetByIDs(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockUseCase)(nil).GetByIDs), ctx, ids)
+}
+
+// GetBySlug mocks base method.
+func (m *MockUseCase) GetBySlug(ctx context.Context, slug string) (*domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBySlug", ctx, slug)
+	ret0, _ := ret[0].(*domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBySlug indicates an expected call of GetBySlug.
+func (mr *MockUseCaseMockRecorder) GetBySlug(ctx, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySlug", reflect.TypeOf((*MockUseCase)(nil).GetBySlug), ctx, slug)
+}
+
+// Hide mocks base method.
+func (m *MockUseCase) Hide(ctx context.Context, id string) (*domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hide", ctx, id)
+	ret0, _ := ret[0].(*domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Hide indicates an expected call of Hide.
+func (mr *MockUseCaseMockRecorder) Hide(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hide", reflect.TypeOf((*MockUseCase)(nil).Hide), ctx, id)
+}
+
 // List mocks base method.
-func (m *MockUseCase) List(ctx context.Context) (*domain.Articles, error) {
+func (m *MockUseCase) List(ctx context.Context, params domain.ListParams) (*domain.Articles, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", ctx)
+	ret := m.ctrl.Call(m, "List", ctx, params)
 	ret0, _ := ret[0].(*domain.Articles)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // List indicates an expected call of List.
-func (mr *MockUseCaseMockRecorder) List(ctx interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) List(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUseCase)(nil).List), ctx, params)
+}
+
+// PurgeCache mocks base method.
+func (m *MockUseCase) PurgeCache(ctx context.Context) (*domain.PurgeResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeCache", ctx)
+	ret0, _ := ret[0].(*domain.PurgeResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeCache indicates an expected call of PurgeCache.
+func (mr *MockUseCaseMockRecorder) PurgeCache(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeCache", reflect.TypeOf((*MockUseCase)(nil).PurgeCache), ctx)
+}
+
+// Search mocks base method.
+func (m *MockUseCase) Search(ctx context.Context, params domain.SearchParams) (*domain.SearchResults, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, params)
+	ret0, _ := ret[0].(*domain.SearchResults)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockUseCaseMockRecorder) Search(ctx, params interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUseCase)(nil).List), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockUseCase)(nil).Search), ctx, params)
 }
@@ -8,11 +8,26 @@ import (
 
 type Repository interface {
 	GetByID(ctx context.Context, id string) (*domain.Article, error)
-	List(ctx context.Context) (*domain.Articles, error)
-	Upsert(ctx context.Context, article *domain.Article) (*domain.Article, error)
+	// GetByIDs returns the articles with the ids, in no particular order. Unknown ids are left out.
+	GetByIDs(ctx context.Context, ids []string) ([]*domain.Article, error)
+	GetByArticleID(ctx context.Context, provider, articleID string) (*domain.Article, error)
+	GetBySlug(ctx context.Context, slug string) (*domain.Article, error)
+	List(ctx context.Context, params domain.ListParams) (*domain.Articles, error)
+	Search(ctx context.Context, params domain.SearchParams) (*domain.SearchResults, error)
+	Upsert(ctx context.Context, article *domain.Article) (*domain.Article, domain.Change, error)
+	// Delete deletes the article with the id given to it by the provider and returns it.
+	Delete(ctx context.Context, provider, articleID string) (*domain.Article, error)
+	// Hide hides the article with the id from every read and returns it.
+	Hide(ctx context.Context, id string) (*domain.Article, error)
 }
 
 type Cache interface {
 	Get(ctx context.Context, id string) (*domain.Article, error)
 	Set(ctx context.Context, article *domain.Article) error
+	// GetID and SetID map another key of an article, such as its slug, to its id.
+	GetID(ctx context.Context, key string) (string, error)
+	SetID(ctx context.Context, key, id string) error
+	Delete(ctx context.Context, id string) error
+	// Purge deletes every cached article and key and returns how many it deleted.
+	Purge(ctx context.Context) (int64, error)
 }
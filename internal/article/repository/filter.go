@@ -0,0 +1,337 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/KarolosLykos/sportsnews/domain"
+)
+
+// listSort is the default order of the article list. Every filter index ends with it so that
+// pages are read in index order whatever the filter.
+var listSort = bson.D{{Key: "published", Value: -1}, {Key: "_id", Value: -1}}
+
+// legacyArticleIDIndex is the unique index on the article id alone, from before the articles of
+// different providers could share an id. It is replaced by the unique provider and id index.
+const legacyArticleIDIndex = "articleID_1"
+
+// Codes of the server errors that dropping a missing index or collection fails with.
+const (
+	namespaceNotFound = 26
+	indexNotFound     = 27
+)
+
+// sortedFilters are the equality filters that pages in the other sorts are commonly read with. The
+// indexes of the other sorts start with them, so that those pages are read in index order too.
+var sortedFilters = [][]string{nil, {"teamId"}, {"isPublished"}, {"teamId", "isPublished"}}
+
+// articleIndexes support the upsert of the consumer and every filter and sort field of the article list.
+// The planner picks the index of the most selective equality filter and applies the others
+// to the documents it reads; the common team and publication state pair has its own index.
+// The first index is the key of the articles.
+var articleIndexes = append([]mongo.IndexModel{
+	{Keys: bson.D{{Key: "provider", Value: 1}, {Key: "articleID", Value: 1}}, Options: options.Index().SetUnique(true)},
+	{
+		Keys:    bson.D{{Key: "slug", Value: 1}},
+		Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.D{{Key: "slug", Value: bson.D{{Key: "$type", Value: "string"}}}}),
+	},
+	listIndex(),
+	listIndex("teamId"),
+	listIndex("type"),
+	listIndex("isPublished"),
+	listIndex("optaMatchId"),
+	listIndex("videoUrl"),
+	listIndex("teamId", "isPublished"),
+	textIndex,
+}, sortIndexes("updated", "title", "popularity")...)
+
+// textIndex supports the article search. A collection has at most one text index.
+var textIndex = mongo.IndexModel{
+	Keys: bson.D{
+		{Key: "title", Value: "text"},
+		{Key: "subtitle", Value: "text"},
+		{Key: "teaser", Value: "text"},
+		{Key: "content", Value: "text"},
+	},
+	Options: options.Index().SetName("article_text").SetWeights(bson.D{
+		{Key: "title", Value: 10},
+		{Key: "subtitle", Value: 5},
+		{Key: "teaser", Value: 3},
+		{Key: "content", Value: 1},
+	}),
+}
+
+// listIndex returns an index on the equality fields followed by the list order.
+func listIndex(fields ...string) mongo.IndexModel {
+	keys := bson.D{}
+	for _, f := range fields {
+		keys = append(keys, bson.E{Key: f, Value: 1})
+	}
+
+	return mongo.IndexModel{Keys: append(keys, listSort...)}
+}
+
+// sortIndexes returns the indexes of each sort field and the id that breaks its ties, following
+// each of the sortedFilters. Indexes are read in both directions, so they serve ascending and
+// descending sorts.
+func sortIndexes(fields ...string) []mongo.IndexModel {
+	indexes := make([]mongo.IndexModel, 0, len(fields)*len(sortedFilters))
+
+	for _, field := range fields {
+		for _, filter := range sortedFilters {
+			keys := bson.D{}
+			for _, f := range filter {
+				keys = append(keys, bson.E{Key: f, Value: 1})
+			}
+
+			keys = append(keys, bson.E{Key: field, Value: 1}, bson.E{Key: "_id", Value: 1})
+			indexes = append(indexes, mongo.IndexModel{Keys: keys})
+		}
+	}
+
+	return indexes
+}
+
+// EnsureIndexes creates the indexes of the articles collection that do not exist yet. Articles
+// stored before they had a provider or sort fields are given them first, so that they are keyed and
+// listed like the others, and the legacy index on the article id alone is dropped once its
+// replacement exists. Indexes are created one at a time, so that one that cannot be created, such as
+// one conflicting with an existing index, does not keep the others from being created.
+func (m *mongoRepository) EnsureIndexes(ctx context.Context) error {
+	if err := m.migrateProviders(ctx); err != nil {
+		return fmt.Errorf("%w:%v", ErrIndexes, err)
+	}
+
+	if err := m.migrateSortFields(ctx); err != nil {
+		return fmt.Errorf("%w:%v", ErrIndexes, err)
+	}
+
+	var failed int
+	keyed := true
+
+	for i, index := range articleIndexes {
+		if _, err := m.articlesCollection().Indexes().CreateOne(ctx, index); err != nil {
+			m.logger.Warnf(ctx, err, "could not create article index %v", index.Keys)
+			failed++
+
+			// The legacy index is kept until the key of the articles is indexed.
+			if i == 0 {
+				keyed = false
+			}
+		}
+	}
+
+	if keyed {
+		if _, err := m.articlesCollection().Indexes().DropOne(ctx, legacyArticleIDIndex); err != nil && !isNotFound(err) {
+			return fmt.Errorf("%w:%v", ErrIndexes, err)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%w:%d of %d indexes could not be created", ErrIndexes, failed, len(articleIndexes))
+	}
+
+	return nil
+}
+
+// migrateProviders gives the articles stored without a provider the Hull City provider, the only
+// one there was before articles had a provider.
+func (m *mongoRepository) migrateProviders(ctx context.Context) error {
+	filter := bson.D{{Key: "provider", Value: bson.D{{Key: "$in", Value: bson.A{nil, ""}}}}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "provider", Value: domain.HullCityProvider}}}}
+
+	res, err := m.articlesCollection().UpdateMany(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+
+	if res.ModifiedCount > 0 {
+		m.logger.Infof(ctx, "gave %d articles the %s provider", res.ModifiedCount, domain.HullCityProvider)
+	}
+
+	return nil
+}
+
+// migrateSortFields gives the articles stored before they had an update time or a popularity theirs:
+// the update time is the publication time, as the article was not updated since, and the popularity
+// is zero. Keyset pages compare the sort values of the cursor, so articles without them would be
+// left out of every page after the first one.
+func (m *mongoRepository) migrateSortFields(ctx context.Context) error {
+	missing := func(field string) bson.D {
+		return bson.D{{Key: field, Value: bson.D{{Key: "$in", Value: bson.A{nil}}}}}
+	}
+
+	res, err := m.articlesCollection().UpdateMany(ctx, missing("updated"), mongo.Pipeline{
+		{{Key: "$set", Value: bson.D{{Key: "updated", Value: "$published"}}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	if res.ModifiedCount > 0 {
+		m.logger.Infof(ctx, "gave %d articles their publication time as update time", res.ModifiedCount)
+	}
+
+	res, err = m.articlesCollection().UpdateMany(ctx, missing("popularity"), bson.D{
+		{Key: "$set", Value: bson.D{{Key: "popularity", Value: int64(0)}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	if res.ModifiedCount > 0 {
+		m.logger.Infof(ctx, "gave %d articles a popularity of zero", res.ModifiedCount)
+	}
+
+	return nil
+}
+
+// isNotFound tells whether the error is that of dropping an index or collection that does not exist.
+func isNotFound(err error) bool {
+	var cmdErr mongo.CommandError
+	if !errors.As(err, &cmdErr) {
+		return false
+	}
+
+	return cmdErr.Code == namespaceNotFound || cmdErr.Code == indexNotFound
+}
+
+// articleFilter returns the conditions of the filter, empty when it does not filter.
+func articleFilter(f domain.ArticleFilter) bson.D {
+	filter := bson.D{}
+
+	if f.TeamID != "" {
+		filter = append(filter, bson.E{Key: "teamId", Value: f.TeamID})
+	}
+
+	if len(f.Types) > 0 {
+		op := "$in"
+		if f.TypeMatch == domain.TypeMatchAll {
+			op = "$all"
+		}
+
+		filter = append(filter, bson.E{Key: "type", Value: bson.D{{Key: op, Value: f.Types}}})
+	}
+
+	if f.PublishedSince != nil || f.PublishedUntil != nil {
+		published := bson.D{}
+		if f.PublishedSince != nil {
+			published = append(published, bson.E{Key: "$gte", Value: *f.PublishedSince})
+		}
+
+		if f.PublishedUntil != nil {
+			published = append(published, bson.E{Key: "$lt", Value: *f.PublishedUntil})
+		}
+
+		filter = append(filter, bson.E{Key: "published", Value: published})
+	}
+
+	if f.IsPublished != nil {
+		// isPublished is omitted when false.
+		if *f.IsPublished {
+			filter = append(filter, bson.E{Key: "isPublished", Value: true})
+		} else {
+			filter = append(filter, bson.E{Key: "isPublished", Value: bson.D{{Key: "$ne", Value: true}}})
+		}
+	}
+
+	switch {
+	case len(f.OptaMatchIDs) > 0 && f.OptaMatchID != "":
+		filter = append(filter, bson.E{Key: "optaMatchId", Value: bson.D{
+			{Key: "$eq", Value: f.OptaMatchID},
+			{Key: "$in", Value: f.OptaMatchIDs},
+		}})
+	case len(f.OptaMatchIDs) > 0:
+		filter = append(filter, bson.E{Key: "optaMatchId", Value: bson.D{{Key: "$in", Value: f.OptaMatchIDs}}})
+	case f.OptaMatchID != "":
+		filter = append(filter, bson.E{Key: "optaMatchId", Value: f.OptaMatchID})
+	}
+
+	if f.HasVideo != nil {
+		// videoUrl is omitted when empty.
+		if *f.HasVideo {
+			filter = append(filter, bson.E{Key: "videoUrl", Value: bson.D{{Key: "$gt", Value: ""}}})
+		} else {
+			filter = append(filter, bson.E{Key: "videoUrl", Value: bson.D{{Key: "$in", Value: bson.A{nil, ""}}}})
+		}
+	}
+
+	return filter
+}
+
+// sortOrder returns the Mongo sort of the list, reversed to read the page before a cursor.
+func sortOrder(sort domain.Sort, reverse bool) bson.D {
+	order := make(bson.D, 0, len(sort)+1)
+
+	direction := 1
+	for _, f := range sort {
+		direction = 1
+		if f.Desc != reverse {
+			direction = -1
+		}
+
+		order = append(order, bson.E{Key: f.Field, Value: direction})
+	}
+
+	// The id breaks ties in the direction of the last field.
+	return append(order, bson.E{Key: "_id", Value: direction})
+}
+
+// keyset returns the condition that selects the articles after the cursor in the list order,
+// or before it for a Before cursor: the articles that differ from the cursor on a field
+// after being equal to it on the preceding ones.
+func keyset(c *domain.Cursor) (bson.E, error) {
+	id, err := primitive.ObjectIDFromHex(c.ID)
+	if err != nil {
+		return bson.E{}, err
+	}
+
+	order := sortOrder(c.Sort, c.Before)
+	values := append(append(make([]interface{}, 0, len(order)), c.Values...), id)
+	or := make(bson.A, 0, len(order))
+
+	for i, e := range order {
+		op := "$gt"
+		if e.Value == -1 {
+			op = "$lt"
+		}
+
+		cond := make(bson.D, 0, i+1)
+		for j := 0; j < i; j++ {
+			cond = append(cond, bson.E{Key: order[j].Key, Value: values[j]})
+		}
+
+		or = append(or, append(cond, bson.E{Key: e.Key, Value: bson.D{{Key: op, Value: values[i]}}}))
+	}
+
+	return bson.E{Key: "$or", Value: or}, nil
+}
+
+// projection returns the projection of the fields, with the sort fields that the cursors are made of.
+func projection(fields domain.Fields, sort domain.Sort) bson.D {
+	p := bson.D{}
+	seen := make(map[string]bool)
+
+	include := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			p = append(p, bson.E{Key: name, Value: 1})
+		}
+	}
+
+	for _, name := range fields.Stored() {
+		include(name)
+	}
+
+	for _, f := range sort {
+		include(f.Field)
+	}
+
+	return p
+}
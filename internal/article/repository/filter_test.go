@@ -0,0 +1,249 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/KarolosLykos/sportsnews/domain"
+)
+
+func TestArticleFilter(t *testing.T) {
+	yes, no := true, false
+	since := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2023, 3, 8, 0, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		name   string
+		filter domain.ArticleFilter
+		want   bson.D
+	}{
+		{
+			name:   "empty",
+			filter: domain.ArticleFilter{},
+			want:   bson.D{},
+		},
+		{
+			name:   "team and any type",
+			filter: domain.ArticleFilter{TeamID: "Hull City", Types: []string{"Academy", "Video"}},
+			want: bson.D{
+				{Key: "teamId", Value: "Hull City"},
+				{Key: "type", Value: bson.D{{Key: "$in", Value: []string{"Academy", "Video"}}}},
+			},
+		},
+		{
+			name:   "all types",
+			filter: domain.ArticleFilter{Types: []string{"Academy", "Video"}, TypeMatch: domain.TypeMatchAll},
+			want:   bson.D{{Key: "type", Value: bson.D{{Key: "$all", Value: []string{"Academy", "Video"}}}}},
+		},
+		{
+			name:   "published range",
+			filter: domain.ArticleFilter{PublishedSince: &since, PublishedUntil: &until},
+			want: bson.D{{Key: "published", Value: bson.D{
+				{Key: "$gte", Value: since},
+				{Key: "$lt", Value: until},
+			}}},
+		},
+		{
+			name:   "published and video",
+			filter: domain.ArticleFilter{IsPublished: &yes, HasVideo: &yes, OptaMatchID: "g1"},
+			want: bson.D{
+				{Key: "isPublished", Value: true},
+				{Key: "optaMatchId", Value: "g1"},
+				{Key: "videoUrl", Value: bson.D{{Key: "$gt", Value: ""}}},
+			},
+		},
+		{
+			name:   "matches",
+			filter: domain.ArticleFilter{OptaMatchIDs: []string{"g1", "g2"}},
+			want:   bson.D{{Key: "optaMatchId", Value: bson.D{{Key: "$in", Value: []string{"g1", "g2"}}}}},
+		},
+		{
+			name:   "unpublished without video",
+			filter: domain.ArticleFilter{IsPublished: &no, HasVideo: &no},
+			want: bson.D{
+				{Key: "isPublished", Value: bson.D{{Key: "$ne", Value: true}}},
+				{Key: "videoUrl", Value: bson.D{{Key: "$in", Value: bson.A{nil, ""}}}},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, articleFilter(tc.filter))
+		})
+	}
+}
+
+// TestArticleIndexes checks that every field the list filters on leads an index that ends with the list order.
+func TestArticleIndexes(t *testing.T) {
+	yes := true
+	since := time.Now()
+
+	filter := articleFilter(domain.ArticleFilter{
+		TeamID:         "team",
+		Types:          []string{"type"},
+		PublishedSince: &since,
+		IsPublished:    &yes,
+		OptaMatchID:    "match",
+		HasVideo:       &yes,
+	})
+
+	leading := map[string]bool{}
+	for _, index := range articleIndexes {
+		keys, ok := index.Keys.(bson.D)
+		require.True(t, ok)
+
+		if len(keys) >= len(listSort) && assert.ObjectsAreEqual(listSort, keys[len(keys)-len(listSort):]) {
+			leading[keys[0].Key] = true
+		}
+	}
+
+	for _, e := range filter {
+		assert.True(t, leading[e.Key], "no index for %s", e.Key)
+	}
+
+	// The pages of the team, of the published articles and of both are read in index order in
+	// every sort.
+	for _, field := range []string{"updated", "title", "popularity"} {
+		for _, prefix := range []bson.D{
+			{},
+			{{Key: "teamId", Value: 1}},
+			{{Key: "isPublished", Value: 1}},
+			{{Key: "teamId", Value: 1}, {Key: "isPublished", Value: 1}},
+		} {
+			keys := append(prefix, bson.E{Key: field, Value: 1}, bson.E{Key: "_id", Value: 1})
+
+			found := false
+			for _, index := range articleIndexes {
+				found = found || assert.ObjectsAreEqual(keys, index.Keys)
+			}
+
+			assert.True(t, found, "no index for %v", keys)
+		}
+	}
+}
+
+func TestSetCursors(t *testing.T) {
+	published := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+	articles := func() []*domain.Article {
+		return []*domain.Article{
+			{ID: "3", Published: published.Add(3 * time.Hour)},
+			{ID: "2", Published: published.Add(2 * time.Hour)},
+		}
+	}
+
+	tt := []struct {
+		name   string
+		offset int
+		cursor *domain.Cursor
+		more   bool
+		first  string
+		next   bool
+		prev   bool
+	}{
+		{name: "first page", more: true, first: "3", next: true},
+		{name: "only page", first: "3"},
+		{name: "offset page", offset: 2, first: "3", prev: true},
+		{name: "after cursor", cursor: &domain.Cursor{ID: "4"}, more: true, first: "3", next: true, prev: true},
+		{name: "last page after cursor", cursor: &domain.Cursor{ID: "4"}, first: "3", prev: true},
+		{name: "before cursor", cursor: &domain.Cursor{ID: "1", Before: true}, first: "2", next: true},
+		{name: "before cursor with more", cursor: &domain.Cursor{ID: "1", Before: true}, more: true, first: "2", next: true, prev: true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			page := &domain.Articles{Articles: articles(), Offset: tc.offset}
+			params := domain.ListParams{Sort: domain.DefaultSort, Offset: tc.offset, Cursor: tc.cursor}
+
+			setCursors(page, params, tc.more)
+
+			assert.Equal(t, tc.first, page.Articles[0].ID)
+			assert.Equal(t, tc.next, page.Next != nil)
+			assert.Equal(t, tc.prev, page.Prev != nil)
+
+			if page.Prev != nil {
+				assert.True(t, page.Prev.Before)
+				assert.Equal(t, page.Articles[0].ID, page.Prev.ID)
+				assert.Equal(t, []interface{}{page.Articles[0].Published}, page.Prev.Values)
+			}
+		})
+	}
+}
+
+func TestKeyset(t *testing.T) {
+	id := primitive.NewObjectID()
+	published := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		name   string
+		cursor *domain.Cursor
+		order  bson.D
+		want   bson.A
+	}{
+		{
+			name:   "after",
+			cursor: &domain.Cursor{Sort: domain.DefaultSort, Values: []interface{}{published}, ID: id.Hex()},
+			order:  bson.D{{Key: "published", Value: -1}, {Key: "_id", Value: -1}},
+			want: bson.A{
+				bson.D{{Key: "published", Value: bson.D{{Key: "$lt", Value: published}}}},
+				bson.D{{Key: "published", Value: published}, {Key: "_id", Value: bson.D{{Key: "$lt", Value: id}}}},
+			},
+		},
+		{
+			name: "before on two fields",
+			cursor: &domain.Cursor{
+				Sort:   domain.Sort{{Field: "title"}, {Field: "popularity", Desc: true}},
+				Values: []interface{}{"title", int64(3)},
+				ID:     id.Hex(),
+				Before: true,
+			},
+			order: bson.D{{Key: "title", Value: -1}, {Key: "popularity", Value: 1}, {Key: "_id", Value: 1}},
+			want: bson.A{
+				bson.D{{Key: "title", Value: bson.D{{Key: "$lt", Value: "title"}}}},
+				bson.D{{Key: "title", Value: "title"}, {Key: "popularity", Value: bson.D{{Key: "$gt", Value: int64(3)}}}},
+				bson.D{{Key: "title", Value: "title"}, {Key: "popularity", Value: int64(3)}, {Key: "_id", Value: bson.D{{Key: "$gt", Value: id}}}},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.order, sortOrder(tc.cursor.Sort, tc.cursor.Before))
+
+			e, err := keyset(tc.cursor)
+			require.NoError(t, err)
+			assert.Equal(t, "$or", e.Key)
+			assert.Equal(t, tc.want, e.Value)
+		})
+	}
+
+	_, err := keyset(&domain.Cursor{Sort: domain.DefaultSort, ID: "invalid"})
+	assert.Error(t, err)
+}
+
+func TestProjection(t *testing.T) {
+	want := bson.D{
+		{Key: "_id", Value: 1},
+		{Key: "title", Value: 1},
+		{Key: "clubURL", Value: 1},
+		{Key: "popularity", Value: 1},
+	}
+
+	sort := domain.Sort{{Field: "title"}, {Field: "popularity", Desc: true}}
+	assert.Equal(t, want, projection(domain.Fields{"id", "title", "ClubURL"}, sort))
+}
+
+func TestIsNotFound(t *testing.T) {
+	assert.True(t, isNotFound(mongo.CommandError{Code: indexNotFound, Name: "IndexNotFound"}))
+	assert.True(t, isNotFound(fmt.Errorf("drop: %w", mongo.CommandError{Code: namespaceNotFound, Name: "NamespaceNotFound"})))
+	assert.False(t, isNotFound(mongo.CommandError{Code: 11000, Name: "DuplicateKey"}))
+	assert.False(t, isNotFound(errors.New("something went wrong")))
+}
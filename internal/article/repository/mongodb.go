@@ -4,8 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
+	"strings"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
@@ -18,10 +21,20 @@ const (
 	articlesCollection = "articles"
 )
 
+// visible leaves the hidden articles out of a read.
+var visible = bson.E{Key: "hidden", Value: bson.D{{Key: "$ne", Value: true}}}
+
 var (
-	ErrGetByID = errors.New("repository: getByID")
-	ErrList    = errors.New("repository: list")
-	ErrUpsert  = errors.New("repository: upsert")
+	ErrGetByID        = errors.New("repository: getByID")
+	ErrGetByIDs       = errors.New("repository: getByIDs")
+	ErrGetByArticleID = errors.New("repository: getByArticleID")
+	ErrGetBySlug      = errors.New("repository: getBySlug")
+	ErrList           = errors.New("repository: list")
+	ErrUpsert         = errors.New("repository: upsert")
+	ErrDelete         = errors.New("repository: delete")
+	ErrHide           = errors.New("repository: hide")
+	ErrIndexes        = errors.New("repository: indexes")
+	ErrSearch         = errors.New("repository: search")
 )
 
 type mongoRepository struct {
@@ -36,60 +49,348 @@ func NewMongoRepository(client *mongo.Client, logger logger.Logger) *mongoReposi
 	}
 }
 
+// GetByID returns the article with the id. Ids that are not object ids match no article.
 func (m *mongoRepository) GetByID(ctx context.Context, id string) (*domain.Article, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrGetByID, mongo.ErrNoDocuments)
+	}
+
 	article := &domain.Article{}
 
 	opts := options.FindOne()
-	if err := m.articlesCollection().FindOne(ctx, bson.M{"_id": id}, opts).Decode(article); err != nil {
+	if err = m.articlesCollection().FindOne(ctx, bson.D{{Key: "_id", Value: objectID}, visible}, opts).Decode(article); err != nil {
 		return nil, fmt.Errorf("%w:%v", ErrGetByID, err)
 	}
 
 	return article, nil
 }
 
-func (m *mongoRepository) List(ctx context.Context) (*domain.Articles, error) {
-	count, err := m.articlesCollection().CountDocuments(ctx, bson.D{})
+// GetByIDs returns the articles with the ids in a single query. Ids that are not object ids match no article.
+func (m *mongoRepository) GetByIDs(ctx context.Context, ids []string) ([]*domain.Article, error) {
+	objectIDs := make(bson.A, 0, len(ids))
+	for _, id := range ids {
+		if objectID, err := primitive.ObjectIDFromHex(id); err == nil {
+			objectIDs = append(objectIDs, objectID)
+		}
+	}
+
+	articles := make([]*domain.Article, 0, len(objectIDs))
+	if len(objectIDs) == 0 {
+		return articles, nil
+	}
+
+	cursor, err := m.articlesCollection().Find(ctx, bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: objectIDs}}}, visible})
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrGetByIDs, err)
+	}
+
+	if err = cursor.All(ctx, &articles); err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrGetByIDs, err)
+	}
+
+	return articles, nil
+}
+
+// GetByArticleID returns the article with the id given to it by the provider.
+func (m *mongoRepository) GetByArticleID(ctx context.Context, provider, articleID string) (*domain.Article, error) {
+	article := &domain.Article{}
+
+	filter := bson.D{{Key: "provider", Value: provider}, {Key: "articleID", Value: articleID}, visible}
+	if err := m.articlesCollection().FindOne(ctx, filter).Decode(article); err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrGetByArticleID, err)
+	}
+
+	return article, nil
+}
+
+func (m *mongoRepository) GetBySlug(ctx context.Context, slug string) (*domain.Article, error) {
+	article := &domain.Article{}
+
+	if err := m.articlesCollection().FindOne(ctx, bson.D{{Key: "slug", Value: slug}, visible}).Decode(article); err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrGetBySlug, err)
+	}
+
+	return article, nil
+}
+
+// List returns a page of the filtered and sorted articles. Pages are read with a keyset query when
+// params carry a cursor and with skip otherwise. One extra document is read to know whether
+// another page follows. Only the selected fields are read, when params select some.
+func (m *mongoRepository) List(ctx context.Context, params domain.ListParams) (*domain.Articles, error) {
+	filter := append(articleFilter(params.Filter), visible)
+
+	count, err := m.articlesCollection().CountDocuments(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("%w:%v", ErrList, err)
 	}
 
+	page := &domain.Articles{
+		Total:    count,
+		Articles: make([]*domain.Article, 0),
+		Limit:    params.Limit,
+		Offset:   params.Offset,
+	}
+
 	if count == 0 {
-		return &domain.Articles{Articles: make([]*domain.Article, 0), Total: 0}, nil
+		return page, nil
 	}
-	cursor, err := m.articlesCollection().Find(ctx, bson.D{})
+
+	opts := options.Find().
+		SetLimit(int64(params.Limit) + 1).
+		SetSort(sortOrder(params.Sort, params.Cursor != nil && params.Cursor.Before))
+
+	if params.Fields != nil {
+		opts.SetProjection(projection(params.Fields, params.Sort))
+	}
+
+	if c := params.Cursor; c != nil {
+		var after bson.E
+		if after, err = keyset(c); err != nil {
+			return nil, fmt.Errorf("%w:%v", ErrList, err)
+		}
+
+		filter = append(filter, after)
+	} else {
+		opts.SetSkip(int64(params.Offset))
+	}
+
+	cursor, err := m.articlesCollection().Find(ctx, filter, opts)
 	if err != nil {
 		return nil, fmt.Errorf("%w:%v", ErrList, err)
 	}
 
 	defer cursor.Close(ctx)
 
-	articles := make([]*domain.Article, 0)
 	for cursor.Next(ctx) {
 		a := &domain.Article{}
 		if err = cursor.Decode(a); err != nil {
 			return nil, fmt.Errorf("%w:%v", ErrList, err)
 		}
-		articles = append(articles, a)
+		page.Articles = append(page.Articles, a)
 	}
 	if err = cursor.Err(); err != nil {
 		return nil, fmt.Errorf("%w:%v", ErrList, err)
 	}
 
-	return &domain.Articles{Total: count, Articles: articles}, nil
+	more := len(page.Articles) > params.Limit
+	if more {
+		page.Articles = page.Articles[:params.Limit]
+	}
+
+	setCursors(page, params, more)
+
+	return page, nil
+}
+
+// setCursors orders a page read before a cursor newest first and sets the cursors of the
+// neighbouring pages. more tells whether articles follow the page in the read order.
+func setCursors(page *domain.Articles, params domain.ListParams, more bool) {
+	cursor := params.Cursor
+	before := cursor != nil && cursor.Before
+	if before {
+		for i, j := 0, len(page.Articles)-1; i < j; i, j = i+1, j-1 {
+			page.Articles[i], page.Articles[j] = page.Articles[j], page.Articles[i]
+		}
+	}
+
+	if len(page.Articles) == 0 {
+		return
+	}
+
+	first, last := page.Articles[0], page.Articles[len(page.Articles)-1]
+
+	// A page reached through a cursor always has a neighbour on the side it came from.
+	hasNext, hasPrev := more, cursor != nil || page.Offset > 0
+	if before {
+		hasNext, hasPrev = true, more
+	}
+
+	if hasNext {
+		page.Next = domain.NewCursor(params.Sort, last, false)
+	}
+
+	if hasPrev {
+		page.Prev = domain.NewCursor(params.Sort, first, true)
+	}
 }
 
-func (m *mongoRepository) Upsert(ctx context.Context, article *domain.Article) (*domain.Article, error) {
-	filter := bson.D{{Key: "articleID", Value: article.ArticleID}}
-	update := bson.D{{Key: "$set", Value: article}}
-	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+// Search returns a page of the filtered articles matching the text query, by descending relevance.
+func (m *mongoRepository) Search(ctx context.Context, params domain.SearchParams) (*domain.SearchResults, error) {
+	filter := append(articleFilter(params.Filter), visible, bson.E{Key: "$text", Value: bson.D{{Key: "$search", Value: params.Query}}})
+
+	count, err := m.articlesCollection().CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrSearch, err)
+	}
+
+	results := &domain.SearchResults{
+		Total:  count,
+		Hits:   make([]*domain.SearchHit, 0),
+		Limit:  params.Limit,
+		Offset: params.Offset,
+	}
 
-	if err := m.articlesCollection().FindOneAndUpdate(ctx, filter, update, opts).Decode(article); err != nil {
-		return nil, fmt.Errorf("%w:%v", ErrUpsert, err)
+	if count == 0 {
+		return results, nil
+	}
+
+	score := bson.D{{Key: "$meta", Value: "textScore"}}
+	opts := options.Find().
+		SetProjection(bson.D{{Key: "score", Value: score}}).
+		SetSort(append(bson.D{{Key: "score", Value: score}}, listSort...)).
+		SetSkip(int64(params.Offset)).
+		SetLimit(int64(params.Limit))
+
+	cursor, err := m.articlesCollection().Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrSearch, err)
+	}
+
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		hit := &struct {
+			domain.Article `bson:",inline"`
+			Score          float64 `bson:"score"`
+		}{}
+		if err = cursor.Decode(hit); err != nil {
+			return nil, fmt.Errorf("%w:%v", ErrSearch, err)
+		}
+		results.Hits = append(results.Hits, &domain.SearchHit{Article: &hit.Article, Score: hit.Score})
+	}
+	if err = cursor.Err(); err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrSearch, err)
+	}
+
+	return results, nil
+}
+
+// Upsert inserts or updates the article and reports whether it was created, updated or left unchanged.
+// The slug is set when the article is created and kept afterwards, so that links to it do not break
+// when its title changes. Articles stored before they had a provider and a slug are given them.
+func (m *mongoRepository) Upsert(ctx context.Context, article *domain.Article) (*domain.Article, domain.Change, error) {
+	filter := bson.D{
+		{Key: "articleID", Value: article.ArticleID},
+		{Key: "provider", Value: bson.D{{Key: "$in", Value: bson.A{article.Provider, nil}}}},
+	}
+
+	set := *article
+	set.Slug = ""
+
+	update := bson.D{{Key: "$set", Value: &set}}
+	if article.Provider == domain.EditorialProvider {
+		if unset := emptyFields(article); len(unset) > 0 {
+			update = append(update, bson.E{Key: "$unset", Value: unset})
+		}
+	}
+	if article.Slug != "" {
+		update = append(update, bson.E{Key: "$setOnInsert", Value: bson.D{{Key: "slug", Value: article.Slug}}})
+	}
+
+	opts := options.Update().SetUpsert(true)
+
+	res, err := m.articlesCollection().UpdateOne(ctx, filter, update, opts)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w:%v", ErrUpsert, err)
+	}
+
+	change := domain.ChangeUnchanged
+	switch {
+	case res.UpsertedCount > 0:
+		change = domain.ChangeCreated
+	case res.ModifiedCount > 0:
+		change = domain.ChangeUpdated
+	}
+
+	stored := &domain.Article{}
+	if err = m.articlesCollection().FindOne(ctx, filter).Decode(stored); err != nil {
+		return nil, "", fmt.Errorf("%w:%v", ErrUpsert, err)
+	}
+
+	if stored.Slug == "" && article.Slug != "" {
+		if err = m.setSlug(ctx, stored.ID, article.Slug); err != nil {
+			return nil, "", fmt.Errorf("%w:%v", ErrUpsert, err)
+		}
+
+		stored.Slug = article.Slug
+	}
+
+	return stored, change, nil
+}
+
+// keptFields are the fields that are omitted when empty but not cleared by emptyFields: they are
+// not written by editors.
+var keptFields = map[string]bool{"_id": true, "provider": true, "slug": true, "hidden": true}
+
+// emptyFields returns the $unset of the fields of the article that $set omits because they are
+// empty. Editorial articles are written whole, so the fields editors clear are cleared in the
+// database too, while provider syncs keep the fields their provider does not supply.
+func emptyFields(article *domain.Article) bson.D {
+	unset := bson.D{}
+
+	v := reflect.ValueOf(article).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		tag := strings.Split(v.Type().Field(i).Tag.Get("bson"), ",")
+		if len(tag) < 2 || tag[1] != "omitempty" || keptFields[tag[0]] {
+			continue
+		}
+
+		// Empty slices are omitted like nil ones.
+		if f := v.Field(i); !f.IsZero() && (f.Kind() != reflect.Slice || f.Len() > 0) {
+			continue
+		}
+
+		unset = append(unset, bson.E{Key: tag[0], Value: ""})
+	}
+
+	return unset
+}
+
+// Delete deletes the article with the id given to it by the provider and returns it.
+func (m *mongoRepository) Delete(ctx context.Context, provider, articleID string) (*domain.Article, error) {
+	article := &domain.Article{}
+
+	filter := bson.D{{Key: "provider", Value: provider}, {Key: "articleID", Value: articleID}}
+	if err := m.articlesCollection().FindOneAndDelete(ctx, filter).Decode(article); err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrDelete, err)
 	}
 
 	return article, nil
 }
 
+// Hide marks the article hidden and returns it. Upserts leave the mark, since hidden is omitted
+// from the articles they store when false.
+func (m *mongoRepository) Hide(ctx context.Context, id string) (*domain.Article, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrHide, err)
+	}
+
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "hidden", Value: true}}}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	article := &domain.Article{}
+	if err = m.articlesCollection().FindOneAndUpdate(ctx, bson.D{{Key: "_id", Value: objectID}}, update, opts).Decode(article); err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrHide, err)
+	}
+
+	return article, nil
+}
+
+// setSlug sets the slug of an article that has none.
+func (m *mongoRepository) setSlug(ctx context.Context, id, slug string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	filter := bson.D{{Key: "_id", Value: objectID}, {Key: "slug", Value: bson.D{{Key: "$exists", Value: false}}}}
+	_, err = m.articlesCollection().UpdateOne(ctx, filter, bson.D{{Key: "$set", Value: bson.D{{Key: "slug", Value: slug}}}})
+
+	return err
+}
+
 func (m *mongoRepository) articlesCollection() *mongo.Collection {
 	return m.client.Database(sportsNewsDB).Collection(articlesCollection)
 }
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+
+	"github.com/KarolosLykos/sportsnews/domain"
+)
+
+func TestMongoRepository_GetByID(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("object id", func(mt *mtest.T) {
+		id := primitive.NewObjectID()
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "sportsnews.articles", mtest.FirstBatch, bson.D{
+			{Key: "_id", Value: id},
+			{Key: "articleID", Value: "123"},
+		}))
+
+		a, err := NewMongoRepository(mt.Client, nil).GetByID(context.Background(), id.Hex())
+		require.NoError(t, err)
+		assert.Equal(t, "123", a.ArticleID)
+
+		filter := mt.GetStartedEvent().Command.Lookup("filter").Document()
+		assert.Equal(t, id, filter.Lookup("_id").ObjectID())
+	})
+
+	mt.Run("malformed id", func(mt *mtest.T) {
+		_, err := NewMongoRepository(mt.Client, nil).GetByID(context.Background(), "not-an-id")
+		assert.ErrorIs(t, err, ErrGetByID)
+		assert.Contains(t, err.Error(), mongo.ErrNoDocuments.Error())
+		assert.Nil(t, mt.GetStartedEvent())
+	})
+}
+
+func TestMongoRepository_Upsert(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	published := time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		name    string
+		article *domain.Article
+		unset   []string
+	}{
+		{
+			name: "editorial draft cleared by its editor",
+			article: &domain.Article{
+				ArticleID: "a1b2c3d4e5f6", Provider: domain.EditorialProvider, Slug: "tigers-sign-a-striker-a1b2c3d4e5f6",
+				TeamID: "Hull City", Title: "Tigers sign a striker", Content: "<p>...</p>", Type: []string{},
+				Published: published, Updated: published,
+			},
+			unset: []string{
+				"clubURL", "optaMatchId", "type", "teaser", "url", "imageUrl", "galleryUrls", "videoUrl", "bodyText",
+				"subtitle", "isPublished",
+			},
+		},
+		{
+			name: "provider article",
+			article: &domain.Article{
+				ArticleID: "123", Provider: domain.HullCityProvider, TeamID: "Hull City", Title: "Tigers win the derby",
+				Published: published, Updated: published,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		mt.Run(tc.name, func(mt *mtest.T) {
+			mt.AddMockResponses(
+				mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}),
+				mtest.CreateCursorResponse(0, "sportsnews.articles", mtest.FirstBatch, bson.D{
+					{Key: "_id", Value: "6405f896a019b8815f6892c7"},
+					{Key: "slug", Value: "tigers"},
+				}),
+			)
+
+			_, change, err := NewMongoRepository(mt.Client, nil).Upsert(context.Background(), tc.article)
+			require.NoError(t, err)
+			assert.Equal(t, domain.ChangeUpdated, change)
+
+			started := mt.GetStartedEvent()
+			require.NotNil(t, started)
+			require.Equal(t, "update", started.CommandName)
+
+			update := started.Command.Lookup("updates").Array().Index(0).Value().Document().Lookup("u").Document()
+
+			set := update.Lookup("$set").Document()
+			_, err = set.LookupErr("isPublished")
+			assert.Error(t, err, "isPublished is not set when false")
+
+			unset, ok := update.Lookup("$unset").DocumentOK()
+			if tc.unset == nil {
+				assert.False(t, ok)
+				return
+			}
+
+			require.True(t, ok)
+
+			elems, err := unset.Elements()
+			require.NoError(t, err)
+
+			keys := make([]string, 0, len(elems))
+			for _, e := range elems {
+				keys = append(keys, e.Key())
+			}
+
+			assert.Equal(t, tc.unset, keys)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/go-redis/redis/v8"
 
@@ -18,8 +19,17 @@ var (
 	ErrUnMarshal = errors.New("cache: couldn't unmarshal")
 	ErrSet       = errors.New("cache: couldn't set value")
 	ErrGet       = errors.New("cache: couldn't get value")
+	ErrDel       = errors.New("cache: couldn't delete value")
+	ErrPurge     = errors.New("cache: couldn't purge")
 )
 
+// purgeBatch is the number of keys scanned and deleted at a time when the cache is purged.
+const purgeBatch = 500
+
+// cachedPatterns match the keys of the cached articles, by their object id, and of the other keys
+// mapped to them. The other keys under the prefix, of the API keys and the events, do not match.
+var cachedPatterns = []string{strings.Repeat("[0-9a-f]", 24), "slug:*", "article:*"}
+
 type Cache struct {
 	cfg    *config.Config
 	logger logger.Logger
@@ -58,6 +68,71 @@ func (c Cache) Set(ctx context.Context, article *domain.Article) error {
 	return nil
 }
 
+func (c Cache) GetID(ctx context.Context, key string) (string, error) {
+	id, err := c.client.Get(ctx, getKey(c.cfg.Redis.KeyPrefix, key)).Result()
+	if err != nil {
+		return "", fmt.Errorf("%w:%v", ErrGet, err)
+	}
+
+	return id, nil
+}
+
+func (c Cache) SetID(ctx context.Context, key, id string) error {
+	if err := c.client.Set(ctx, getKey(c.cfg.Redis.KeyPrefix, key), id, c.cfg.Redis.Expiration).Err(); err != nil {
+		return fmt.Errorf("%w:%v", ErrSet, err)
+	}
+
+	return nil
+}
+
+func (c Cache) Delete(ctx context.Context, id string) error {
+	if err := c.client.Del(ctx, getKey(c.cfg.Redis.KeyPrefix, id)).Err(); err != nil {
+		return fmt.Errorf("%w:%v", ErrDel, err)
+	}
+
+	return nil
+}
+
+// Purge scans the cached keys a batch at a time and deletes them.
+func (c Cache) Purge(ctx context.Context) (int64, error) {
+	var purged int64
+
+	for _, pattern := range cachedPatterns {
+		keys := make([]string, 0, purgeBatch)
+
+		iter := c.client.Scan(ctx, 0, getKey(c.cfg.Redis.KeyPrefix, pattern), purgeBatch).Iterator()
+		for iter.Next(ctx) {
+			keys = append(keys, iter.Val())
+			if len(keys) < purgeBatch {
+				continue
+			}
+
+			n, err := c.client.Del(ctx, keys...).Result()
+			if err != nil {
+				return purged, fmt.Errorf("%w:%v", ErrPurge, err)
+			}
+
+			purged += n
+			keys = keys[:0]
+		}
+
+		if err := iter.Err(); err != nil {
+			return purged, fmt.Errorf("%w:%v", ErrPurge, err)
+		}
+
+		if len(keys) > 0 {
+			n, err := c.client.Del(ctx, keys...).Result()
+			if err != nil {
+				return purged, fmt.Errorf("%w:%v", ErrPurge, err)
+			}
+
+			purged += n
+		}
+	}
+
+	return purged, nil
+}
+
 func getKey(prefix, id string) string {
 	return fmt.Sprintf("%s:%s", prefix, id)
 }
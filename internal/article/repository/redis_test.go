@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/sportsnews/config"
+)
+
+func TestCache_Purge(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+
+	cfg := &config.Config{Redis: config.RedisConfig{KeyPrefix: "articles"}}
+
+	cached := []string{
+		"articles:6405f896a019b8815f6892c7",
+		"articles:slug:tigers-sign-a-striker-123",
+		"articles:article:hullcity:123",
+	}
+	kept := []string{
+		"articles:apikeys:1",
+		"articles:events:seq",
+		"other:6405f896a019b8815f6892c7",
+	}
+
+	for _, key := range append(cached, kept...) {
+		require.NoError(t, mr.Set(key, "1"))
+	}
+
+	purged, err := NewCacheRepository(cfg, nil, client).Purge(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(cached)), purged)
+
+	for _, key := range cached {
+		assert.False(t, mr.Exists(key), key)
+	}
+
+	for _, key := range kept {
+		assert.True(t, mr.Exists(key), key)
+	}
+}
@@ -8,5 +8,14 @@ import (
 
 type UseCase interface {
 	GetByID(ctx context.Context, id string) (*domain.Article, error)
-	List(ctx context.Context) (*domain.Articles, error)
+	// GetByIDs returns the articles with the ids, in the order of the ids. Unknown ids are left out.
+	GetByIDs(ctx context.Context, ids []string) ([]*domain.Article, error)
+	GetByArticleID(ctx context.Context, provider, articleID string) (*domain.Article, error)
+	GetBySlug(ctx context.Context, slug string) (*domain.Article, error)
+	List(ctx context.Context, params domain.ListParams) (*domain.Articles, error)
+	Search(ctx context.Context, params domain.SearchParams) (*domain.SearchResults, error)
+	// Hide hides the article from every read, on behalf of an operator.
+	Hide(ctx context.Context, id string) (*domain.Article, error)
+	// PurgeCache empties the article cache, on behalf of an operator.
+	PurgeCache(ctx context.Context) (*domain.PurgeResult, error)
 }
@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/KarolosLykos/sportsnews/domain"
+)
+
+const (
+	// snippetLength is the number of characters around the first match of a long field.
+	snippetLength = 160
+	// minTermLength drops stop words like "a" that the text index ignores anyway.
+	minTermLength = 2
+)
+
+var tags = regexp.MustCompile(`<[^>]*>`)
+
+// highlight returns the fragments of the searchable fields of the article that contain
+// a term of the query, with the matching words wrapped in <mark> tags.
+func highlight(a *domain.Article, query string) map[string][]string {
+	terms := queryTerms(query)
+	if len(terms) == 0 {
+		return nil
+	}
+
+	fields := []struct {
+		name  string
+		value string
+	}{
+		{"title", a.Title},
+		{"subtitle", a.Subtitle},
+		{"teaser", a.Teaser},
+		{"content", a.Content},
+	}
+
+	highlights := make(map[string][]string)
+
+	for _, f := range fields {
+		text := strings.Join(strings.Fields(html.UnescapeString(tags.ReplaceAllString(f.value, " "))), " ")
+		if fragment, ok := mark(text, terms); ok {
+			highlights[f.name] = []string{fragment}
+		}
+	}
+
+	if len(highlights) == 0 {
+		return nil
+	}
+
+	return highlights
+}
+
+// queryTerms returns the lowercase terms of a text search, leaving out negated terms.
+func queryTerms(query string) []string {
+	terms := make([]string, 0)
+
+	for _, word := range strings.Fields(strings.ToLower(query)) {
+		if strings.HasPrefix(word, "-") {
+			continue
+		}
+
+		word = strings.TrimFunc(word, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsNumber(r) })
+		if len([]rune(word)) >= minTermLength {
+			terms = append(terms, word)
+		}
+	}
+
+	return terms
+}
+
+// matches reports whether the word matches a term. The text index stems words,
+// so a word matches a term it starts with, or that starts with it.
+func matches(word string, terms []string) bool {
+	word = strings.ToLower(word)
+
+	for _, term := range terms {
+		if strings.HasPrefix(word, term) || len(word) >= minTermLength+1 && strings.HasPrefix(term, word) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// mark returns the fragment of text around its first matching word, with every matching word
+// marked. It reports false when no word matches.
+func mark(text string, terms []string) (string, bool) {
+	words := strings.Fields(text)
+
+	first := -1
+	for i, w := range words {
+		if matches(strings.TrimFunc(w, unicode.IsPunct), terms) {
+			first = i
+			break
+		}
+	}
+
+	if first < 0 {
+		return "", false
+	}
+
+	// Go back a few words so that the match has some context.
+	start := first
+	for length := 0; start > 0 && length < snippetLength/4; start-- {
+		length += len(words[start-1]) + 1
+	}
+
+	var b strings.Builder
+
+	if start > 0 {
+		b.WriteString("… ")
+	}
+
+	end := start
+	for length := 0; end < len(words) && length < snippetLength; end++ {
+		if end > start {
+			b.WriteString(" ")
+		}
+
+		w := words[end]
+		length += len(w) + 1
+
+		if trimmed := strings.TrimFunc(w, unicode.IsPunct); trimmed != "" && matches(trimmed, terms) {
+			i := strings.Index(w, trimmed)
+			b.WriteString(html.EscapeString(w[:i]) + "<mark>" + html.EscapeString(trimmed) + "</mark>" + html.EscapeString(w[i+len(trimmed):]))
+
+			continue
+		}
+
+		b.WriteString(html.EscapeString(w))
+	}
+
+	if end < len(words) {
+		b.WriteString(" …")
+	}
+
+	return b.String(), true
+}
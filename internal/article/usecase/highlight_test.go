@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/KarolosLykos/sportsnews/domain"
+)
+
+func TestHighlight(t *testing.T) {
+	long := strings.Repeat("filler words here ", 20)
+
+	tt := []struct {
+		name    string
+		article *domain.Article
+		query   string
+		want    map[string][]string
+	}{
+		{
+			name:    "no match",
+			article: &domain.Article{Title: "Tigers draw"},
+			query:   "derby",
+			want:    nil,
+		},
+		{
+			name:    "stemmed and punctuated",
+			article: &domain.Article{Title: "Goal! Tigers score late", Subtitle: "Goals galore."},
+			query:   "goals",
+			want: map[string][]string{
+				"title":    {"<mark>Goal</mark>! Tigers score late"},
+				"subtitle": {"<mark>Goals</mark> galore."},
+			},
+		},
+		{
+			name:    "negated and short terms are ignored",
+			article: &domain.Article{Title: "A win in the derby"},
+			query:   "a derby -win",
+			want:    map[string][]string{"title": {"A win in the <mark>derby</mark>"}},
+		},
+		{
+			name:    "html is stripped and escaped",
+			article: &domain.Article{Content: "<p>Hull &amp; <b>Sunderland</b> meet</p><p>Fish & chips</p>"},
+			query:   "sunderland chips",
+			want:    map[string][]string{"content": {"Hull &amp; <mark>Sunderland</mark> meet Fish &amp; <mark>chips</mark>"}},
+		},
+		{
+			name:    "long fields are cut around the match",
+			article: &domain.Article{Content: long + "the derby " + long},
+			query:   "derby",
+			want: map[string][]string{"content": {
+				"… filler words here filler words here the <mark>derby</mark> filler words here filler words here " +
+					"filler words here filler words here filler words here filler words here filler …",
+			}},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, highlight(tc.article, tc.query))
+		})
+	}
+}
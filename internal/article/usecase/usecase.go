@@ -8,11 +8,18 @@ import (
 	"github.com/KarolosLykos/sportsnews/domain"
 	"github.com/KarolosLykos/sportsnews/internal/article"
 	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+	"github.com/KarolosLykos/sportsnews/internal/utils/metrics"
 )
 
 var (
-	ErrGetByID = errors.New("usecase: getByID")
-	ErrList    = errors.New("usecase: list")
+	ErrGetByID        = errors.New("usecase: getByID")
+	ErrGetByIDs       = errors.New("usecase: getByIDs")
+	ErrGetByArticleID = errors.New("usecase: getByArticleID")
+	ErrGetBySlug      = errors.New("usecase: getBySlug")
+	ErrList           = errors.New("usecase: list")
+	ErrSearch         = errors.New("usecase: search")
+	ErrHide           = errors.New("usecase: hide")
+	ErrPurgeCache     = errors.New("usecase: purgeCache")
 )
 
 type articleUseCase struct {
@@ -30,18 +37,32 @@ func New(logger logger.Logger, repository article.Repository, cache article.Cach
 }
 
 func (u *articleUseCase) GetByID(ctx context.Context, id string) (*domain.Article, error) {
+	art, err := u.getByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrGetByID, err)
+	}
+
+	return art, nil
+}
+
+// getByID returns the cached article with the id, or reads it from the repository and caches it.
+// It records the result of its cache lookup, which is the only one of the request.
+func (u *articleUseCase) getByID(ctx context.Context, id string) (*domain.Article, error) {
 	cached, err := u.cache.Get(ctx, id)
 	if err != nil {
 		u.logger.Warnf(ctx, err, "could not get cached article with id: %s", id)
 	}
 
 	if cached != nil {
+		metrics.CacheHit()
 		return cached, nil
 	}
 
+	metrics.CacheMiss()
+
 	art, err := u.repository.GetByID(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("%w:%v", ErrGetByID, err)
+		return nil, err
 	}
 
 	if err = u.cache.Set(ctx, art); err != nil {
@@ -51,11 +72,167 @@ func (u *articleUseCase) GetByID(ctx context.Context, id string) (*domain.Articl
 	return art, nil
 }
 
-func (u *articleUseCase) List(ctx context.Context) (*domain.Articles, error) {
-	articles, err := u.repository.List(ctx)
+// GetByIDs returns the cached articles and reads the others from the repository at once, caching them.
+func (u *articleUseCase) GetByIDs(ctx context.Context, ids []string) ([]*domain.Article, error) {
+	found := make(map[string]*domain.Article, len(ids))
+	missing := make([]string, 0, len(ids))
+
+	for _, id := range ids {
+		cached, err := u.cache.Get(ctx, id)
+		if err != nil {
+			u.logger.Warnf(ctx, err, "could not get cached article with id: %s", id)
+		}
+
+		if cached != nil {
+			metrics.CacheHit()
+			found[id] = cached
+
+			continue
+		}
+
+		metrics.CacheMiss()
+		missing = append(missing, id)
+	}
+
+	if len(missing) > 0 {
+		articles, err := u.repository.GetByIDs(ctx, missing)
+		if err != nil {
+			return nil, fmt.Errorf("%w:%v", ErrGetByIDs, err)
+		}
+
+		for _, art := range articles {
+			found[art.ID] = art
+
+			if err = u.cache.Set(ctx, art); err != nil {
+				u.logger.Warnf(ctx, err, "could not set article with id: %s", art.ID)
+			}
+		}
+	}
+
+	articles := make([]*domain.Article, 0, len(found))
+	for _, id := range ids {
+		if art, ok := found[id]; ok {
+			articles = append(articles, art)
+		}
+	}
+
+	return articles, nil
+}
+
+// GetByArticleID returns the article with the id given to it by the provider.
+func (u *articleUseCase) GetByArticleID(ctx context.Context, provider, articleID string) (*domain.Article, error) {
+	key := fmt.Sprintf("article:%s:%s", provider, articleID)
+
+	art, err := u.getByKey(ctx, key, func() (*domain.Article, error) {
+		return u.repository.GetByArticleID(ctx, provider, articleID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrGetByArticleID, err)
+	}
+
+	return art, nil
+}
+
+func (u *articleUseCase) GetBySlug(ctx context.Context, slug string) (*domain.Article, error) {
+	art, err := u.getByKey(ctx, "slug:"+slug, func() (*domain.Article, error) {
+		return u.repository.GetBySlug(ctx, slug)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrGetBySlug, err)
+	}
+
+	return art, nil
+}
+
+// getByKey returns the article another key maps to. The cache maps the key to the id of the article,
+// which never changes, and the article is then read like GetByID does, so that it is always as fresh
+// as the article cached by id.
+func (u *articleUseCase) getByKey(
+	ctx context.Context,
+	key string,
+	get func() (*domain.Article, error),
+) (*domain.Article, error) {
+	id, err := u.cache.GetID(ctx, key)
+	if err != nil {
+		u.logger.Warnf(ctx, err, "could not get cached id of key: %s", key)
+	}
+
+	// A lookup records a single cache result: that of the article when the key is cached, a miss
+	// otherwise.
+	if id != "" {
+		return u.getByID(ctx, id)
+	}
+
+	metrics.CacheMiss()
+
+	art, err := get()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = u.cache.SetID(ctx, key, art.ID); err != nil {
+		u.logger.Warnf(ctx, err, "could not set cached id of key: %s", key)
+	}
+
+	if err = u.cache.Set(ctx, art); err != nil {
+		u.logger.Warnf(ctx, err, "could not set article with id: %s", art.ID)
+	}
+
+	return art, nil
+}
+
+// List returns a page of articles. The page size defaults to domain.DefaultPageSize
+// and is capped to domain.MaxPageSize.
+func (u *articleUseCase) List(ctx context.Context, params domain.ListParams) (*domain.Articles, error) {
+	articles, err := u.repository.List(ctx, params.Normalize())
 	if err != nil {
 		return nil, fmt.Errorf("%w:%v", ErrList, err)
 	}
 
 	return articles, nil
 }
+
+// Search returns a page of the articles matching the query, with the fragments that match highlighted
+// unless the search engine already did.
+func (u *articleUseCase) Search(ctx context.Context, params domain.SearchParams) (*domain.SearchResults, error) {
+	params = params.Normalize()
+
+	results, err := u.repository.Search(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrSearch, err)
+	}
+
+	for _, hit := range results.Hits {
+		if hit.Highlights == nil {
+			hit.Highlights = highlight(hit.Article, params.Query)
+		}
+	}
+
+	return results, nil
+}
+
+// Hide hides the article and removes it from the cache. The keys mapped to it are left to expire,
+// the article they lead to is no longer found. A cache failure is logged only.
+func (u *articleUseCase) Hide(ctx context.Context, id string) (*domain.Article, error) {
+	art, err := u.repository.Hide(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrHide, err)
+	}
+
+	if err = u.cache.Delete(ctx, art.ID); err != nil {
+		u.logger.Warnf(ctx, err, "could not delete cached article with id: %s", art.ID)
+	}
+
+	return art, nil
+}
+
+func (u *articleUseCase) PurgeCache(ctx context.Context) (*domain.PurgeResult, error) {
+	purged, err := u.cache.Purge(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrPurgeCache, err)
+	}
+
+	u.logger.Infof(ctx, "purged %d cached keys", purged)
+
+	return &domain.PurgeResult{Purged: purged}, nil
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"errors"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/golang/mock/gomock"
+	"github.com/prometheus/common/expfmt"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -17,6 +20,7 @@ import (
 	"github.com/KarolosLykos/sportsnews/domain"
 	mock "github.com/KarolosLykos/sportsnews/internal/article/mock"
 	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+	"github.com/KarolosLykos/sportsnews/internal/utils/metrics"
 )
 
 func TestArticleUseCase_GetByID(t *testing.T) {
@@ -105,6 +109,204 @@ func TestArticleUseCase_GetByID(t *testing.T) {
 	}
 }
 
+func TestArticleUseCase_GetByIDs(t *testing.T) {
+	first := &domain.Article{ID: "6405f896a019b8815f6892c7", Title: "first"}
+	second := &domain.Article{ID: "6405f896a019b8815f6892c8", Title: "second"}
+	third := &domain.Article{ID: "6405f896a019b8815f6892c9", Title: "third"}
+	ids := []string{first.ID, third.ID, second.ID}
+
+	tt := []struct {
+		name      string
+		repoStub  func(repo *mock.MockRepository)
+		cacheStub func(cache *mock.MockCache)
+		want      []*domain.Article
+		err       error
+	}{
+		{
+			name: "ok partly cached",
+			repoStub: func(repo *mock.MockRepository) {
+				repo.EXPECT().GetByIDs(gomock.Any(), []string{third.ID, second.ID}).Times(1).
+					Return([]*domain.Article{second}, nil)
+			},
+			cacheStub: func(cache *mock.MockCache) {
+				cache.EXPECT().Get(gomock.Any(), first.ID).Times(1).Return(first, nil)
+				cache.EXPECT().Get(gomock.Any(), gomock.Any()).Times(2).Return(nil, redis.Nil)
+				cache.EXPECT().Set(gomock.Any(), second).Times(1).Return(nil)
+			},
+			want: []*domain.Article{first, second},
+		},
+		{
+			name: "ok all cached",
+			repoStub: func(repo *mock.MockRepository) {
+				repo.EXPECT().GetByIDs(gomock.Any(), gomock.Any()).Times(0)
+			},
+			cacheStub: func(cache *mock.MockCache) {
+				cache.EXPECT().Get(gomock.Any(), first.ID).Times(1).Return(first, nil)
+				cache.EXPECT().Get(gomock.Any(), third.ID).Times(1).Return(third, nil)
+				cache.EXPECT().Get(gomock.Any(), second.ID).Times(1).Return(second, nil)
+			},
+			want: []*domain.Article{first, third, second},
+		},
+		{
+			name: "repository error",
+			repoStub: func(repo *mock.MockRepository) {
+				repo.EXPECT().GetByIDs(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("generic error"))
+			},
+			cacheStub: func(cache *mock.MockCache) {
+				cache.EXPECT().Get(gomock.Any(), gomock.Any()).Times(3).Return(nil, redis.Nil)
+			},
+			err: ErrGetByIDs,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockRepository(ctrl)
+			cache := mock.NewMockCache(ctrl)
+
+			tc.repoStub(repo)
+			tc.cacheStub(cache)
+
+			uc := New(getLogger(), repo, cache)
+
+			articles, err := uc.GetByIDs(context.Background(), ids)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, articles)
+		})
+	}
+}
+
+func TestArticleUseCase_GetBySlug(t *testing.T) {
+	log := getLogger()
+
+	testArticle := &domain.Article{
+		ID:        "6405f896a019b8815f6892c7",
+		ArticleID: "123",
+		Provider:  domain.HullCityProvider,
+		Slug:      "hall-really-happy-with-our-team-performance-123",
+		Title:     "Hall: ‘Really happy with our team performance’",
+	}
+
+	tt := []struct {
+		name      string
+		repoStub  func(repo *mock.MockRepository)
+		cacheStub func(repo *mock.MockCache)
+		hit, miss float64
+		err       error
+	}{
+		{
+			name: "ok not cached",
+			repoStub: func(repo *mock.MockRepository) {
+				repo.EXPECT().GetBySlug(gomock.Any(), testArticle.Slug).Times(1).Return(testArticle, nil)
+			},
+			cacheStub: func(cache *mock.MockCache) {
+				cache.EXPECT().GetID(gomock.Any(), "slug:"+testArticle.Slug).Times(1).Return("", redis.Nil)
+				cache.EXPECT().SetID(gomock.Any(), "slug:"+testArticle.Slug, testArticle.ID).Times(1).Return(nil)
+				cache.EXPECT().Set(gomock.Any(), testArticle).Times(1).Return(nil)
+			},
+			miss: 1,
+		},
+		{
+			name: "ok id cached",
+			repoStub: func(repo *mock.MockRepository) {
+				repo.EXPECT().GetBySlug(gomock.Any(), gomock.Any()).Times(0)
+				repo.EXPECT().GetByID(gomock.Any(), testArticle.ID).Times(1).Return(testArticle, nil)
+			},
+			cacheStub: func(cache *mock.MockCache) {
+				cache.EXPECT().GetID(gomock.Any(), "slug:"+testArticle.Slug).Times(1).Return(testArticle.ID, nil)
+				cache.EXPECT().Get(gomock.Any(), testArticle.ID).Times(1).Return(nil, redis.Nil)
+				cache.EXPECT().Set(gomock.Any(), testArticle).Times(1).Return(nil)
+			},
+			miss: 1,
+		},
+		{
+			name: "ok cached",
+			repoStub: func(repo *mock.MockRepository) {
+				repo.EXPECT().GetBySlug(gomock.Any(), gomock.Any()).Times(0)
+				repo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Times(0)
+			},
+			cacheStub: func(cache *mock.MockCache) {
+				cache.EXPECT().GetID(gomock.Any(), "slug:"+testArticle.Slug).Times(1).Return(testArticle.ID, nil)
+				cache.EXPECT().Get(gomock.Any(), testArticle.ID).Times(1).Return(testArticle, nil)
+			},
+			hit: 1,
+		},
+		{
+			name: "not found",
+			repoStub: func(repo *mock.MockRepository) {
+				repo.EXPECT().GetBySlug(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("no documents in result"))
+			},
+			cacheStub: func(cache *mock.MockCache) {
+				cache.EXPECT().GetID(gomock.Any(), gomock.Any()).Times(1).Return("", redis.Nil)
+				cache.EXPECT().SetID(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			miss: 1,
+			err:  ErrGetBySlug,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockRepository(ctrl)
+			cache := mock.NewMockCache(ctrl)
+
+			tc.repoStub(repo)
+			tc.cacheStub(cache)
+
+			uc := New(log, repo, cache)
+
+			hit, miss := cacheRequests(t)
+
+			a, err := uc.GetBySlug(context.Background(), testArticle.Slug)
+
+			// Each lookup records a single cache result.
+			afterHit, afterMiss := cacheRequests(t)
+			assert.Equal(t, tc.hit, afterHit-hit)
+			assert.Equal(t, tc.miss, afterMiss-miss)
+
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testArticle, a)
+		})
+	}
+}
+
+func TestArticleUseCase_GetByArticleID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testArticle := &domain.Article{ID: "6405f896a019b8815f6892c7", ArticleID: "123", Provider: domain.HullCityProvider}
+
+	repo := mock.NewMockRepository(ctrl)
+	repo.EXPECT().GetByArticleID(gomock.Any(), domain.HullCityProvider, "123").Times(1).Return(testArticle, nil)
+
+	cache := mock.NewMockCache(ctrl)
+	cache.EXPECT().GetID(gomock.Any(), "article:hullcity:123").Times(1).Return("", redis.Nil)
+	cache.EXPECT().SetID(gomock.Any(), "article:hullcity:123", testArticle.ID).Times(1).Return(nil)
+	cache.EXPECT().Set(gomock.Any(), testArticle).Times(1).Return(nil)
+
+	uc := New(getLogger(), repo, cache)
+
+	a, err := uc.GetByArticleID(context.Background(), domain.HullCityProvider, "123")
+	require.NoError(t, err)
+	assert.Equal(t, testArticle, a)
+}
+
 func TestArticleUseCase_List(t *testing.T) {
 	log := getLogger()
 
@@ -126,22 +328,43 @@ func TestArticleUseCase_List(t *testing.T) {
 		},
 	}
 
+	sort := domain.Sort{{Field: "title"}}
+	cursor := &domain.Cursor{Sort: sort, Values: []interface{}{"title"}, ID: "6405f896a019b8815f6892c7"}
+
 	tt := []struct {
 		name     string
+		params   domain.ListParams
 		repoStub func(repo *mock.MockRepository)
 		err      error
 	}{
 		{
-			name: "ok",
+			name:   "ok",
+			params: domain.ListParams{Limit: 10, Offset: 5},
+			repoStub: func(repo *mock.MockRepository) {
+				repo.EXPECT().List(gomock.Any(), domain.ListParams{Sort: domain.DefaultSort, Limit: 10, Offset: 5}).Times(1).Return(testArticles, nil)
+			},
+			err: nil,
+		},
+		{
+			name:   "default page size",
+			params: domain.ListParams{},
 			repoStub: func(repo *mock.MockRepository) {
-				repo.EXPECT().List(gomock.Any()).Times(1).Return(testArticles, nil)
+				repo.EXPECT().List(gomock.Any(), domain.ListParams{Sort: domain.DefaultSort, Limit: domain.DefaultPageSize}).Times(1).Return(testArticles, nil)
+			},
+			err: nil,
+		},
+		{
+			name:   "max page size",
+			params: domain.ListParams{Sort: domain.DefaultSort, Limit: 1000, Offset: 3, Cursor: cursor},
+			repoStub: func(repo *mock.MockRepository) {
+				repo.EXPECT().List(gomock.Any(), domain.ListParams{Sort: sort, Limit: domain.MaxPageSize, Cursor: cursor}).Times(1).Return(testArticles, nil)
 			},
 			err: nil,
 		},
 		{
 			name: "generic err",
 			repoStub: func(repo *mock.MockRepository) {
-				repo.EXPECT().List(gomock.Any()).Times(1).Return(nil, errors.New("generic error"))
+				repo.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("generic error"))
 			},
 			err: ErrList,
 		},
@@ -159,7 +382,7 @@ func TestArticleUseCase_List(t *testing.T) {
 
 			uc := New(log, repo, cache)
 
-			a, err := uc.List(context.Background())
+			a, err := uc.List(context.Background(), tc.params)
 			if err != nil && tc.err != nil {
 				assert.ErrorContains(t, err, tc.err.Error())
 			} else {
@@ -171,6 +394,162 @@ func TestArticleUseCase_List(t *testing.T) {
 	}
 }
 
+func TestArticleUseCase_Search(t *testing.T) {
+	log := getLogger()
+
+	tt := []struct {
+		name     string
+		params   domain.SearchParams
+		repoStub func(repo *mock.MockRepository)
+		err      error
+	}{
+		{
+			name:   "ok",
+			params: domain.SearchParams{Query: " derby win ", Limit: 500},
+			repoStub: func(repo *mock.MockRepository) {
+				repo.EXPECT().Search(gomock.Any(), domain.SearchParams{Query: "derby win", Limit: domain.MaxPageSize}).Times(1).
+					Return(&domain.SearchResults{Total: 1, Hits: []*domain.SearchHit{{
+						Article: &domain.Article{Title: "Tigers win the derby", Teaser: "A late goal"},
+						Score:   2.5,
+					}}}, nil)
+			},
+			err: nil,
+		},
+		{
+			name:   "generic err",
+			params: domain.SearchParams{Query: "derby"},
+			repoStub: func(repo *mock.MockRepository) {
+				repo.EXPECT().Search(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("generic error"))
+			},
+			err: ErrSearch,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockRepository(ctrl)
+			cache := mock.NewMockCache(ctrl)
+
+			tc.repoStub(repo)
+
+			uc := New(log, repo, cache)
+
+			res, err := uc.Search(context.Background(), tc.params)
+			if tc.err != nil {
+				assert.ErrorContains(t, err, tc.err.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, res.Hits, 1)
+			assert.Equal(t, map[string][]string{
+				"title": {"Tigers <mark>win</mark> the <mark>derby</mark>"},
+			}, res.Hits[0].Highlights)
+		})
+	}
+}
+
+// cacheRequests returns the article cache hits and misses recorded so far.
+func cacheRequests(t *testing.T) (hit, miss float64) {
+	rec := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	families, err := (&expfmt.TextParser{}).TextToMetricFamilies(rec.Body)
+	require.NoError(t, err)
+
+	family, ok := families["sportsnews_cache_requests_total"]
+	if !ok {
+		return 0, 0
+	}
+
+	for _, m := range family.GetMetric() {
+		for _, l := range m.GetLabel() {
+			switch {
+			case l.GetName() == "result" && l.GetValue() == "hit":
+				hit = m.GetCounter().GetValue()
+			case l.GetName() == "result" && l.GetValue() == "miss":
+				miss = m.GetCounter().GetValue()
+			}
+		}
+	}
+
+	return hit, miss
+}
+
+func TestArticleUseCase_Hide(t *testing.T) {
+	tt := []struct {
+		name string
+		stub func(repo *mock.MockRepository, cache *mock.MockCache)
+		err  bool
+	}{
+		{
+			name: "hidden",
+			stub: func(repo *mock.MockRepository, cache *mock.MockCache) {
+				repo.EXPECT().Hide(gomock.Any(), "6405f896a019b8815f6892c7").Times(1).
+					Return(&domain.Article{ID: "6405f896a019b8815f6892c7", Hidden: true}, nil)
+				cache.EXPECT().Delete(gomock.Any(), "6405f896a019b8815f6892c7").Times(1).Return(nil)
+			},
+		},
+		{
+			name: "cache error",
+			stub: func(repo *mock.MockRepository, cache *mock.MockCache) {
+				repo.EXPECT().Hide(gomock.Any(), "6405f896a019b8815f6892c7").Times(1).
+					Return(&domain.Article{ID: "6405f896a019b8815f6892c7", Hidden: true}, nil)
+				cache.EXPECT().Delete(gomock.Any(), "6405f896a019b8815f6892c7").Times(1).Return(errors.New("something went wrong"))
+			},
+		},
+		{
+			name: "not found",
+			stub: func(repo *mock.MockRepository, cache *mock.MockCache) {
+				repo.EXPECT().Hide(gomock.Any(), "6405f896a019b8815f6892c7").Times(1).
+					Return(nil, errors.New("mongo: no documents in result"))
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockRepository(ctrl)
+			cache := mock.NewMockCache(ctrl)
+			tc.stub(repo, cache)
+
+			a, err := New(getLogger(), repo, cache).Hide(context.Background(), "6405f896a019b8815f6892c7")
+			if tc.err {
+				assert.ErrorIs(t, err, ErrHide)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, a.Hidden)
+		})
+	}
+}
+
+func TestArticleUseCase_PurgeCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cache := mock.NewMockCache(ctrl)
+	cache.EXPECT().Purge(gomock.Any()).Times(1).Return(int64(12), nil)
+	cache.EXPECT().Purge(gomock.Any()).Times(1).Return(int64(0), errors.New("something went wrong"))
+
+	uc := New(getLogger(), mock.NewMockRepository(ctrl), cache)
+
+	result, err := uc.PurgeCache(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &domain.PurgeResult{Purged: 12}, result)
+
+	_, err = uc.PurgeCache(context.Background())
+	assert.ErrorIs(t, err, ErrPurgeCache)
+}
+
 func getLogger() logger.Logger {
 	cfg := &config.Config{}
 
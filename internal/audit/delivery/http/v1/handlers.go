@@ -0,0 +1,52 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/KarolosLykos/sportsnews/domain"
+	"github.com/KarolosLykos/sportsnews/internal/audit"
+	httperrors "github.com/KarolosLykos/sportsnews/internal/utils/http_errors"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+type auditHandler struct {
+	logger logger.Logger
+	uc     audit.UseCase
+}
+
+func NewAuditHandler(logger logger.Logger, uc audit.UseCase) *auditHandler {
+	return &auditHandler{
+		logger: logger,
+		uc:     uc,
+	}
+}
+
+// List returns the latest entries of the audit log, limit of them, of the actor when given.
+func (h *auditHandler) List() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		limit := domain.DefaultPageSize
+		if s := c.QueryParam("limit"); s != "" {
+			n, err := strconv.Atoi(s)
+			if err != nil || n <= 0 {
+				return httperrors.ErrorResponse(c, fmt.Errorf("%w:limit must be a positive integer", httperrors.ErrBadRequest))
+			}
+
+			limit = n
+		}
+
+		if limit > domain.MaxPageSize {
+			limit = domain.MaxPageSize
+		}
+
+		entries, err := h.uc.List(c.Request().Context(), c.QueryParam("actor"), limit)
+		if err != nil {
+			return httperrors.ErrorResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, entries.ToRest())
+	}
+}
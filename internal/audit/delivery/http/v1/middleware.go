@@ -0,0 +1,74 @@
+package v1
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/KarolosLykos/sportsnews/domain"
+	"github.com/KarolosLykos/sportsnews/internal/audit"
+	"github.com/KarolosLykos/sportsnews/internal/utils/jwtauth"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+type auditMiddleware struct {
+	logger logger.Logger
+	uc     audit.UseCase
+}
+
+func NewAuditMiddleware(logger logger.Logger, uc audit.UseCase) *auditMiddleware {
+	return &auditMiddleware{
+		logger: logger,
+		uc:     uc,
+	}
+}
+
+// Record records the actions of the operators: the requests that change something, once they are
+// answered. It goes after jwtauth.Require, which tells the operator. Reads are not recorded, and
+// neither are the requests Require refuses. Entries that cannot be stored are logged only.
+func (m *auditMiddleware) Record() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			err := next(c)
+
+			req := c.Request()
+			switch req.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				return err
+			}
+
+			operator := jwtauth.OperatorOf(c)
+			if operator == nil {
+				return err
+			}
+
+			status := c.Response().Status
+			if err != nil && !c.Response().Committed {
+				status = http.StatusInternalServerError
+			}
+
+			entry := &domain.AuditEntry{
+				Actor:    operator.Subject,
+				Roles:    operator.Roles,
+				Action:   req.Method + " " + c.Path(),
+				Status:   status,
+				RemoteIP: c.RealIP(),
+				Time:     time.Now().UTC(),
+			}
+
+			if names := c.ParamNames(); len(names) > 0 {
+				entry.Params = make(map[string]string, len(names))
+				for i, name := range names {
+					entry.Params[name] = c.ParamValues()[i]
+				}
+			}
+
+			if rerr := m.uc.Record(req.Context(), entry); rerr != nil {
+				m.logger.Warn(req.Context(), rerr, "could not record audit entry")
+			}
+
+			return err
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package v1
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+	mock "github.com/KarolosLykos/sportsnews/internal/audit/mock"
+	"github.com/KarolosLykos/sportsnews/internal/utils/jwtauth"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+func TestAuditMiddleware_Record(t *testing.T) {
+	tt := []struct {
+		name    string
+		method  string
+		handler echo.HandlerFunc
+		stub    func(uc *mock.MockUseCase)
+		code    int
+	}{
+		{
+			name:    "action",
+			method:  http.MethodPost,
+			handler: func(c echo.Context) error { return c.NoContent(http.StatusOK) },
+			stub: func(uc *mock.MockUseCase) {
+				uc.EXPECT().Record(gomock.Any(), gomock.Any()).Times(1).
+					Do(func(_ interface{}, entry *domain.AuditEntry) {
+						assert.Equal(t, "jane@example.com", entry.Actor)
+						assert.Equal(t, "POST /api/v1/admin/keys/:id/revoke", entry.Action)
+						assert.Equal(t, map[string]string{"id": "1"}, entry.Params)
+						assert.Equal(t, http.StatusOK, entry.Status)
+						assert.Equal(t, "192.0.2.1", entry.RemoteIP)
+						assert.False(t, entry.Time.IsZero())
+					}).Return(nil)
+			},
+			code: http.StatusOK,
+		},
+		{
+			name:    "failed action",
+			method:  http.MethodPost,
+			handler: func(c echo.Context) error { return c.NoContent(http.StatusNotFound) },
+			stub: func(uc *mock.MockUseCase) {
+				uc.EXPECT().Record(gomock.Any(), gomock.Any()).Times(1).
+					Do(func(_ interface{}, entry *domain.AuditEntry) {
+						assert.Equal(t, http.StatusNotFound, entry.Status)
+					}).Return(nil)
+			},
+			code: http.StatusNotFound,
+		},
+		{
+			name:    "read",
+			method:  http.MethodGet,
+			handler: func(c echo.Context) error { return c.NoContent(http.StatusOK) },
+			code:    http.StatusOK,
+		},
+		{
+			name:    "record error",
+			method:  http.MethodPost,
+			handler: func(c echo.Context) error { return c.NoContent(http.StatusOK) },
+			stub: func(uc *mock.MockUseCase) {
+				uc.EXPECT().Record(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("something went wrong"))
+			},
+			code: http.StatusOK,
+		},
+	}
+
+	cfg := &config.Config{}
+	cfg.Auth.JWTSecret = "secret"
+	cfg.Auth.RolesClaim = "roles"
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":   "jane@example.com",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"roles": []string{"admin"},
+	}).SignedString([]byte("secret"))
+	require.NoError(t, err)
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := mock.NewMockUseCase(ctrl)
+			if tc.stub != nil {
+				tc.stub(uc)
+			}
+
+			operators, err := jwtauth.New(cfg, getLogger())
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(tc.method, "/api/v1/admin/keys/1/revoke", nil)
+			req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+			req.RemoteAddr = "192.0.2.1:1234"
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+			c.SetPath("/api/v1/admin/keys/:id/revoke")
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+
+			h := operators.Require(domain.RoleAdmin)(NewAuditMiddleware(getLogger(), uc).Record()(tc.handler))
+
+			require.NoError(t, h(c))
+			assert.Equal(t, tc.code, rec.Code)
+		})
+	}
+}
+
+func TestAuditMiddleware_RecordWithoutOperator(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/keys", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	next := func(c echo.Context) error { return c.NoContent(http.StatusCreated) }
+
+	require.NoError(t, NewAuditMiddleware(getLogger(), mock.NewMockUseCase(ctrl)).Record()(next)(c))
+	assert.Equal(t, http.StatusCreated, rec.Code)
+}
+
+func getLogger() logger.Logger {
+	cfg := &config.Config{}
+
+	l := &logrus.Logger{
+		Out:          io.Discard,
+		Hooks:        make(logrus.LevelHooks),
+		ReportCaller: false,
+		ExitFunc:     os.Exit,
+		Level:        logrus.InfoLevel,
+		Formatter:    &logrus.JSONFormatter{},
+	}
+
+	return logger.New(cfg, l)
+}
@@ -0,0 +1,13 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/KarolosLykos/sportsnews/domain"
+)
+
+type Repository interface {
+	Create(ctx context.Context, entry *domain.AuditEntry) error
+	// List returns the latest entries, newest first, of the actor or of every actor when empty.
+	List(ctx context.Context, actor string, limit int) (domain.AuditEntries, error)
+}
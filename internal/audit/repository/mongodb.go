@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+const (
+	sportsNewsDB       = "sportsnews"
+	auditLogCollection = "auditLog"
+)
+
+var (
+	ErrCreate  = errors.New("repository: create")
+	ErrList    = errors.New("repository: list")
+	ErrIndexes = errors.New("repository: indexes")
+)
+
+type mongoRepository struct {
+	cfg    *config.Config
+	logger logger.Logger
+	client *mongo.Client
+}
+
+func NewMongoRepository(cfg *config.Config, client *mongo.Client, logger logger.Logger) *mongoRepository {
+	return &mongoRepository{
+		cfg:    cfg,
+		client: client,
+		logger: logger,
+	}
+}
+
+// EnsureIndexes creates the indexes of the log that do not exist yet: the entries of an actor by
+// time, and the expiry of old entries, which also lists every entry by time.
+func (m *mongoRepository) EnsureIndexes(ctx context.Context) error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "actor", Value: 1}, {Key: "time", Value: -1}},
+			Options: options.Index().SetName("actor_time"),
+		},
+		{
+			Keys: bson.D{{Key: "time", Value: 1}},
+			Options: options.Index().SetName("time_ttl").
+				SetExpireAfterSeconds(int32(m.cfg.Auth.AuditRetention.Seconds())),
+		},
+	}
+
+	if _, err := m.collection().Indexes().CreateMany(ctx, indexes); err != nil {
+		return fmt.Errorf("%w:%v", ErrIndexes, err)
+	}
+
+	return nil
+}
+
+func (m *mongoRepository) Create(ctx context.Context, entry *domain.AuditEntry) error {
+	res, err := m.collection().InsertOne(ctx, entry)
+	if err != nil {
+		return fmt.Errorf("%w:%v", ErrCreate, err)
+	}
+
+	if id, ok := res.InsertedID.(primitive.ObjectID); ok {
+		entry.ID = id.Hex()
+	}
+
+	return nil
+}
+
+func (m *mongoRepository) List(ctx context.Context, actor string, limit int) (domain.AuditEntries, error) {
+	filter := bson.D{}
+	if actor != "" {
+		filter = append(filter, bson.E{Key: "actor", Value: actor})
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "time", Value: -1}}).SetLimit(int64(limit))
+
+	cursor, err := m.collection().Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrList, err)
+	}
+
+	entries := make(domain.AuditEntries, 0)
+	if err = cursor.All(ctx, &entries); err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrList, err)
+	}
+
+	return entries, nil
+}
+
+func (m *mongoRepository) collection() *mongo.Collection {
+	return m.client.Database(sportsNewsDB).Collection(auditLogCollection)
+}
@@ -0,0 +1,12 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/KarolosLykos/sportsnews/domain"
+)
+
+type UseCase interface {
+	Record(ctx context.Context, entry *domain.AuditEntry) error
+	List(ctx context.Context, actor string, limit int) (domain.AuditEntries, error)
+}
@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/KarolosLykos/sportsnews/domain"
+	"github.com/KarolosLykos/sportsnews/internal/audit"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+var (
+	ErrRecord = errors.New("usecase: record")
+	ErrList   = errors.New("usecase: list")
+)
+
+type auditUseCase struct {
+	logger     logger.Logger
+	repository audit.Repository
+}
+
+func New(logger logger.Logger, repository audit.Repository) *auditUseCase {
+	return &auditUseCase{
+		logger:     logger,
+		repository: repository,
+	}
+}
+
+// Record stores the entry, and logs it too, so that actions are kept when the database fails.
+func (u *auditUseCase) Record(ctx context.Context, entry *domain.AuditEntry) error {
+	u.logger.Infof(ctx, "audit: actor: %s, action: %s, params: %v, status: %d", entry.Actor, entry.Action, entry.Params, entry.Status)
+
+	if err := u.repository.Create(ctx, entry); err != nil {
+		return fmt.Errorf("%w:%v", ErrRecord, err)
+	}
+
+	return nil
+}
+
+func (u *auditUseCase) List(ctx context.Context, actor string, limit int) (domain.AuditEntries, error) {
+	entries, err := u.repository.List(ctx, actor, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrList, err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,84 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/KarolosLykos/sportsnews/domain"
+	"github.com/KarolosLykos/sportsnews/internal/editorial"
+	httperrors "github.com/KarolosLykos/sportsnews/internal/utils/http_errors"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+type editorialHandler struct {
+	logger logger.Logger
+	uc     editorial.UseCase
+}
+
+func NewEditorialHandler(logger logger.Logger, uc editorial.UseCase) *editorialHandler {
+	return &editorialHandler{
+		logger: logger,
+		uc:     uc,
+	}
+}
+
+// Create stores the article of the body and returns it with its ids and slug.
+func (h *editorialHandler) Create() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		e, err := bind(c)
+		if err != nil {
+			return httperrors.ErrorResponse(c, err)
+		}
+
+		created, err := h.uc.Create(c.Request().Context(), e)
+		if err != nil {
+			return httperrors.ErrorResponse(c, err)
+		}
+
+		return c.JSON(http.StatusCreated, created.ToRest())
+	}
+}
+
+// Update replaces the article with the articleID by the article of the body.
+func (h *editorialHandler) Update() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		e, err := bind(c)
+		if err != nil {
+			return httperrors.ErrorResponse(c, err)
+		}
+
+		updated, err := h.uc.Update(c.Request().Context(), c.Param("articleID"), e)
+		if err != nil {
+			return httperrors.ErrorResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, updated.ToRest())
+	}
+}
+
+func (h *editorialHandler) Delete() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if err := h.uc.Delete(c.Request().Context(), c.Param("articleID")); err != nil {
+			return httperrors.ErrorResponse(c, err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// bind reads the article of the body and validates it.
+func bind(c echo.Context) (*domain.EditorialArticle, error) {
+	e := &domain.EditorialArticle{}
+	if err := c.Bind(e); err != nil {
+		return nil, fmt.Errorf("%w:%v", httperrors.ErrBadRequest, err)
+	}
+
+	if err := e.Validate(time.Now()); err != nil {
+		return nil, fmt.Errorf("%w:%v", httperrors.ErrBadRequest, err)
+	}
+
+	return e, nil
+}
@@ -0,0 +1,228 @@
+package v1
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+	mock "github.com/KarolosLykos/sportsnews/internal/editorial/mock"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+func TestEditorialHandler_Create(t *testing.T) {
+	future := time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339)
+
+	tt := []struct {
+		name string
+		body string
+		stub func(uc *mock.MockUseCase)
+		code int
+	}{
+		{
+			name: "published",
+			body: `{"teamId":"Hull City","title":"Tigers sign a striker","content":"<p>...</p>","status":"published",` +
+				`"imageUrl":"https://example.com/striker.jpg","galleryUrls":["https://example.com/1.jpg"],"published":"2023-03-01T12:00:00Z"}`,
+			stub: func(uc *mock.MockUseCase) {
+				published := time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)
+				uc.EXPECT().Create(gomock.Any(), &domain.EditorialArticle{
+					TeamID:      "Hull City",
+					Title:       "Tigers sign a striker",
+					Content:     "<p>...</p>",
+					Status:      domain.EditorialPublished,
+					ImageURL:    "https://example.com/striker.jpg",
+					GalleryURLs: []string{"https://example.com/1.jpg"},
+					Published:   &published,
+				}).Times(1).Return(&domain.Article{ID: "1", ArticleID: "a1b2c3d4e5f6", Provider: domain.EditorialProvider}, nil)
+			},
+			code: http.StatusCreated,
+		},
+		{
+			name: "draft planned for later",
+			body: `{"teamId":"Hull City","title":"Tigers sign a striker","content":"<p>...</p>","status":"draft","published":"` + future + `"}`,
+			stub: func(uc *mock.MockUseCase) {
+				uc.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(&domain.Article{ID: "1"}, nil)
+			},
+			code: http.StatusCreated,
+		},
+		{
+			name: "malformed",
+			body: `{"title":`,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "without title",
+			body: `{"teamId":"Hull City","content":"<p>...</p>","status":"draft"}`,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "without content",
+			body: `{"teamId":"Hull City","title":"Tigers sign a striker","status":"draft"}`,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "unknown status",
+			body: `{"teamId":"Hull City","title":"Tigers sign a striker","content":"<p>...</p>","status":"archived"}`,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "relative url",
+			body: `{"teamId":"Hull City","title":"Tigers sign a striker","content":"<p>...</p>","status":"draft","videoUrl":"/video"}`,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "gallery url of another scheme",
+			body: `{"teamId":"Hull City","title":"Tigers sign a striker","content":"<p>...</p>","status":"draft","galleryUrls":["ftp://example.com/1.jpg"]}`,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "invalid date",
+			body: `{"teamId":"Hull City","title":"Tigers sign a striker","content":"<p>...</p>","status":"draft","published":"yesterday"}`,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "published in the future",
+			body: `{"teamId":"Hull City","title":"Tigers sign a striker","content":"<p>...</p>","status":"published","published":"` + future + `"}`,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "error",
+			body: `{"teamId":"Hull City","title":"Tigers sign a striker","content":"<p>...</p>","status":"draft"}`,
+			stub: func(uc *mock.MockUseCase) {
+				uc.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("something went wrong"))
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := mock.NewMockUseCase(ctrl)
+			if tc.stub != nil {
+				tc.stub(uc)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/editorial/articles", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+
+			require.NoError(t, NewEditorialHandler(getLogger(), uc).Create()(c))
+			assert.Equal(t, tc.code, rec.Code, rec.Body.String())
+		})
+	}
+}
+
+func TestEditorialHandler_Update(t *testing.T) {
+	tt := []struct {
+		name string
+		body string
+		stub func(uc *mock.MockUseCase)
+		code int
+	}{
+		{
+			name: "updated",
+			body: `{"teamId":"Hull City","title":"Tigers sign a striker","content":"<p>...</p>","status":"published"}`,
+			stub: func(uc *mock.MockUseCase) {
+				uc.EXPECT().Update(gomock.Any(), "a1b2c3d4e5f6", gomock.Any()).Times(1).Return(&domain.Article{ID: "1"}, nil)
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "invalid",
+			body: `{"teamId":"Hull City","title":"Tigers sign a striker","content":"<p>...</p>"}`,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "not found",
+			body: `{"teamId":"Hull City","title":"Tigers sign a striker","content":"<p>...</p>","status":"draft"}`,
+			stub: func(uc *mock.MockUseCase) {
+				uc.EXPECT().Update(gomock.Any(), "a1b2c3d4e5f6", gomock.Any()).Times(1).
+					Return(nil, errors.New("usecase: update:repository: getByArticleID:mongo: no documents in result"))
+			},
+			code: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := mock.NewMockUseCase(ctrl)
+			if tc.stub != nil {
+				tc.stub(uc)
+			}
+
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/editorial/articles/a1b2c3d4e5f6", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+			c.SetParamNames("articleID")
+			c.SetParamValues("a1b2c3d4e5f6")
+
+			require.NoError(t, NewEditorialHandler(getLogger(), uc).Update()(c))
+			assert.Equal(t, tc.code, rec.Code, rec.Body.String())
+		})
+	}
+}
+
+func TestEditorialHandler_Delete(t *testing.T) {
+	tt := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{name: "deleted", code: http.StatusNoContent},
+		{name: "not found", err: errors.New("usecase: delete:repository: delete:mongo: no documents in result"), code: http.StatusNotFound},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := mock.NewMockUseCase(ctrl)
+			uc.EXPECT().Delete(gomock.Any(), "a1b2c3d4e5f6").Times(1).Return(tc.err)
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/editorial/articles/a1b2c3d4e5f6", nil)
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+			c.SetParamNames("articleID")
+			c.SetParamValues("a1b2c3d4e5f6")
+
+			require.NoError(t, NewEditorialHandler(getLogger(), uc).Delete()(c))
+			assert.Equal(t, tc.code, rec.Code)
+		})
+	}
+}
+
+func getLogger() logger.Logger {
+	cfg := &config.Config{}
+
+	l := &logrus.Logger{
+		Out:          io.Discard,
+		Hooks:        make(logrus.LevelHooks),
+		ReportCaller: false,
+		ExitFunc:     os.Exit,
+		Level:        logrus.InfoLevel,
+		Formatter:    &logrus.JSONFormatter{},
+	}
+
+	return logger.New(cfg, l)
+}
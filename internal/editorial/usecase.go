@@ -0,0 +1,14 @@
+package editorial
+
+import (
+	"context"
+
+	"github.com/KarolosLykos/sportsnews/domain"
+)
+
+// UseCase manages the articles written in-house, stored as articles of domain.EditorialProvider.
+type UseCase interface {
+	Create(ctx context.Context, article *domain.EditorialArticle) (*domain.Article, error)
+	Update(ctx context.Context, articleID string, article *domain.EditorialArticle) (*domain.Article, error)
+	Delete(ctx context.Context, articleID string) error
+}
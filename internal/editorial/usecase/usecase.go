@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/KarolosLykos/sportsnews/domain"
+	"github.com/KarolosLykos/sportsnews/internal/article"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+var (
+	ErrCreate = errors.New("usecase: create")
+	ErrUpdate = errors.New("usecase: update")
+	ErrDelete = errors.New("usecase: delete")
+)
+
+// editorialUseCase writes the editorial articles through the article repository, so that their
+// changes are published and indexed like the changes of provider articles, and keeps the article
+// cache up to date.
+type editorialUseCase struct {
+	logger     logger.Logger
+	repository article.Repository
+	cache      article.Cache
+}
+
+func New(logger logger.Logger, repository article.Repository, cache article.Cache) *editorialUseCase {
+	return &editorialUseCase{
+		logger:     logger,
+		repository: repository,
+		cache:      cache,
+	}
+}
+
+// Create stores the article with a new id. It is published now unless it names another time.
+func (u *editorialUseCase) Create(ctx context.Context, e *domain.EditorialArticle) (*domain.Article, error) {
+	articleID, err := newArticleID()
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrCreate, err)
+	}
+
+	now := time.Now()
+
+	published := now
+	if e.Published != nil {
+		published = *e.Published
+	}
+
+	created, _, err := u.repository.Upsert(ctx, e.ToArticle(articleID, published, now))
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrCreate, err)
+	}
+
+	u.setCache(ctx, created)
+
+	return created, nil
+}
+
+// Update replaces the article with the id. It keeps its publication time unless it names another
+// one, or unless a draft is published, which publishes it now. Its slug is kept too.
+func (u *editorialUseCase) Update(ctx context.Context, articleID string, e *domain.EditorialArticle) (*domain.Article, error) {
+	existing, err := u.repository.GetByArticleID(ctx, domain.EditorialProvider, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrUpdate, err)
+	}
+
+	now := time.Now()
+
+	published := existing.Published
+	switch {
+	case e.Published != nil:
+		published = *e.Published
+	case !existing.IsPublished && e.Status == domain.EditorialPublished:
+		published = now
+	}
+
+	updated, _, err := u.repository.Upsert(ctx, e.ToArticle(articleID, published, now))
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrUpdate, err)
+	}
+
+	u.setCache(ctx, updated)
+
+	return updated, nil
+}
+
+// Delete deletes the article with the id and evicts it from the cache.
+func (u *editorialUseCase) Delete(ctx context.Context, articleID string) error {
+	deleted, err := u.repository.Delete(ctx, domain.EditorialProvider, articleID)
+	if err != nil {
+		return fmt.Errorf("%w:%v", ErrDelete, err)
+	}
+
+	if err = u.cache.Delete(ctx, deleted.ID); err != nil {
+		u.logger.Warnf(ctx, err, "could not delete cached article with id: %s", deleted.ID)
+	}
+
+	return nil
+}
+
+func (u *editorialUseCase) setCache(ctx context.Context, a *domain.Article) {
+	if err := u.cache.Set(ctx, a); err != nil {
+		u.logger.Warnf(ctx, err, "could not set article with id: %s", a.ID)
+	}
+}
+
+// newArticleID returns a random id for a new editorial article.
+func newArticleID() (string, error) {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
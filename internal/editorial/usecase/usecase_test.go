@@ -0,0 +1,255 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+	mock "github.com/KarolosLykos/sportsnews/internal/article/mock"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+func TestEditorialUseCase_Create(t *testing.T) {
+	published := time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		name      string
+		article   *domain.EditorialArticle
+		stub      func(repo *mock.MockRepository, cache *mock.MockCache)
+		published func(t *testing.T, got time.Time)
+		err       bool
+	}{
+		{
+			name:    "published now",
+			article: &domain.EditorialArticle{TeamID: "Hull City", Title: "Tigers sign a striker", Content: "<p>...</p>", Status: domain.EditorialPublished},
+			stub: func(repo *mock.MockRepository, cache *mock.MockCache) {
+				cache.EXPECT().Set(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+			published: func(t *testing.T, got time.Time) {
+				assert.WithinDuration(t, time.Now(), got, time.Minute)
+			},
+		},
+		{
+			name: "published before",
+			article: &domain.EditorialArticle{
+				TeamID: "Hull City", Title: "Tigers sign a striker", Content: "<p>...</p>", Status: domain.EditorialPublished, Published: &published,
+			},
+			stub: func(repo *mock.MockRepository, cache *mock.MockCache) {
+				cache.EXPECT().Set(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("something went wrong"))
+			},
+			published: func(t *testing.T, got time.Time) {
+				assert.Equal(t, published, got)
+			},
+		},
+		{
+			name:    "repository error",
+			article: &domain.EditorialArticle{TeamID: "Hull City", Title: "Tigers sign a striker", Content: "<p>...</p>", Status: domain.EditorialDraft},
+			stub: func(repo *mock.MockRepository, cache *mock.MockCache) {
+				repo.EXPECT().Upsert(gomock.Any(), gomock.Any()).Times(1).Return(nil, domain.Change(""), errors.New("something went wrong"))
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockRepository(ctrl)
+			cache := mock.NewMockCache(ctrl)
+			tc.stub(repo, cache)
+
+			repo.EXPECT().Upsert(gomock.Any(), gomock.Any()).AnyTimes().
+				DoAndReturn(func(_ context.Context, a *domain.Article) (*domain.Article, domain.Change, error) {
+					created := *a
+					created.ID = "6405f896a019b8815f6892c7"
+
+					return &created, domain.ChangeCreated, nil
+				})
+
+			a, err := New(getLogger(), repo, cache).Create(context.Background(), tc.article)
+			if tc.err {
+				assert.ErrorIs(t, err, ErrCreate)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "6405f896a019b8815f6892c7", a.ID)
+			assert.Equal(t, domain.EditorialProvider, a.Provider)
+			assert.Len(t, a.ArticleID, 12)
+			assert.Equal(t, "tigers-sign-a-striker-"+a.ArticleID, a.Slug)
+			assert.True(t, a.IsPublished)
+			tc.published(t, a.Published)
+		})
+	}
+}
+
+func TestEditorialUseCase_Update(t *testing.T) {
+	published := time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)
+	corrected := time.Date(2023, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		name      string
+		existing  *domain.Article
+		article   *domain.EditorialArticle
+		published func(t *testing.T, got time.Time)
+		getErr    error
+		upsertErr error
+	}{
+		{
+			name:     "published",
+			existing: &domain.Article{ID: "1", IsPublished: true, Published: published},
+			article:  &domain.EditorialArticle{Title: "Tigers sign a striker", Status: domain.EditorialPublished},
+			published: func(t *testing.T, got time.Time) {
+				assert.Equal(t, published, got)
+			},
+		},
+		{
+			name:     "draft published",
+			existing: &domain.Article{ID: "1", Published: published},
+			article:  &domain.EditorialArticle{Title: "Tigers sign a striker", Status: domain.EditorialPublished},
+			published: func(t *testing.T, got time.Time) {
+				assert.WithinDuration(t, time.Now(), got, time.Minute)
+			},
+		},
+		{
+			name:     "published at another time",
+			existing: &domain.Article{ID: "1", IsPublished: true, Published: published},
+			article:  &domain.EditorialArticle{Title: "Tigers sign a striker", Status: domain.EditorialPublished, Published: &corrected},
+			published: func(t *testing.T, got time.Time) {
+				assert.Equal(t, corrected, got)
+			},
+		},
+		{
+			name:    "not found",
+			article: &domain.EditorialArticle{Title: "Tigers sign a striker", Status: domain.EditorialPublished},
+			getErr:  errors.New("mongo: no documents in result"),
+		},
+		{
+			name:      "repository error",
+			existing:  &domain.Article{ID: "1", IsPublished: true, Published: published},
+			article:   &domain.EditorialArticle{Title: "Tigers sign a striker", Status: domain.EditorialPublished},
+			upsertErr: errors.New("something went wrong"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockRepository(ctrl)
+			cache := mock.NewMockCache(ctrl)
+
+			repo.EXPECT().GetByArticleID(gomock.Any(), domain.EditorialProvider, "a1b2c3d4e5f6").Times(1).Return(tc.existing, tc.getErr)
+
+			if tc.getErr == nil {
+				repo.EXPECT().Upsert(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, a *domain.Article) (*domain.Article, domain.Change, error) {
+						if tc.upsertErr != nil {
+							return nil, "", tc.upsertErr
+						}
+
+						updated := *a
+						updated.ID = tc.existing.ID
+
+						return &updated, domain.ChangeUpdated, nil
+					})
+			}
+
+			if tc.getErr == nil && tc.upsertErr == nil {
+				cache.EXPECT().Set(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			}
+
+			a, err := New(getLogger(), repo, cache).Update(context.Background(), "a1b2c3d4e5f6", tc.article)
+			if tc.getErr != nil || tc.upsertErr != nil {
+				assert.ErrorIs(t, err, ErrUpdate)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "1", a.ID)
+			assert.Equal(t, "a1b2c3d4e5f6", a.ArticleID)
+			assert.Equal(t, domain.EditorialProvider, a.Provider)
+			tc.published(t, a.Published)
+		})
+	}
+}
+
+func TestEditorialUseCase_Delete(t *testing.T) {
+	tt := []struct {
+		name string
+		stub func(repo *mock.MockRepository, cache *mock.MockCache)
+		err  bool
+	}{
+		{
+			name: "deleted",
+			stub: func(repo *mock.MockRepository, cache *mock.MockCache) {
+				repo.EXPECT().Delete(gomock.Any(), domain.EditorialProvider, "a1b2c3d4e5f6").Times(1).Return(&domain.Article{ID: "1"}, nil)
+				cache.EXPECT().Delete(gomock.Any(), "1").Times(1).Return(nil)
+			},
+		},
+		{
+			name: "cache error",
+			stub: func(repo *mock.MockRepository, cache *mock.MockCache) {
+				repo.EXPECT().Delete(gomock.Any(), domain.EditorialProvider, "a1b2c3d4e5f6").Times(1).Return(&domain.Article{ID: "1"}, nil)
+				cache.EXPECT().Delete(gomock.Any(), "1").Times(1).Return(errors.New("something went wrong"))
+			},
+		},
+		{
+			name: "not found",
+			stub: func(repo *mock.MockRepository, cache *mock.MockCache) {
+				repo.EXPECT().Delete(gomock.Any(), domain.EditorialProvider, "a1b2c3d4e5f6").Times(1).
+					Return(nil, errors.New("mongo: no documents in result"))
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockRepository(ctrl)
+			cache := mock.NewMockCache(ctrl)
+			tc.stub(repo, cache)
+
+			err := New(getLogger(), repo, cache).Delete(context.Background(), "a1b2c3d4e5f6")
+			if tc.err {
+				assert.ErrorIs(t, err, ErrDelete)
+				assert.True(t, strings.Contains(err.Error(), "no documents in result"))
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func getLogger() logger.Logger {
+	cfg := &config.Config{}
+
+	l := &logrus.Logger{
+		Out:          io.Discard,
+		Hooks:        make(logrus.LevelHooks),
+		ReportCaller: false,
+		ExitFunc:     os.Exit,
+		Level:        logrus.InfoLevel,
+		Formatter:    &logrus.JSONFormatter{},
+	}
+
+	return logger.New(cfg, l)
+}
@@ -0,0 +1,213 @@
+package fakeprovider
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"log"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	listPath   = "/api/incrowd/getnewlistinformation"
+	singlePath = "/api/incrowd/getnewsarticleinformation"
+)
+
+// Options are the knobs of the fake provider.
+type Options struct {
+	// Latency is added to every response.
+	Latency time.Duration
+	// Jitter is a random extra latency between zero and Jitter.
+	Jitter time.Duration
+	// ErrorRate is the share of requests answered with 500 Internal Server Error.
+	ErrorRate float64
+	// NotModifiedRate is the share of conditional list requests answered with 304 Not Modified
+	// even when the list has changed.
+	NotModifiedRate float64
+	// Churn is the interval between random changes to the articles. Zero disables churn.
+	Churn time.Duration
+	// Seed seeds the random source. Zero uses the current time.
+	Seed int64
+}
+
+// Server serves InCrowd compatible list and detail XML documents.
+// Each store is served under /<store name>/api/incrowd/...
+type Server struct {
+	opts   Options
+	stores []*Store
+	echo   *echo.Echo
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+func New(opts Options, stores ...*Store) *Server {
+	seed := opts.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	s := &Server{
+		opts:   opts,
+		stores: stores,
+		echo:   echo.New(),
+		rand:   rand.New(rand.NewSource(seed)), //nolint:gosec // the fake provider does not need secure randomness.
+	}
+
+	s.echo.HideBanner = true
+	s.echo.HidePort = true
+	s.echo.Use(s.simulate)
+
+	for _, store := range stores {
+		g := s.echo.Group("/" + store.Name())
+		g.GET(listPath, s.list(store))
+		g.GET(singlePath, s.single(store))
+	}
+
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.echo.ServeHTTP(w, r)
+}
+
+// Run churns the stores until ctx is done.
+func (s *Server) Run(ctx context.Context) {
+	if s.opts.Churn <= 0 {
+		<-ctx.Done()
+		return
+	}
+
+	ticker := time.NewTicker(s.opts.Churn)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.churn(now)
+		}
+	}
+}
+
+func (s *Server) churn(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, store := range s.stores {
+		log.Printf("%s: %s", store.Name(), store.Churn(s.rand, now))
+	}
+}
+
+// chance reports whether an event with the given probability happens.
+func (s *Server) chance(p float64) bool {
+	if p <= 0 {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.rand.Float64() < p
+}
+
+// delay returns the latency of the next response.
+func (s *Server) delay() time.Duration {
+	d := s.opts.Latency
+	if s.opts.Jitter > 0 {
+		s.mu.Lock()
+		d += time.Duration(s.rand.Int63n(int64(s.opts.Jitter)))
+		s.mu.Unlock()
+	}
+
+	return d
+}
+
+// simulate applies latency and injected errors to every request.
+func (s *Server) simulate(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if d := s.delay(); d > 0 {
+			timer := time.NewTimer(d)
+			defer timer.Stop()
+
+			select {
+			case <-timer.C:
+			case <-c.Request().Context().Done():
+				return c.Request().Context().Err()
+			}
+		}
+
+		if s.chance(s.opts.ErrorRate) {
+			return c.String(http.StatusInternalServerError, "injected error")
+		}
+
+		return next(c)
+	}
+}
+
+func (s *Server) list(store *Store) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		count, _ := strconv.Atoi(c.QueryParam("count"))
+
+		list, version, modified := store.List(count)
+		etag := fmt.Sprintf(`"%s-%d"`, store.Name(), version)
+
+		c.Response().Header().Set("ETag", etag)
+		c.Response().Header().Set("Last-Modified", modified.Format(http.TimeFormat))
+
+		if notModified(c.Request(), etag, modified) ||
+			(conditional(c.Request()) && s.chance(s.opts.NotModifiedRate)) {
+			return c.NoContent(http.StatusNotModified)
+		}
+
+		return writeXML(c, list)
+	}
+}
+
+func (s *Server) single(store *Store) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		info, ok := store.Get(c.QueryParam("id"))
+		if !ok {
+			return c.String(http.StatusNotFound, "article not found")
+		}
+
+		return writeXML(c, info)
+	}
+}
+
+// conditional reports whether the request carries a validator.
+func conditional(r *http.Request) bool {
+	return r.Header.Get("If-None-Match") != "" || r.Header.Get("If-Modified-Since") != ""
+}
+
+// notModified reports whether the validators of the request match the current list.
+// If-None-Match takes precedence over If-Modified-Since.
+func notModified(r *http.Request, etag string, modified time.Time) bool {
+	if match := r.Header.Get("If-None-Match"); match != "" {
+		return match == etag
+	}
+
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+
+	return !modified.After(since)
+}
+
+func writeXML(c echo.Context, v any) error {
+	b, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return c.Blob(http.StatusOK, echo.MIMEApplicationXMLCharsetUTF8, append([]byte(xml.Header), b...))
+}
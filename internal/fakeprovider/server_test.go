@@ -0,0 +1,234 @@
+package fakeprovider_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+	"github.com/KarolosLykos/sportsnews/internal/article/consumer"
+	mock "github.com/KarolosLykos/sportsnews/internal/article/mock"
+	"github.com/KarolosLykos/sportsnews/internal/fakeprovider"
+	"github.com/KarolosLykos/sportsnews/internal/provider/health"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+const (
+	listURL   = "/club/api/incrowd/getnewlistinformation"
+	singleURL = "/club/api/incrowd/getnewsarticleinformation"
+)
+
+func newServer(t *testing.T, opts fakeprovider.Options) *httptest.Server {
+	t.Helper()
+
+	store, err := fakeprovider.LoadStore("testdata/club")
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(fakeprovider.New(opts, store))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func get(t *testing.T, url string, header http.Header) (*http.Response, string) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	for k := range header {
+		req.Header.Set(k, header.Get(k))
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	defer res.Body.Close()
+
+	b, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+
+	return res, string(b)
+}
+
+func TestLoadStore(t *testing.T) {
+	_, err := fakeprovider.LoadStore(t.TempDir())
+	assert.ErrorIs(t, err, fakeprovider.ErrNoFixtures)
+}
+
+func TestServer(t *testing.T) {
+	srv := newServer(t, fakeprovider.Options{})
+
+	tests := []struct {
+		name     string
+		url      string
+		status   int
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "list",
+			url:      listURL + "?count=10",
+			status:   http.StatusOK,
+			contains: []string{"<ClubName>Test FC</ClubName>", "<NewsArticleID>1</NewsArticleID>", "<NewsArticleID>2</NewsArticleID>"},
+			excludes: []string{"first body"},
+		},
+		{
+			name:     "list count",
+			url:      listURL + "?count=1",
+			status:   http.StatusOK,
+			contains: []string{"<NewsArticleID>2</NewsArticleID>"},
+			excludes: []string{"<NewsArticleID>1</NewsArticleID>"},
+		},
+		{
+			name:     "single",
+			url:      singleURL + "?id=1",
+			status:   http.StatusOK,
+			contains: []string{"<NewsArticleInformation>", "<BodyText>first body</BodyText>"},
+		},
+		{
+			name:   "single not found",
+			url:    singleURL + "?id=404",
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "unknown store",
+			url:    "/other/api/incrowd/getnewlistinformation",
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, body := get(t, srv.URL+tt.url, nil)
+
+			assert.Equal(t, tt.status, res.StatusCode)
+
+			for _, s := range tt.contains {
+				assert.Contains(t, body, s)
+			}
+
+			for _, s := range tt.excludes {
+				assert.NotContains(t, body, s)
+			}
+		})
+	}
+}
+
+func TestServer_Conditional(t *testing.T) {
+	srv := newServer(t, fakeprovider.Options{})
+
+	res, _ := get(t, srv.URL+listURL, nil)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	etag := res.Header.Get("ETag")
+	require.NotEmpty(t, etag)
+
+	res, _ = get(t, srv.URL+listURL, http.Header{"If-None-Match": {etag}})
+	assert.Equal(t, http.StatusNotModified, res.StatusCode)
+
+	res, _ = get(t, srv.URL+listURL, http.Header{"If-Modified-Since": {res.Header.Get("Last-Modified")}})
+	assert.Equal(t, http.StatusNotModified, res.StatusCode)
+
+	res, _ = get(t, srv.URL+listURL, http.Header{"If-None-Match": {`"stale"`}})
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+}
+
+func TestServer_Knobs(t *testing.T) {
+	t.Run("error rate", func(t *testing.T) {
+		srv := newServer(t, fakeprovider.Options{ErrorRate: 1})
+
+		res, _ := get(t, srv.URL+singleURL+"?id=1", nil)
+		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+	})
+
+	t.Run("not modified rate", func(t *testing.T) {
+		srv := newServer(t, fakeprovider.Options{NotModifiedRate: 1})
+
+		res, _ := get(t, srv.URL+listURL, nil)
+		assert.Equal(t, http.StatusOK, res.StatusCode, "unconditional requests are always served")
+
+		res, _ = get(t, srv.URL+listURL, http.Header{"If-None-Match": {`"stale"`}})
+		assert.Equal(t, http.StatusNotModified, res.StatusCode)
+	})
+
+	t.Run("latency", func(t *testing.T) {
+		srv := newServer(t, fakeprovider.Options{Latency: 50 * time.Millisecond})
+
+		start := time.Now()
+		get(t, srv.URL+singleURL+"?id=1", nil)
+		assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	})
+
+	t.Run("churn", func(t *testing.T) {
+		store, err := fakeprovider.LoadStore("testdata/club")
+		require.NoError(t, err)
+
+		s := fakeprovider.New(fakeprovider.Options{Churn: 10 * time.Millisecond, Seed: 1}, store)
+
+		_, before, _ := store.List(0)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		s.Run(ctx)
+
+		_, after, _ := store.List(0)
+		assert.Greater(t, after, before)
+	})
+}
+
+// TestServer_Consumer runs the Hull City consumer against the fake provider.
+func TestServer_Consumer(t *testing.T) {
+	srv := newServer(t, fakeprovider.Options{})
+
+	cfg := &config.Config{Consumer: config.ConsumerConfig{HullConsumer: config.HullConsumer{
+		SingleURL: srv.URL + singleURL,
+		ListURL:   srv.URL + listURL,
+		Count:     50,
+	}}}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockRepository(ctrl)
+	repo.EXPECT().Upsert(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, a *domain.Article) (*domain.Article, domain.Change, error) {
+			return a, domain.ChangeCreated, nil
+		},
+	).Times(2)
+
+	cache := mock.NewMockCache(ctrl)
+	cache.EXPECT().Set(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+	c := consumer.NewHullCityConsumer(cfg, getLogger(), &http.Client{}, repo, cache, health.NewTracker())
+
+	result, err := c.Consume(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Created)
+}
+
+func getLogger() logger.Logger {
+	cfg := &config.Config{}
+
+	l := &logrus.Logger{
+		Out:          io.Discard,
+		Hooks:        make(logrus.LevelHooks),
+		ReportCaller: false,
+		ExitFunc:     os.Exit,
+		Level:        logrus.InfoLevel,
+		Formatter:    &logrus.JSONFormatter{},
+	}
+
+	return logger.New(cfg, l)
+}
@@ -0,0 +1,201 @@
+package fakeprovider
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/KarolosLykos/sportsnews/domain"
+)
+
+const dateLayout = "2006-01-02 15:04:05"
+
+var ErrNoFixtures = errors.New("fakeprovider: no fixtures")
+
+// articleInformation is the detail document served for a single article.
+type articleInformation struct {
+	XMLName        xml.Name           `xml:"NewsArticleInformation"`
+	ClubName       string             `xml:"ClubName"`
+	ClubWebsiteURL string             `xml:"ClubWebsiteURL"`
+	NewsArticle    domain.HullArticle `xml:"NewsArticle"`
+}
+
+// listInformation is the list document served for a club.
+type listInformation struct {
+	XMLName        xml.Name             `xml:"NewListInformation"`
+	ClubName       string               `xml:"ClubName"`
+	ClubWebsiteURL string               `xml:"ClubWebsiteURL"`
+	Items          []domain.HullArticle `xml:"NewsletterNewsItems>NewsletterNewsItem"`
+}
+
+// Store holds the articles of a single club.
+type Store struct {
+	name     string
+	clubName string
+	clubURL  string
+
+	mu       sync.RWMutex
+	articles map[string]domain.HullArticle
+	titles   map[string]string // titles before any churn
+	version  int
+	modified time.Time
+}
+
+// LoadStore reads every *.xml detail document in dir. The store is named after the directory.
+func LoadStore(dir string) (*Store, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.xml"))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("%w:%s", ErrNoFixtures, dir)
+	}
+
+	s := &Store{
+		name:     filepath.Base(dir),
+		articles: make(map[string]domain.HullArticle, len(files)),
+		titles:   make(map[string]string, len(files)),
+		version:  1,
+		modified: time.Now().UTC().Truncate(time.Second),
+	}
+
+	for _, file := range files {
+		b, err := os.ReadFile(file) //nolint:gosec // fixtures are chosen by whoever runs the fake provider.
+		if err != nil {
+			return nil, err
+		}
+
+		info := &articleInformation{}
+		if err = xml.Unmarshal(b, info); err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+
+		if s.clubName == "" {
+			s.clubName = info.ClubName
+			s.clubURL = info.ClubWebsiteURL
+		}
+
+		s.articles[info.NewsArticle.NewsArticleID] = info.NewsArticle
+		s.titles[info.NewsArticle.NewsArticleID] = info.NewsArticle.Title
+	}
+
+	return s, nil
+}
+
+// Name returns the name of the store.
+func (s *Store) Name() string {
+	return s.name
+}
+
+// List returns the count most recently published articles without their body,
+// along with the store version and modification time.
+func (s *Store) List(count int) (*listInformation, int, time.Time) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	items := make([]domain.HullArticle, 0, len(s.articles))
+	for _, a := range s.articles {
+		a.BodyText = ""
+		items = append(items, a)
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].PublishDate == items[j].PublishDate {
+			return items[i].NewsArticleID > items[j].NewsArticleID
+		}
+
+		return items[i].PublishDate > items[j].PublishDate
+	})
+
+	if count > 0 && len(items) > count {
+		items = items[:count]
+	}
+
+	return &listInformation{
+		ClubName:       s.clubName,
+		ClubWebsiteURL: s.clubURL,
+		Items:          items,
+	}, s.version, s.modified
+}
+
+// Get returns the detail document of the article.
+func (s *Store) Get(id string) (*articleInformation, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	a, ok := s.articles[id]
+	if !ok {
+		return nil, false
+	}
+
+	return &articleInformation{
+		ClubName:       s.clubName,
+		ClubWebsiteURL: s.clubURL,
+		NewsArticle:    a,
+	}, true
+}
+
+// Churn applies a random change to the store: an article is updated, added or withdrawn.
+// It returns a description of the change.
+func (s *Store) Churn(r *rand.Rand, now time.Time) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make([]string, 0, len(s.articles))
+	for id := range s.articles {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	a := s.articles[ids[r.Intn(len(ids))]]
+	title := s.titles[a.NewsArticleID]
+	date := now.UTC().Format(dateLayout)
+
+	var change string
+
+	switch n := r.Intn(10); {
+	case n < 6:
+		a.Title = fmt.Sprintf("%s (updated %s)", title, now.UTC().Format("15:04:05"))
+		a.LastUpdateDate = date
+		change = "updated " + a.NewsArticleID
+	case n < 9:
+		a.NewsArticleID = s.nextID(ids)
+		a.Title = fmt.Sprintf("%s (%s)", title, a.NewsArticleID)
+		s.titles[a.NewsArticleID] = title
+		a.PublishDate = date
+		a.LastUpdateDate = date
+		a.IsPublished = true
+		change = "added " + a.NewsArticleID
+	default:
+		a.IsPublished = !a.IsPublished
+		a.LastUpdateDate = date
+		change = fmt.Sprintf("set %s published to %v", a.NewsArticleID, a.IsPublished)
+	}
+
+	s.articles[a.NewsArticleID] = a
+	s.version++
+	s.modified = now.UTC().Truncate(time.Second)
+
+	return change
+}
+
+// nextID returns an id after the largest numeric id of the store.
+func (s *Store) nextID(ids []string) string {
+	next := 1
+	for _, id := range ids {
+		if n, err := strconv.Atoi(id); err == nil && n >= next {
+			next = n + 1
+		}
+	}
+
+	return strconv.Itoa(next)
+}
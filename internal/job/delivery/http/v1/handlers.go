@@ -0,0 +1,50 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/KarolosLykos/sportsnews/domain"
+	"github.com/KarolosLykos/sportsnews/internal/job"
+	httperrors "github.com/KarolosLykos/sportsnews/internal/utils/http_errors"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+type jobHandler struct {
+	logger    logger.Logger
+	scheduler job.Scheduler
+}
+
+func NewJobHandler(logger logger.Logger, scheduler job.Scheduler) *jobHandler {
+	return &jobHandler{
+		logger:    logger,
+		scheduler: scheduler,
+	}
+}
+
+func (h *jobHandler) List() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return c.JSON(http.StatusOK, h.scheduler.Jobs().ToRest())
+	}
+}
+
+// Run resyncs the provider of the job now. The run is accepted and made in the background.
+func (h *jobHandler) Run() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		err := h.scheduler.Run(c.Param("name"))
+
+		switch {
+		case errors.Is(err, domain.ErrUnknownJob):
+			return httperrors.ErrorResponse(c, fmt.Errorf("%w:%v", httperrors.ErrNotFound, err))
+		case errors.Is(err, domain.ErrJobRunning):
+			return httperrors.ErrorResponse(c, fmt.Errorf("%w:%v", httperrors.ErrConflict, err))
+		case err != nil:
+			return httperrors.ErrorResponse(c, err)
+		}
+
+		return c.NoContent(http.StatusAccepted)
+	}
+}
@@ -0,0 +1,115 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+	mock "github.com/KarolosLykos/sportsnews/internal/job/mock"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+func TestJobHandler_List(t *testing.T) {
+	log := getLogger()
+
+	lastRun := time.Date(2023, time.March, 11, 10, 0, 0, 0, time.UTC)
+	nextRun := lastRun.Add(30 * time.Minute)
+
+	jobs := domain.Jobs{
+		{
+			Name:         "hullcity",
+			Schedule:     "every 30m0s",
+			RunCount:     1,
+			LastRun:      &lastRun,
+			LastDuration: "2s",
+			NextRun:      &nextRun,
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := mock.NewMockScheduler(ctrl)
+	s.EXPECT().Jobs().Times(1).Return(jobs)
+
+	h := NewJobHandler(log, s)
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/jobs", nil)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	list := h.List()
+	err := list(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	res := &domain.JobsRest{}
+	err = json.NewDecoder(rec.Body).Decode(res)
+	require.NoError(t, err)
+
+	assert.Equal(t, "success", res.Status)
+	assert.Equal(t, jobs, res.Data)
+}
+
+func TestJobHandler_Run(t *testing.T) {
+	tt := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{name: "started", code: http.StatusAccepted},
+		{name: "unknown", err: fmt.Errorf("%w:hullcity", domain.ErrUnknownJob), code: http.StatusNotFound},
+		{name: "running", err: fmt.Errorf("%w:hullcity", domain.ErrJobRunning), code: http.StatusConflict},
+		{name: "error", err: errors.New("something went wrong"), code: http.StatusInternalServerError},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := mock.NewMockScheduler(ctrl)
+			s.EXPECT().Run("hullcity").Times(1).Return(tc.err)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/jobs/hullcity/run", nil)
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+			c.SetParamNames("name")
+			c.SetParamValues("hullcity")
+
+			require.NoError(t, NewJobHandler(getLogger(), s).Run()(c))
+			assert.Equal(t, tc.code, rec.Code, rec.Body.String())
+		})
+	}
+}
+
+func getLogger() logger.Logger {
+	cfg := &config.Config{}
+
+	l := &logrus.Logger{
+		Out:          io.Discard,
+		Hooks:        make(logrus.LevelHooks),
+		ReportCaller: false,
+		ExitFunc:     os.Exit,
+		Level:        logrus.InfoLevel,
+		Formatter:    &logrus.JSONFormatter{},
+	}
+
+	return logger.New(cfg, l)
+}
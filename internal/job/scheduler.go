@@ -0,0 +1,12 @@
+package job
+
+import (
+	"github.com/KarolosLykos/sportsnews/domain"
+)
+
+type Scheduler interface {
+	Jobs() domain.Jobs
+	// Run runs the job with the name now, in the background. It returns domain.ErrUnknownJob and
+	// domain.ErrJobRunning when the job cannot be run.
+	Run(name string) error
+}
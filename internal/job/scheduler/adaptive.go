@@ -0,0 +1,85 @@
+package scheduler
+
+import (
+	"fmt"
+	"time"
+)
+
+// adaptive picks a polling interval between min and max from the rate of
+// new or changed articles seen over the last window runs. It aims for
+// target changes per poll: a busy feed is polled more often, a quiet one less.
+type adaptive struct {
+	min     time.Duration
+	max     time.Duration
+	target  float64
+	window  int
+	samples []sample
+}
+
+// sample is the outcome of a single run.
+type sample struct {
+	changes int
+	elapsed time.Duration
+}
+
+// decision is the interval picked after a run and why.
+type decision struct {
+	interval time.Duration
+	rate     float64
+	reason   string
+}
+
+func newAdaptive(minimum, maximum time.Duration, target float64, window int) *adaptive {
+	return &adaptive{
+		min:    minimum,
+		max:    maximum,
+		target: target,
+		window: window,
+	}
+}
+
+// observe records a run that saw changes articles over elapsed time and returns the next interval.
+func (a *adaptive) observe(changes int, elapsed time.Duration) decision {
+	a.samples = append(a.samples, sample{changes: changes, elapsed: elapsed})
+	if len(a.samples) > a.window {
+		a.samples = a.samples[len(a.samples)-a.window:]
+	}
+
+	var (
+		total int
+		span  time.Duration
+	)
+
+	for _, s := range a.samples {
+		total += s.changes
+		span += s.elapsed
+	}
+
+	if total == 0 || span <= 0 {
+		return decision{
+			interval: a.max,
+			reason:   fmt.Sprintf("no changes in last %d runs", len(a.samples)),
+		}
+	}
+
+	rate := float64(total) / span.Hours()
+	interval := a.clamp(time.Duration(a.target / rate * float64(time.Hour)).Round(time.Second))
+
+	return decision{
+		interval: interval,
+		rate:     rate,
+		reason:   fmt.Sprintf("%d changes in last %d runs (%.2f/h)", total, len(a.samples), rate),
+	}
+}
+
+// clamp bounds d to [min, max].
+func (a *adaptive) clamp(d time.Duration) time.Duration {
+	switch {
+	case d < a.min:
+		return a.min
+	case d > a.max:
+		return a.max
+	}
+
+	return d
+}
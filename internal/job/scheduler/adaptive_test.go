@@ -0,0 +1,56 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdaptive_Observe(t *testing.T) {
+	type run struct {
+		changes int
+		elapsed time.Duration
+	}
+
+	tt := []struct {
+		name     string
+		runs     []run
+		expected time.Duration
+	}{
+		{
+			name:     "no changes backs off to max",
+			runs:     []run{{0, 30 * time.Minute}, {0, 30 * time.Minute}},
+			expected: 2 * time.Hour,
+		},
+		{
+			name:     "busy feed speeds up to min",
+			runs:     []run{{10, 30 * time.Minute}},
+			expected: 5 * time.Minute,
+		},
+		{
+			name:     "rate within range",
+			runs:     []run{{1, 30 * time.Minute}, {1, 30 * time.Minute}},
+			expected: 30 * time.Minute,
+		},
+		{
+			name:     "old runs leave the window",
+			runs:     []run{{10, 30 * time.Minute}, {0, 30 * time.Minute}, {0, 30 * time.Minute}, {0, 30 * time.Minute}},
+			expected: 2 * time.Hour,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			a := newAdaptive(5*time.Minute, 2*time.Hour, 1, 3)
+
+			var d decision
+			for _, r := range tc.runs {
+				d = a.observe(r.changes, r.elapsed)
+			}
+
+			assert.Equal(t, tc.expected, d.interval)
+			assert.NotEmpty(t, d.reason)
+		})
+	}
+}
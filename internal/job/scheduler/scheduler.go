@@ -0,0 +1,438 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/go-co-op/gocron"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+	"github.com/KarolosLykos/sportsnews/internal/article"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+	"github.com/KarolosLykos/sportsnews/internal/utils/metrics"
+)
+
+var (
+	ErrSchedule   = errors.New("scheduler: invalid schedule")
+	ErrQuietHours = errors.New("scheduler: invalid quiet hours")
+)
+
+type Scheduler struct {
+	logger   logger.Logger
+	location *time.Location
+
+	// cronMu serialises calls to the gocron builder chain.
+	cronMu sync.Mutex
+	cron   *gocron.Scheduler
+
+	mu   sync.RWMutex
+	jobs []*job
+}
+
+func New(logger logger.Logger, location *time.Location) *Scheduler {
+	return &Scheduler{
+		logger:   logger,
+		location: location,
+		cron:     gocron.NewScheduler(location),
+	}
+}
+
+// Add schedules the consumer under the given name.
+func (s *Scheduler) Add(ctx context.Context, name string, schedule config.Schedule, consumer article.Consumer) error {
+	quiet, err := parseQuietHours(schedule.QuietHours)
+	if err != nil {
+		return err
+	}
+
+	if err = validate(schedule); err != nil {
+		return fmt.Errorf("%w:job %s: %v", ErrSchedule, name, err)
+	}
+
+	j := &job{
+		ctx:      ctx,
+		name:     name,
+		schedule: schedule,
+		quiet:    quiet,
+		consumer: consumer,
+		logger:   s.logger,
+		location: s.location,
+		interval: schedule.Frequency,
+		rand:     rand.New(rand.NewSource(time.Now().UnixNano())), //nolint:gosec // jitter does not need a secure source.
+	}
+
+	j.reschedule = func(interval time.Duration) error {
+		return s.reschedule(j, interval)
+	}
+
+	if schedule.Adaptive {
+		j.adaptive = newAdaptive(
+			schedule.MinFrequency,
+			schedule.MaxFrequency,
+			schedule.AdaptiveTarget,
+			schedule.AdaptiveWindow,
+		)
+		j.interval = j.adaptive.clamp(schedule.Frequency)
+	}
+
+	s.cronMu.Lock()
+	defer s.cronMu.Unlock()
+
+	var cron *gocron.Scheduler
+	if schedule.Cron != "" {
+		cron = s.cron.Cron(schedule.Cron)
+	} else {
+		cron = s.cron.Every(j.interval)
+	}
+
+	j.cronJob, err = cron.SingletonMode().Tag(name).Do(j.run, ctx)
+	if err != nil {
+		return fmt.Errorf("%w:%v", ErrSchedule, err)
+	}
+
+	s.mu.Lock()
+	s.jobs = append(s.jobs, j)
+	s.mu.Unlock()
+
+	return nil
+}
+
+// reschedule changes the interval of an already scheduled job.
+func (s *Scheduler) reschedule(j *job, interval time.Duration) error {
+	s.cronMu.Lock()
+	defer s.cronMu.Unlock()
+
+	if _, err := s.cron.Job(j.cronJob).Every(interval).Update(); err != nil {
+		return fmt.Errorf("%w:%v", ErrSchedule, err)
+	}
+
+	return nil
+}
+
+// Start starts the scheduler asynchronously.
+func (s *Scheduler) Start() {
+	s.cron.StartAsync()
+}
+
+// Stop stops the scheduler.
+func (s *Scheduler) Stop() {
+	s.cron.Stop()
+}
+
+// Run runs the job now, skipping its quiet hours and jitter, unless it is running already. The run
+// counts like a scheduled one.
+func (s *Scheduler) Run(name string) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, j := range s.jobs {
+		if j.name != name {
+			continue
+		}
+
+		if !j.syncing.TryLock() {
+			return fmt.Errorf("%w:%s", domain.ErrJobRunning, name)
+		}
+
+		go func() {
+			defer j.syncing.Unlock()
+			j.consume(j.ctx)
+		}()
+
+		return nil
+	}
+
+	return fmt.Errorf("%w:%s", domain.ErrUnknownJob, name)
+}
+
+// Jobs returns the status of every scheduled job.
+func (s *Scheduler) Jobs() domain.Jobs {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	jobs := make(domain.Jobs, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		jobs = append(jobs, j.status())
+	}
+
+	return jobs
+}
+
+// validate checks that the schedule can be run.
+func validate(schedule config.Schedule) error {
+	if schedule.Cron == "" && schedule.Frequency <= 0 {
+		return errors.New("neither a cron expression nor a frequency is set")
+	}
+
+	if !schedule.Adaptive {
+		return nil
+	}
+
+	switch {
+	case schedule.Cron != "":
+		return errors.New("adaptive polling cannot be used with a cron expression")
+	case schedule.MinFrequency <= 0 || schedule.MaxFrequency < schedule.MinFrequency:
+		return fmt.Errorf("invalid adaptive range %v-%v", schedule.MinFrequency, schedule.MaxFrequency)
+	case schedule.AdaptiveWindow < 1:
+		return fmt.Errorf("invalid adaptive window %d", schedule.AdaptiveWindow)
+	case schedule.AdaptiveTarget <= 0:
+		return fmt.Errorf("invalid adaptive target %v", schedule.AdaptiveTarget)
+	}
+
+	return nil
+}
+
+type job struct {
+	// ctx is the context of the scheduled runs, which the runs on demand use too.
+	ctx        context.Context
+	name       string
+	schedule   config.Schedule
+	quiet      *quietHours
+	adaptive   *adaptive
+	consumer   article.Consumer
+	logger     logger.Logger
+	location   *time.Location
+	cronJob    *gocron.Job
+	reschedule func(interval time.Duration) error
+
+	// syncing is held while the consumer runs, so that runs on demand and scheduled runs do not overlap.
+	syncing sync.Mutex
+
+	mu           sync.Mutex
+	rand         *rand.Rand
+	interval     time.Duration
+	lastRun      time.Time
+	lastDuration time.Duration
+	lastResult   *domain.SyncResult
+	runCount     int
+	decision     *decision
+	decidedAt    time.Time
+}
+
+// run consumes the provider feed, honouring quiet hours and jitter.
+func (j *job) run(ctx context.Context) {
+	now := time.Now().In(j.location)
+
+	j.mu.Lock()
+	lastRun := j.lastRun
+	var delay time.Duration
+	if j.schedule.Jitter > 0 {
+		delay = time.Duration(j.rand.Int63n(int64(j.schedule.Jitter)))
+	}
+	j.mu.Unlock()
+
+	if j.quiet.contains(now) && !lastRun.IsZero() && now.Sub(lastRun) < j.schedule.QuietFrequency {
+		j.logger.Debugf(ctx, "job %s: skipping run during quiet hours, last run at %v", j.name, lastRun)
+		return
+	}
+
+	if delay > 0 {
+		j.logger.Debugf(ctx, "job %s: delaying run by %v", j.name, delay)
+
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+
+	if !j.syncing.TryLock() {
+		j.logger.Debugf(ctx, "job %s: skipping run, the job is running already", j.name)
+		return
+	}
+
+	defer j.syncing.Unlock()
+
+	j.consume(ctx)
+}
+
+// consume runs the consumer and records the result of the run.
+func (j *job) consume(ctx context.Context) {
+	j.mu.Lock()
+	lastRun := j.lastRun
+	j.mu.Unlock()
+
+	start := time.Now()
+
+	result, err := j.consumer.Consume(ctx)
+	metrics.ObserveSync(j.name, result, time.Since(start))
+
+	if err != nil {
+		j.logger.Errorf(ctx, err, "job %s: run failed", j.name)
+	} else {
+		j.logger.Infof(
+			ctx,
+			"job %s: %d created, %d updated, %d unchanged, %d failed",
+			j.name, result.Created, result.Updated, result.Unchanged, result.Failed,
+		)
+	}
+
+	j.mu.Lock()
+	elapsed := j.interval
+	if !lastRun.IsZero() {
+		elapsed = start.Sub(lastRun)
+	}
+
+	j.lastRun = start
+	j.lastDuration = time.Since(start)
+	j.lastResult = result
+	j.runCount++
+	j.mu.Unlock()
+
+	if j.adaptive != nil && result != nil {
+		j.adapt(ctx, result.Changed(), elapsed)
+	}
+}
+
+// adapt picks the next poll interval from the changes seen and reschedules the job if it differs.
+func (j *job) adapt(ctx context.Context, changes int, elapsed time.Duration) {
+	j.mu.Lock()
+	d := j.adaptive.observe(changes, elapsed)
+	previous := j.interval
+	j.decision = &d
+	j.decidedAt = time.Now()
+	j.interval = d.interval
+	j.mu.Unlock()
+
+	if d.interval == previous {
+		j.logger.Infof(ctx, "job %s: keeping poll interval at %v: %s", j.name, previous, d.reason)
+		return
+	}
+
+	j.logger.Infof(ctx, "job %s: changing poll interval from %v to %v: %s", j.name, previous, d.interval, d.reason)
+
+	if err := j.reschedule(d.interval); err != nil {
+		j.logger.Warnf(ctx, err, "job %s: could not reschedule", j.name)
+	}
+}
+
+// status returns the current status of the job.
+func (j *job) status() *domain.Job {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	status := &domain.Job{
+		Name:     j.name,
+		Schedule: j.describe(),
+		Quiet:    j.quiet.contains(time.Now().In(j.location)),
+		Running:  j.cronJob.IsRunning(),
+		RunCount: j.runCount,
+	}
+
+	if j.schedule.Cron == "" {
+		status.Interval = j.interval.String()
+	}
+
+	if j.schedule.Jitter > 0 {
+		status.Jitter = j.schedule.Jitter.String()
+	}
+
+	if j.adaptive != nil {
+		status.Adaptive = &domain.AdaptiveStatus{
+			MinFrequency: j.schedule.MinFrequency.String(),
+			MaxFrequency: j.schedule.MaxFrequency.String(),
+		}
+
+		if j.decision != nil {
+			decidedAt := j.decidedAt
+			status.Adaptive.ChangesPerHour = j.decision.rate
+			status.Adaptive.LastDecision = j.decision.reason
+			status.Adaptive.DecidedAt = &decidedAt
+		}
+	}
+
+	if j.quiet != nil {
+		status.QuietHours = j.schedule.QuietHours
+		status.QuietFrequency = j.schedule.QuietFrequency.String()
+	}
+
+	if !j.lastRun.IsZero() {
+		lastRun := j.lastRun
+		status.LastRun = &lastRun
+		status.LastDuration = j.lastDuration.String()
+		status.LastResult = j.lastResult
+	}
+
+	if nextRun := j.cronJob.NextRun(); !nextRun.IsZero() {
+		status.NextRun = &nextRun
+	}
+
+	return status
+}
+
+// describe returns a human-readable description of the job schedule.
+func (j *job) describe() string {
+	switch {
+	case j.schedule.Cron != "":
+		return "cron " + j.schedule.Cron
+	case j.adaptive != nil:
+		return fmt.Sprintf("adaptive every %v-%v", j.schedule.MinFrequency, j.schedule.MaxFrequency)
+	}
+
+	return "every " + j.schedule.Frequency.String()
+}
+
+// quietHours is a daily window, expressed as offsets from midnight.
+// A window whose end is before its start wraps around midnight.
+type quietHours struct {
+	start time.Duration
+	end   time.Duration
+}
+
+// parseQuietHours parses a "HH:MM-HH:MM" window. An empty value disables quiet hours.
+func parseQuietHours(s string) (*quietHours, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	var startH, startM, endH, endM int
+	if _, err := fmt.Sscanf(s, "%d:%d-%d:%d", &startH, &startM, &endH, &endM); err != nil {
+		return nil, fmt.Errorf("%w:%q: %v", ErrQuietHours, s, err)
+	}
+
+	start, err := clock(startH, startM)
+	if err != nil {
+		return nil, fmt.Errorf("%w:%q: %v", ErrQuietHours, s, err)
+	}
+
+	end, err := clock(endH, endM)
+	if err != nil {
+		return nil, fmt.Errorf("%w:%q: %v", ErrQuietHours, s, err)
+	}
+
+	if start == end {
+		return nil, fmt.Errorf("%w:%q: empty window", ErrQuietHours, s)
+	}
+
+	return &quietHours{start: start, end: end}, nil
+}
+
+// contains reports whether t falls within the quiet window.
+func (q *quietHours) contains(t time.Time) bool {
+	if q == nil {
+		return false
+	}
+
+	offset := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+
+	if q.start < q.end {
+		return offset >= q.start && offset < q.end
+	}
+
+	return offset >= q.start || offset < q.end
+}
+
+func clock(hour, minute int) (time.Duration, error) {
+	if hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		return 0, fmt.Errorf("invalid time %02d:%02d", hour, minute)
+	}
+
+	return time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute, nil
+}
@@ -0,0 +1,371 @@
+package scheduler
+
+import (
+	"context"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+	mock "github.com/KarolosLykos/sportsnews/internal/article/mock"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+func TestParseQuietHours(t *testing.T) {
+	tt := []struct {
+		name  string
+		value string
+		in    []string
+		out   []string
+		err   error
+	}{
+		{
+			name: "disabled",
+			out:  []string{"00:00", "12:00", "23:59"},
+		},
+		{
+			name:  "same day",
+			value: "09:30-17:00",
+			in:    []string{"09:30", "12:00", "16:59"},
+			out:   []string{"09:29", "17:00", "23:00"},
+		},
+		{
+			name:  "wraps midnight",
+			value: "23:00-06:00",
+			in:    []string{"23:00", "00:00", "05:59"},
+			out:   []string{"06:00", "12:00", "22:59"},
+		},
+		{
+			name:  "malformed",
+			value: "night",
+			err:   ErrQuietHours,
+		},
+		{
+			name:  "out of range",
+			value: "25:00-06:00",
+			err:   ErrQuietHours,
+		},
+		{
+			name:  "empty window",
+			value: "06:00-06:00",
+			err:   ErrQuietHours,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			q, err := parseQuietHours(tc.value)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			for _, clock := range tc.in {
+				assert.True(t, q.contains(at(t, clock)), clock)
+			}
+
+			for _, clock := range tc.out {
+				assert.False(t, q.contains(at(t, clock)), clock)
+			}
+		})
+	}
+}
+
+func TestScheduler_Add(t *testing.T) {
+	log := getLogger()
+
+	tt := []struct {
+		name     string
+		schedule config.Schedule
+		expected string
+		err      error
+	}{
+		{
+			name:     "interval",
+			schedule: config.Schedule{Frequency: 30 * time.Minute},
+			expected: "every 30m0s",
+		},
+		{
+			name:     "cron takes precedence",
+			schedule: config.Schedule{Cron: "*/5 * * * *", Frequency: 30 * time.Minute},
+			expected: "cron */5 * * * *",
+		},
+		{
+			name:     "invalid cron",
+			schedule: config.Schedule{Cron: "every now and then"},
+			err:      ErrSchedule,
+		},
+		{
+			name:     "no schedule",
+			schedule: config.Schedule{},
+			err:      ErrSchedule,
+		},
+		{
+			name: "adaptive",
+			schedule: config.Schedule{
+				Frequency:      30 * time.Minute,
+				Adaptive:       true,
+				MinFrequency:   5 * time.Minute,
+				MaxFrequency:   time.Hour,
+				AdaptiveWindow: 3,
+				AdaptiveTarget: 1,
+			},
+			expected: "adaptive every 5m0s-1h0m0s",
+		},
+		{
+			name: "adaptive with cron",
+			schedule: config.Schedule{
+				Cron:           "*/5 * * * *",
+				Adaptive:       true,
+				MinFrequency:   5 * time.Minute,
+				MaxFrequency:   time.Hour,
+				AdaptiveWindow: 3,
+				AdaptiveTarget: 1,
+			},
+			err: ErrSchedule,
+		},
+		{
+			name: "adaptive with invalid range",
+			schedule: config.Schedule{
+				Frequency:      30 * time.Minute,
+				Adaptive:       true,
+				MinFrequency:   time.Hour,
+				MaxFrequency:   5 * time.Minute,
+				AdaptiveWindow: 3,
+				AdaptiveTarget: 1,
+			},
+			err: ErrSchedule,
+		},
+		{
+			name:     "invalid quiet hours",
+			schedule: config.Schedule{Frequency: time.Minute, QuietHours: "late"},
+			err:      ErrQuietHours,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := New(log, time.UTC)
+
+			err := s.Add(context.Background(), "test", tc.schedule, mock.NewMockConsumer(ctrl))
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				assert.Empty(t, s.Jobs())
+				return
+			}
+
+			require.NoError(t, err)
+
+			jobs := s.Jobs()
+			require.Len(t, jobs, 1)
+			assert.Equal(t, "test", jobs[0].Name)
+			assert.Equal(t, tc.expected, jobs[0].Schedule)
+			assert.Nil(t, jobs[0].LastRun)
+		})
+	}
+}
+
+func TestJob_Run(t *testing.T) {
+	log := getLogger()
+
+	alwaysQuiet := &quietHours{start: 0, end: 24 * time.Hour}
+
+	tt := []struct {
+		name     string
+		quiet    *quietHours
+		lastRun  time.Duration
+		expected int
+	}{
+		{
+			name:     "first run",
+			expected: 1,
+		},
+		{
+			name:     "first run during quiet hours",
+			quiet:    alwaysQuiet,
+			expected: 1,
+		},
+		{
+			name:     "skipped during quiet hours",
+			quiet:    alwaysQuiet,
+			lastRun:  time.Minute,
+			expected: 0,
+		},
+		{
+			name:     "quiet frequency elapsed",
+			quiet:    alwaysQuiet,
+			lastRun:  3 * time.Hour,
+			expected: 1,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			consumer := mock.NewMockConsumer(ctrl)
+			consumer.EXPECT().Consume(gomock.Any()).Times(tc.expected).Return(&domain.SyncResult{}, nil)
+
+			j := &job{
+				name:     "test",
+				schedule: config.Schedule{Frequency: time.Minute, QuietFrequency: 2 * time.Hour},
+				quiet:    tc.quiet,
+				consumer: consumer,
+				logger:   log,
+				location: time.UTC,
+			}
+
+			if tc.lastRun > 0 {
+				j.lastRun = time.Now().Add(-tc.lastRun)
+			}
+
+			j.run(context.Background())
+
+			assert.Equal(t, tc.expected, j.runCount)
+		})
+	}
+}
+
+func TestScheduler_Run(t *testing.T) {
+	log := getLogger()
+
+	tt := []struct {
+		name    string
+		job     string
+		running bool
+		err     error
+	}{
+		{name: "run", job: "test"},
+		{name: "running", job: "test", running: true, err: domain.ErrJobRunning},
+		{name: "unknown", job: "other", err: domain.ErrUnknownJob},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			done := make(chan struct{})
+
+			consumer := mock.NewMockConsumer(ctrl)
+			if tc.err == nil {
+				consumer.EXPECT().Consume(gomock.Any()).Times(1).
+					DoAndReturn(func(context.Context) (*domain.SyncResult, error) {
+						defer close(done)
+						return &domain.SyncResult{}, nil
+					})
+			}
+
+			j := &job{
+				ctx:      context.Background(),
+				name:     "test",
+				schedule: config.Schedule{Frequency: time.Minute},
+				// The quiet hours are skipped by the runs on demand.
+				quiet:    &quietHours{start: 0, end: 24 * time.Hour},
+				lastRun:  time.Now(),
+				consumer: consumer,
+				logger:   log,
+				location: time.UTC,
+			}
+
+			if tc.running {
+				j.syncing.Lock()
+				defer j.syncing.Unlock()
+			}
+
+			s := New(log, time.UTC)
+			s.jobs = append(s.jobs, j)
+
+			err := s.Run(tc.job)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+				t.Fatal("the job did not run")
+			}
+		})
+	}
+}
+
+func TestJob_Adapt(t *testing.T) {
+	log := getLogger()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := New(log, time.UTC)
+	// Run the job by hand only.
+	s.cron.WaitForScheduleAll()
+
+	consumer := mock.NewMockConsumer(ctrl)
+	consumer.EXPECT().Consume(gomock.Any()).Times(1).Return(&domain.SyncResult{Created: 4, Updated: 2}, nil)
+
+	err := s.Add(context.Background(), "test", config.Schedule{
+		Frequency:      30 * time.Minute,
+		Adaptive:       true,
+		MinFrequency:   5 * time.Minute,
+		MaxFrequency:   time.Hour,
+		AdaptiveWindow: 3,
+		AdaptiveTarget: 1,
+	}, consumer)
+	require.NoError(t, err)
+
+	s.Start()
+	defer s.Stop()
+
+	j := s.jobs[0]
+
+	// 6 changes in 30 minutes is one every 5 minutes.
+	j.run(context.Background())
+
+	status := s.Jobs()[0]
+	assert.Equal(t, "5m0s", status.Interval)
+	require.NotNil(t, status.Adaptive)
+	assert.InDelta(t, 12, status.Adaptive.ChangesPerHour, 0.01)
+	assert.NotEmpty(t, status.Adaptive.LastDecision)
+	require.NotNil(t, status.NextRun)
+	assert.WithinDuration(t, time.Now().Add(5*time.Minute), *status.NextRun, time.Minute)
+}
+
+func at(t *testing.T, clock string) time.Time {
+	t.Helper()
+
+	c, err := time.Parse("15:04", clock)
+	require.NoError(t, err)
+
+	return time.Date(2023, time.March, 11, c.Hour(), c.Minute(), 0, 0, time.UTC)
+}
+
+func getLogger() logger.Logger {
+	cfg := &config.Config{}
+
+	l := &logrus.Logger{
+		Out:          io.Discard,
+		Hooks:        make(logrus.LevelHooks),
+		ReportCaller: false,
+		ExitFunc:     os.Exit,
+		Level:        logrus.InfoLevel,
+		Formatter:    &logrus.JSONFormatter{},
+	}
+
+	return logger.New(cfg, l)
+}
@@ -0,0 +1,28 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/KarolosLykos/sportsnews/internal/provider"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+type providerHandler struct {
+	logger  logger.Logger
+	tracker provider.HealthTracker
+}
+
+func NewProviderHandler(logger logger.Logger, tracker provider.HealthTracker) *providerHandler {
+	return &providerHandler{
+		logger:  logger,
+		tracker: tracker,
+	}
+}
+
+func (h *providerHandler) Health() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return c.JSON(http.StatusOK, h.tracker.Health().ToRest())
+	}
+}
@@ -0,0 +1,81 @@
+package v1
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+	mock "github.com/KarolosLykos/sportsnews/internal/provider/mock"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+func TestProviderHandler_Health(t *testing.T) {
+	log := getLogger()
+
+	lastSuccess := time.Date(2023, time.March, 11, 10, 0, 0, 0, time.UTC)
+
+	healths := domain.ProviderHealths{
+		{
+			Provider:            "hullcity",
+			Status:              domain.ProviderDegraded,
+			Reasons:             []string{"3 consecutive failures"},
+			LastSuccess:         &lastSuccess,
+			ConsecutiveFailures: 3,
+			Requests:            10,
+			ErrorRate:           0.3,
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tracker := mock.NewMockHealthTracker(ctrl)
+	tracker.EXPECT().Health().Times(1).Return(healths)
+
+	h := NewProviderHandler(log, tracker)
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/providers/health", nil)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	health := h.Health()
+	err := health(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	res := &domain.ProviderHealthsRest{}
+	err = json.NewDecoder(rec.Body).Decode(res)
+	require.NoError(t, err)
+
+	assert.Equal(t, "success", res.Status)
+	assert.Equal(t, healths, res.Data)
+}
+
+func getLogger() logger.Logger {
+	cfg := &config.Config{}
+
+	l := &logrus.Logger{
+		Out:          io.Discard,
+		Hooks:        make(logrus.LevelHooks),
+		ReportCaller: false,
+		ExitFunc:     os.Exit,
+		Level:        logrus.InfoLevel,
+		Formatter:    &logrus.JSONFormatter{},
+	}
+
+	return logger.New(cfg, l)
+}
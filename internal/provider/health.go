@@ -0,0 +1,13 @@
+package provider
+
+import (
+	"time"
+
+	"github.com/KarolosLykos/sportsnews/domain"
+)
+
+type HealthTracker interface {
+	RecordRequest(provider string, err error)
+	RecordIngestion(provider string, published, stored time.Time)
+	Health() domain.ProviderHealths
+}
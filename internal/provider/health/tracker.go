@@ -0,0 +1,222 @@
+package health
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+)
+
+// window is the number of recent requests and ingested articles kept per provider.
+const window = 100
+
+type Tracker struct {
+	mu        sync.RWMutex
+	now       func() time.Time
+	started   time.Time
+	providers map[string]*state
+}
+
+func NewTracker() *Tracker {
+	return &Tracker{
+		now:       time.Now,
+		started:   time.Now(),
+		providers: make(map[string]*state),
+	}
+}
+
+// Register sets the thresholds of a provider. Providers that are not registered
+// are tracked with zero thresholds, which disables the corresponding checks.
+func (t *Tracker) Register(provider string, thresholds config.Health) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.get(provider).thresholds = thresholds
+}
+
+// RecordRequest records the outcome of a request made to the provider.
+func (t *Tracker) RecordRequest(provider string, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s := t.get(provider)
+	now := t.now()
+
+	s.requests = push(s.requests, err != nil)
+
+	if err != nil {
+		s.lastFailure = now
+		s.lastError = err.Error()
+		s.consecutiveFailures++
+
+		return
+	}
+
+	s.lastSuccess = now
+	s.consecutiveFailures = 0
+}
+
+// RecordIngestion records that an article published at published was stored at stored.
+// Articles published before the tracker started are a backfill and are not counted.
+func (t *Tracker) RecordIngestion(provider string, published, stored time.Time) {
+	if published.IsZero() || published.Before(t.started) {
+		return
+	}
+
+	lag := stored.Sub(published)
+	if lag < 0 {
+		lag = 0
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s := t.get(provider)
+	s.lags = push(s.lags, lag)
+}
+
+// Health returns the health of every tracked provider, sorted by name.
+func (t *Tracker) Health() domain.ProviderHealths {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	names := make([]string, 0, len(t.providers))
+	for name := range t.providers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	now := t.now()
+	healths := make(domain.ProviderHealths, 0, len(names))
+
+	for _, name := range names {
+		healths = append(healths, t.providers[name].health(name, now))
+	}
+
+	return healths
+}
+
+// get returns the state of the provider, creating it if needed. t.mu must be held.
+func (t *Tracker) get(provider string) *state {
+	s, ok := t.providers[provider]
+	if !ok {
+		s = &state{}
+		t.providers[provider] = s
+	}
+
+	return s
+}
+
+type state struct {
+	thresholds          config.Health
+	lastSuccess         time.Time
+	lastFailure         time.Time
+	lastError           string
+	consecutiveFailures int
+	requests            []bool
+	lags                []time.Duration
+}
+
+func (s *state) health(provider string, now time.Time) *domain.ProviderHealth {
+	h := &domain.ProviderHealth{
+		Provider:            provider,
+		Status:              domain.ProviderHealthy,
+		LastError:           s.lastError,
+		ConsecutiveFailures: s.consecutiveFailures,
+		Requests:            len(s.requests),
+		ErrorRate:           s.errorRate(),
+	}
+
+	if !s.lastSuccess.IsZero() {
+		lastSuccess := s.lastSuccess
+		h.LastSuccess = &lastSuccess
+	}
+
+	if !s.lastFailure.IsZero() {
+		lastFailure := s.lastFailure
+		h.LastFailure = &lastFailure
+	}
+
+	var averageLag time.Duration
+	if len(s.lags) > 0 {
+		var total, maxLag time.Duration
+		for _, lag := range s.lags {
+			total += lag
+			if lag > maxLag {
+				maxLag = lag
+			}
+		}
+
+		averageLag = total / time.Duration(len(s.lags))
+		h.IngestionLag = &domain.IngestionLag{
+			Articles: len(s.lags),
+			Last:     s.lags[len(s.lags)-1].String(),
+			Average:  averageLag.String(),
+			Max:      maxLag.String(),
+		}
+	}
+
+	if len(s.requests) == 0 {
+		h.Status = domain.ProviderUnknown
+		return h
+	}
+
+	th := s.thresholds
+
+	if th.StaleAfter > 0 {
+		switch {
+		case s.lastSuccess.IsZero():
+			h.Reasons = append(h.Reasons, "no successful fetch yet")
+		case now.Sub(s.lastSuccess) > th.StaleAfter:
+			h.Reasons = append(h.Reasons, fmt.Sprintf("last successful fetch older than %v", th.StaleAfter))
+		}
+	}
+
+	if th.MaxFailures > 0 && s.consecutiveFailures >= th.MaxFailures {
+		h.Reasons = append(h.Reasons, fmt.Sprintf("%d consecutive failures", s.consecutiveFailures))
+	}
+
+	if th.MaxErrorRate > 0 && h.ErrorRate > th.MaxErrorRate {
+		h.Reasons = append(h.Reasons, fmt.Sprintf("error rate %.2f above %.2f", h.ErrorRate, th.MaxErrorRate))
+	}
+
+	if th.MaxLag > 0 && averageLag > th.MaxLag {
+		h.Reasons = append(h.Reasons, fmt.Sprintf("average ingestion lag %v above %v", averageLag, th.MaxLag))
+	}
+
+	if len(h.Reasons) > 0 {
+		h.Status = domain.ProviderDegraded
+	}
+
+	return h
+}
+
+// errorRate returns the share of failed requests in the window.
+func (s *state) errorRate() float64 {
+	if len(s.requests) == 0 {
+		return 0
+	}
+
+	var failed int
+	for _, f := range s.requests {
+		if f {
+			failed++
+		}
+	}
+
+	return float64(failed) / float64(len(s.requests))
+}
+
+// push appends v and keeps the last window values.
+func push[T any](values []T, v T) []T {
+	values = append(values, v)
+	if len(values) > window {
+		values = values[len(values)-window:]
+	}
+
+	return values
+}
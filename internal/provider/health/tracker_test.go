@@ -0,0 +1,125 @@
+package health
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+)
+
+func TestTracker_Health(t *testing.T) {
+	thresholds := config.Health{
+		StaleAfter:   time.Hour,
+		MaxLag:       30 * time.Minute,
+		MaxFailures:  3,
+		MaxErrorRate: 0.5,
+	}
+
+	errFetch := errors.New("fetch failed")
+
+	tt := []struct {
+		name     string
+		record   func(tr *Tracker, now *time.Time)
+		status   string
+		failures int
+		rate     float64
+	}{
+		{
+			name:   "no requests",
+			record: func(tr *Tracker, now *time.Time) {},
+			status: domain.ProviderUnknown,
+		},
+		{
+			name: "healthy",
+			record: func(tr *Tracker, now *time.Time) {
+				tr.RecordRequest("test", nil)
+				tr.RecordRequest("test", errFetch)
+				tr.RecordRequest("test", nil)
+				tr.RecordIngestion("test", now.Add(-10*time.Minute), *now)
+			},
+			status: domain.ProviderHealthy,
+			rate:   1.0 / 3,
+		},
+		{
+			name: "stale",
+			record: func(tr *Tracker, now *time.Time) {
+				tr.RecordRequest("test", nil)
+				*now = now.Add(2 * time.Hour)
+			},
+			status: domain.ProviderDegraded,
+		},
+		{
+			name: "consecutive failures",
+			record: func(tr *Tracker, now *time.Time) {
+				for i := 0; i < 5; i++ {
+					tr.RecordRequest("test", nil)
+				}
+				for i := 0; i < 3; i++ {
+					tr.RecordRequest("test", errFetch)
+				}
+			},
+			status:   domain.ProviderDegraded,
+			failures: 3,
+			rate:     3.0 / 8,
+		},
+		{
+			name: "error rate",
+			record: func(tr *Tracker, now *time.Time) {
+				tr.RecordRequest("test", errFetch)
+				tr.RecordRequest("test", errFetch)
+				tr.RecordRequest("test", nil)
+			},
+			status: domain.ProviderDegraded,
+			rate:   2.0 / 3,
+		},
+		{
+			name: "ingestion lag",
+			record: func(tr *Tracker, now *time.Time) {
+				tr.RecordRequest("test", nil)
+				tr.RecordIngestion("test", now.Add(-time.Hour), *now)
+			},
+			status: domain.ProviderDegraded,
+		},
+		{
+			name: "backfill is not counted",
+			record: func(tr *Tracker, now *time.Time) {
+				tr.RecordRequest("test", nil)
+				tr.RecordIngestion("test", tr.started.Add(-24*time.Hour), *now)
+			},
+			status: domain.ProviderHealthy,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			now := time.Date(2023, time.March, 11, 12, 0, 0, 0, time.UTC)
+
+			tr := NewTracker()
+			tr.now = func() time.Time { return now }
+			tr.started = now.Add(-2 * time.Hour)
+			tr.Register("test", thresholds)
+
+			tc.record(tr, &now)
+
+			healths := tr.Health()
+			require.Len(t, healths, 1)
+
+			h := healths[0]
+			assert.Equal(t, "test", h.Provider)
+			assert.Equal(t, tc.status, h.Status, h.Reasons)
+			assert.Equal(t, tc.failures, h.ConsecutiveFailures)
+			assert.InDelta(t, tc.rate, h.ErrorRate, 0.001)
+
+			if tc.status == domain.ProviderDegraded {
+				assert.NotEmpty(t, h.Reasons)
+			} else {
+				assert.Empty(t, h.Reasons)
+			}
+		})
+	}
+}
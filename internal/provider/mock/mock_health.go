@@ -0,0 +1,74 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/provider/health.go
+
+// Package mock_provider is a generated GoMock package.
+package mock_provider
+
+import (
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/KarolosLykos/sportsnews/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockHealthTracker is a mock of HealthTracker interface.
+type MockHealthTracker struct {
+	ctrl     *gomock.Controller
+	recorder *MockHealthTrackerMockRecorder
+}
+
+// MockHealthTrackerMockRecorder is the mock recorder for MockHealthTracker.
+type MockHealthTrackerMockRecorder struct {
+	mock *MockHealthTracker
+}
+
+// NewMockHealthTracker creates a new mock instance.
+func NewMockHealthTracker(ctrl *gomock.Controller) *MockHealthTracker {
+	mock := &MockHealthTracker{ctrl: ctrl}
+	mock.recorder = &MockHealthTrackerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHealthTracker) EXPECT() *MockHealthTrackerMockRecorder {
+	return m.recorder
+}
+
+// Health mocks base method.
+func (m *MockHealthTracker) Health() domain.ProviderHealths {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Health")
+	ret0, _ := ret[0].(domain.ProviderHealths)
+	return ret0
+}
+
+// Health indicates an expected call of Health.
+func (mr *MockHealthTrackerMockRecorder) Health() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Health", reflect.TypeOf((*MockHealthTracker)(nil).Health))
+}
+
+// RecordIngestion mocks base method.
+func (m *MockHealthTracker) RecordIngestion(provider string, published, stored time.Time) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordIngestion", provider, published, stored)
+}
+
+// RecordIngestion indicates an expected call of RecordIngestion.
+func (mr *MockHealthTrackerMockRecorder) RecordIngestion(provider, published, stored interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordIngestion", reflect.TypeOf((*MockHealthTracker)(nil).RecordIngestion), provider, published, stored)
+}
+
+// RecordRequest mocks base method.
+func (m *MockHealthTracker) RecordRequest(provider string, err error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordRequest", provider, err)
+}
+
+// RecordRequest indicates an expected call of RecordRequest.
+func (mr *MockHealthTrackerMockRecorder) RecordRequest(provider, err interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordRequest", reflect.TypeOf((*MockHealthTracker)(nil).RecordRequest), provider, err)
+}
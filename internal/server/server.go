@@ -2,33 +2,77 @@ package server
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
-	"github.com/go-co-op/gocron"
 	"github.com/go-redis/redis/v8"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 
+	articlev1 "github.com/KarolosLykos/sportsnews/api/article/v1"
 	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+	"github.com/KarolosLykos/sportsnews/internal/apikey"
+	apikeygrpc "github.com/KarolosLykos/sportsnews/internal/apikey/delivery/grpc"
+	apikeyv1 "github.com/KarolosLykos/sportsnews/internal/apikey/delivery/http/v1"
+	"github.com/KarolosLykos/sportsnews/internal/apikey/limiter"
+	apikeyrepository "github.com/KarolosLykos/sportsnews/internal/apikey/repository"
+	apikeyusecase "github.com/KarolosLykos/sportsnews/internal/apikey/usecase"
 	"github.com/KarolosLykos/sportsnews/internal/article"
 	"github.com/KarolosLykos/sportsnews/internal/article/consumer"
+	"github.com/KarolosLykos/sportsnews/internal/article/delivery/graphql"
+	articlegrpc "github.com/KarolosLykos/sportsnews/internal/article/delivery/grpc"
 	v1 "github.com/KarolosLykos/sportsnews/internal/article/delivery/http/v1"
+	"github.com/KarolosLykos/sportsnews/internal/article/events"
+	"github.com/KarolosLykos/sportsnews/internal/article/index"
 	"github.com/KarolosLykos/sportsnews/internal/article/repository"
 	"github.com/KarolosLykos/sportsnews/internal/article/usecase"
+	"github.com/KarolosLykos/sportsnews/internal/audit"
+	auditv1 "github.com/KarolosLykos/sportsnews/internal/audit/delivery/http/v1"
+	auditrepository "github.com/KarolosLykos/sportsnews/internal/audit/repository"
+	auditusecase "github.com/KarolosLykos/sportsnews/internal/audit/usecase"
+	"github.com/KarolosLykos/sportsnews/internal/editorial"
+	editorialv1 "github.com/KarolosLykos/sportsnews/internal/editorial/delivery/http/v1"
+	editorialusecase "github.com/KarolosLykos/sportsnews/internal/editorial/usecase"
+	"github.com/KarolosLykos/sportsnews/internal/job"
+	jobv1 "github.com/KarolosLykos/sportsnews/internal/job/delivery/http/v1"
+	"github.com/KarolosLykos/sportsnews/internal/job/scheduler"
+	"github.com/KarolosLykos/sportsnews/internal/provider"
+	providerv1 "github.com/KarolosLykos/sportsnews/internal/provider/delivery/http/v1"
+	"github.com/KarolosLykos/sportsnews/internal/provider/health"
+	"github.com/KarolosLykos/sportsnews/internal/utils/jwtauth"
 	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+	"github.com/KarolosLykos/sportsnews/internal/utils/metrics"
+	"github.com/KarolosLykos/sportsnews/internal/utils/openapi"
+	"github.com/KarolosLykos/sportsnews/internal/webhook"
+	webhookv1 "github.com/KarolosLykos/sportsnews/internal/webhook/delivery/http/v1"
+	"github.com/KarolosLykos/sportsnews/internal/webhook/dispatcher"
+	webhookrepository "github.com/KarolosLykos/sportsnews/internal/webhook/repository"
+	webhookusecase "github.com/KarolosLykos/sportsnews/internal/webhook/usecase"
 )
 
+// grpcShutdownTimeout is how long the gRPC server waits for the calls in flight when it shuts down.
+const grpcShutdownTimeout = 5 * time.Second
+
 type Server struct {
 	cfg    *config.Config
 	logger logger.Logger
 
 	httpServer *echo.Echo
+	grpcServer *grpc.Server
+	grpcHealth *grpchealth.Server
 
 	mongoDB     *mongo.Client
 	redisClient *redis.Client
@@ -54,24 +98,81 @@ func (s *Server) Run() error {
 
 	// Create new mongo repository
 	mongoRepo := repository.NewMongoRepository(s.mongoDB, s.logger)
+	if err := mongoRepo.EnsureIndexes(ctx); err != nil {
+		s.logger.Warn(ctx, err, "could not create article indexes")
+	}
+	// Create new search index, when the embedded search engine is selected.
+	articleRepo, searchIndex, err := s.createSearch(ctx, mongoRepo)
+	if err != nil {
+		return err
+	}
+	if closer, ok := searchIndex.(io.Closer); ok {
+		defer closer.Close()
+	}
+	// Publish the changes to the articles to the subscribers of every replica.
+	articleEvents := events.NewRedisEvents(s.cfg, s.logger, s.redisClient)
+	go articleEvents.Run(ctx)
+	articleRepo = events.NewPublishingRepository(s.logger, articleRepo, articleEvents)
+	// Deliver the article events to the webhooks.
+	webhookUC := s.createWebhooks(ctx, articleEvents)
+	// Create new api key useCase, with the limits of the keys.
+	apiKeyUC, apiKeyLimiter := s.createAPIKeys(ctx)
+	// Create new audit useCase, recording the actions of the operators of the admin routes.
+	auditUC := s.createAudit(ctx)
 	// Create new redis cache.
 	redisCache := repository.NewCacheRepository(s.cfg, s.logger, s.redisClient)
 	// Create new article useCase.
-	articleUC := usecase.New(s.logger, mongoRepo, redisCache)
+	articleUC := usecase.New(s.logger, articleRepo, redisCache)
+	// Create new editorial useCase, writing the in-house articles next to the provider ones.
+	editorialUC := editorialusecase.New(s.logger, articleRepo, redisCache)
+	// Create new provider health tracker.
+	healthTracker := health.NewTracker()
+	healthTracker.Register(domain.HullCityProvider, s.cfg.Consumer.HullConsumer.Health())
 	// Create new hullCity consumer.
-	hullCityConsumer := consumer.NewHullCityConsumer(s.cfg, s.logger, http.DefaultClient, mongoRepo, redisCache)
+	hullCityConsumer := consumer.NewHullCityConsumer(
+		s.cfg,
+		s.logger,
+		http.DefaultClient,
+		articleRepo,
+		redisCache,
+		healthTracker,
+	)
 
-	// Setup cron.
-	cron := gocron.NewScheduler(time.UTC)
-	hullJob, err := cron.Every(s.cfg.Consumer.HullConsumer.Frequency).Do(hullCityConsumer.Consume, ctx)
+	// Setup scheduler.
+	location, err := time.LoadLocation(s.cfg.Scheduler.Timezone)
 	if err != nil {
-		s.logger.Warnf(ctx, err, "Job: %v, Error: %v", hullJob, err)
+		return err
+	}
+
+	jobScheduler := scheduler.New(s.logger, location)
+	if err = jobScheduler.Add(
+		ctx,
+		domain.HullCityProvider,
+		s.cfg.Consumer.HullConsumer.Schedule(),
+		hullCityConsumer,
+	); err != nil {
+		s.logger.Warnf(ctx, err, "could not schedule job: %s", domain.HullCityProvider)
 		cancel()
 	}
 
-	cron.StartAsync()
+	jobScheduler.Start()
+
+	s.httpServer, err = s.createHTTP(
+		articleUC,
+		editorialUC,
+		articleEvents,
+		webhookUC,
+		apiKeyUC,
+		apiKeyLimiter,
+		auditUC,
+		jobScheduler,
+		healthTracker,
+		searchIndex,
+	)
+	if err != nil {
+		return err
+	}
 
-	s.httpServer = s.createHTTP(articleUC)
 	go func() {
 		s.logger.Infof(ctx, "http server listening on port: %s", s.cfg.HTTP.Port)
 		if err := s.httpServer.Start(s.cfg.HTTP.Port); err != nil {
@@ -80,6 +181,15 @@ func (s *Server) Run() error {
 		}
 	}()
 
+	s.createGRPC(articleUC, articleEvents, apiKeyUC, apiKeyLimiter)
+	go func() {
+		s.logger.Infof(ctx, "grpc server listening on port: %s", s.cfg.GRPC.Port)
+		if err := s.serveGRPC(); err != nil {
+			s.logger.Warn(ctx, err, "grpc server error ")
+			cancel()
+		}
+	}()
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGQUIT, syscall.SIGTERM)
 
@@ -92,20 +202,108 @@ func (s *Server) Run() error {
 
 	// Gracefully shutdown servers.
 	s.gracefullyShutdown(ctx)
-	cron.Stop()
+	jobScheduler.Stop()
 
 	return nil
 }
 
+// createSearch returns the repository the articles are stored in and searched with.
+// With the embedded search engine, it is the mongo repository wrapped to keep the returned
+// index up to date. The index is built from mongo when it is empty.
+func (s *Server) createSearch(
+	ctx context.Context,
+	mongoRepo article.Repository,
+) (article.Repository, article.Index, error) {
+	switch s.cfg.Search.Engine {
+	case "mongo":
+		return mongoRepo, nil, nil
+	case "bleve":
+	default:
+		return nil, nil, fmt.Errorf("unknown search engine: %s", s.cfg.Search.Engine)
+	}
+
+	searchIndex, err := index.NewBleveIndex(s.cfg, s.logger, mongoRepo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if count, err := searchIndex.DocCount(); err == nil && count == 0 {
+		go func() {
+			if _, err := searchIndex.Reindex(ctx); err != nil {
+				s.logger.Warn(ctx, err, "could not build the search index")
+			}
+		}()
+	}
+
+	return index.NewIndexedRepository(s.logger, mongoRepo, searchIndex), searchIndex, nil
+}
+
+// createWebhooks starts the delivery of the article events to the webhooks, until ctx is done,
+// and returns the use case that manages them.
+func (s *Server) createWebhooks(ctx context.Context, ev article.Events) webhook.UseCase {
+	webhookRepo := webhookrepository.NewMongoRepository(s.cfg, s.mongoDB, s.logger)
+	if err := webhookRepo.EnsureIndexes(ctx); err != nil {
+		s.logger.Warn(ctx, err, "could not create webhook indexes")
+	}
+
+	go dispatcher.NewDispatcher(s.cfg, s.logger, dispatcher.NewClient(), webhookRepo, ev).Run(ctx)
+
+	return webhookusecase.New(s.logger, webhookRepo)
+}
+
+// createAPIKeys returns the use case that manages the api keys, and the limiter of their requests.
+func (s *Server) createAPIKeys(ctx context.Context) (apikey.UseCase, apikey.Limiter) {
+	apiKeyRepo := apikeyrepository.NewMongoRepository(s.mongoDB, s.logger)
+	if err := apiKeyRepo.EnsureIndexes(ctx); err != nil {
+		s.logger.Warn(ctx, err, "could not create api key indexes")
+	}
+
+	apiKeyLimiter := limiter.NewRedisLimiter(s.cfg, s.logger, s.redisClient)
+
+	return apikeyusecase.New(s.cfg, s.logger, apiKeyRepo, apiKeyLimiter), apiKeyLimiter
+}
+
+// createAudit returns the use case of the audit log.
+func (s *Server) createAudit(ctx context.Context) audit.UseCase {
+	auditRepo := auditrepository.NewMongoRepository(s.cfg, s.mongoDB, s.logger)
+	if err := auditRepo.EnsureIndexes(ctx); err != nil {
+		s.logger.Warn(ctx, err, "could not create audit indexes")
+	}
+
+	return auditusecase.New(s.logger, auditRepo)
+}
+
 // createHTTP creates new instance of Echo.
 func (s *Server) createHTTP(
 	uc article.UseCase,
-) *echo.Echo {
+	eu editorial.UseCase,
+	ev article.Events,
+	wu webhook.UseCase,
+	ku apikey.UseCase,
+	kl apikey.Limiter,
+	au audit.UseCase,
+	js job.Scheduler,
+	ht provider.HealthTracker,
+	si article.Index,
+) (*echo.Echo, error) {
+	spec, err := openapi.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	operators, err := jwtauth.New(s.cfg, s.logger)
+	switch {
+	case errors.Is(err, jwtauth.ErrNoKeys):
+		s.logger.Warn(context.Background(), err, "admin routes are not mounted")
+	case err != nil:
+		return nil, err
+	}
+
 	e := echo.New()
 	e.Logger.SetOutput(io.Discard)
+	e.Use(metrics.Middleware())
 	e.Use(middleware.Recover())
 	e.Use(middleware.RequestLoggerWithConfig(middleware.RequestLoggerConfig{
-		LogURI:    true,
 		LogStatus: true,
 		LogValuesFunc: func(c echo.Context, values middleware.RequestLoggerValues) error {
 			s.logger.Infof(
@@ -114,21 +312,131 @@ func (s *Server) createHTTP(
 				values.Status,
 				c.Request().Method,
 				time.Since(values.StartTime),
-				values.URI,
+				apikeyv1.RedactedURI(c.Request()),
 				values.Error,
 			)
 
 			return nil
 		},
 	}))
+	e.Use(spec.Middleware())
+
+	e.GET("/metrics", echo.WrapHandler(metrics.Handler()))
+	e.GET("/openapi.json", spec.Handler())
+	e.GET("/docs", openapi.DocsHandler())
+
+	// Every route below needs credentials when authentication is enabled: an api key with the scope
+	// of the route, or the token of an operator with the role of the admin route.
+	auth := apikeyv1.NewAuthMiddleware(s.cfg, s.logger, ku, kl)
+	read := auth.Require(domain.ScopeRead)
 
-	articleHandler := v1.NewArticleHandler(s.logger, uc)
+	articleHandler := v1.NewArticleHandler(s.cfg, s.logger, uc)
+	streamHandler := v1.NewStreamHandler(s.cfg, s.logger, ev)
+	socketHandler := v1.NewSocketHandler(s.cfg, s.logger, ev)
 
 	group := e.Group("/api/v1/articles")
-	group.GET("/:id", articleHandler.GetByID())
-	group.GET("", articleHandler.List())
+	group.GET("/search", articleHandler.Search(), read)
+	group.GET("/stream", streamHandler.Stream(), read)
+	group.GET("/socket", socketHandler.Socket(), read)
+	group.GET("/slug/:slug", articleHandler.GetBySlug(), read)
+	group.GET("/provider/:provider/:articleID", articleHandler.GetByArticleID(), read)
+	group.GET("/:id", articleHandler.GetByID(), read)
+	group.GET("", articleHandler.List(), read)
+
+	feedHandler := v1.NewFeedHandler(s.cfg, s.logger, uc)
+	e.GET("/feeds/:feed", feedHandler.Feed(), read)
+
+	graphqlHandler := graphql.NewGraphQLHandler(s.cfg, s.logger, uc, ev)
+	e.GET("/graphql", graphqlHandler.Query(), read)
+	e.POST("/graphql", graphqlHandler.Query(), read)
+
+	webhookHandler := webhookv1.NewWebhookHandler(s.logger, wu)
+	manageWebhooks := auth.Require(domain.ScopeWebhooks)
+
+	webhooks := e.Group("/api/v1/webhooks")
+	webhooks.POST("", webhookHandler.Create(), manageWebhooks)
+	webhooks.GET("", webhookHandler.List(), manageWebhooks)
+	webhooks.GET("/:id", webhookHandler.GetByID(), manageWebhooks)
+	webhooks.DELETE("/:id", webhookHandler.Delete(), manageWebhooks)
+	webhooks.POST("/:id/enable", webhookHandler.Enable(), manageWebhooks)
+	webhooks.GET("/:id/deliveries", webhookHandler.Deliveries(), manageWebhooks)
+
+	editorialHandler := editorialv1.NewEditorialHandler(s.logger, eu)
+	edit := auth.Require(domain.ScopeEditorial)
+
+	editorials := e.Group("/api/v1/editorial/articles")
+	editorials.POST("", editorialHandler.Create(), edit)
+	editorials.PUT("/:articleID", editorialHandler.Update(), edit)
+	editorials.DELETE("/:articleID", editorialHandler.Delete(), edit)
+
+	// Admin routes are for operators, who always need a token, and are left out without the keys
+	// to verify it. Their actions are audited.
+	if operators == nil {
+		return e, nil
+	}
+
+	view := operators.Require(domain.RoleViewer)
+	operate := operators.Require(domain.RoleOperator)
+	administer := operators.Require(domain.RoleAdmin)
+	record := auditv1.NewAuditMiddleware(s.logger, au).Record()
+
+	admin := e.Group("/api/v1/admin")
+
+	jobHandler := jobv1.NewJobHandler(s.logger, js)
+	admin.GET("/jobs", jobHandler.List(), view)
+	admin.POST("/jobs/:name/run", jobHandler.Run(), operate, record)
+
+	providerHandler := providerv1.NewProviderHandler(s.logger, ht)
+	admin.GET("/providers/health", providerHandler.Health(), view)
+
+	adminHandler := v1.NewAdminHandler(s.logger, uc)
+	admin.POST("/articles/:id/hide", adminHandler.Hide(), operate, record)
+	admin.DELETE("/cache", adminHandler.PurgeCache(), operate, record)
+
+	if si != nil {
+		indexHandler := v1.NewIndexHandler(s.logger, si)
+		admin.POST("/search/reindex", indexHandler.Reindex(), operate, record)
+	}
 
-	return e
+	apiKeyHandler := apikeyv1.NewAPIKeyHandler(s.cfg, s.logger, ku)
+	admin.POST("/keys", apiKeyHandler.Create(), administer, record)
+	admin.GET("/keys", apiKeyHandler.List(), administer)
+	admin.GET("/keys/:id", apiKeyHandler.GetByID(), administer)
+	admin.POST("/keys/:id/revoke", apiKeyHandler.Revoke(), administer, record)
+	admin.GET("/keys/:id/usage", apiKeyHandler.Usage(), administer)
+
+	auditHandler := auditv1.NewAuditHandler(s.logger, au)
+	admin.GET("/audit", auditHandler.List(), administer)
+
+	return e, nil
+}
+
+// createGRPC creates the gRPC server of the articles, with the health and reflection services. The
+// calls to the articles need an api key with the read scope when authentication is enabled.
+func (s *Server) createGRPC(uc article.UseCase, ev article.Events, ku apikey.UseCase, kl apikey.Limiter) {
+	auth := apikeygrpc.NewAuthInterceptor(s.cfg, s.logger, ku, kl)
+	service := articlev1.ArticleService_ServiceDesc.ServiceName
+
+	s.grpcServer = grpc.NewServer(
+		grpc.ChainUnaryInterceptor(auth.Unary(service, domain.ScopeRead)),
+		grpc.ChainStreamInterceptor(auth.Stream(service, domain.ScopeRead)),
+	)
+	articlev1.RegisterArticleServiceServer(s.grpcServer, articlegrpc.NewArticleServer(s.logger, uc, ev))
+
+	s.grpcHealth = grpchealth.NewServer()
+	s.grpcHealth.SetServingStatus(articlev1.ArticleService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(s.grpcServer, s.grpcHealth)
+
+	reflection.Register(s.grpcServer)
+}
+
+func (s *Server) serveGRPC() error {
+	lis, err := net.Listen("tcp", s.cfg.GRPC.Port)
+	if err != nil {
+		return err
+	}
+
+	return s.grpcServer.Serve(lis)
 }
 
 // gracefullyShutdown gracefully shutdown servers.
@@ -137,5 +445,20 @@ func (s *Server) gracefullyShutdown(ctx context.Context) {
 		s.logger.Warn(ctx, err, "http shutdown server")
 	}
 
+	// Watches only end with their clients, so they are cut after the grace period.
+	s.grpcHealth.Shutdown()
+
+	stopped := make(chan struct{})
+	go func() {
+		s.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(grpcShutdownTimeout):
+		s.grpcServer.Stop()
+	}
+
 	s.logger.Info(ctx, "service exited gracefully")
 }
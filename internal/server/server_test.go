@@ -0,0 +1,86 @@
+package server
+
+import (
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	mock "github.com/KarolosLykos/sportsnews/internal/article/mock"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+	"github.com/KarolosLykos/sportsnews/internal/utils/openapi"
+)
+
+// TestServer_OpenAPI fails when a route is added to or removed from the router without the document.
+func TestServer_OpenAPI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{}
+	cfg.Auth.JWTSecret = "secret"
+
+	s := New(cfg, getLogger(), nil, nil)
+
+	e, err := s.createHTTP(nil, nil, nil, nil, nil, nil, nil, nil, nil, mock.NewMockIndex(ctrl))
+	require.NoError(t, err)
+
+	spec, err := openapi.Load()
+	require.NoError(t, err)
+
+	param := regexp.MustCompile(`:([^/]+)`)
+
+	routes := make([]string, 0, len(e.Routes()))
+	for _, r := range e.Routes() {
+		routes = append(routes, r.Method+" "+param.ReplaceAllString(r.Path, "{$1}"))
+	}
+
+	documented := make([]string, 0, len(routes))
+	for path, item := range spec.Doc().Paths {
+		for method := range item.Operations() {
+			documented = append(documented, strings.ToUpper(method)+" "+path)
+		}
+	}
+
+	sort.Strings(routes)
+	sort.Strings(documented)
+	assert.Equal(t, routes, documented)
+}
+
+// TestServer_AdminWithoutKeys fails when the admin routes are mounted without the keys that verify
+// the tokens of operators.
+func TestServer_AdminWithoutKeys(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := New(&config.Config{}, getLogger(), nil, nil)
+
+	e, err := s.createHTTP(nil, nil, nil, nil, nil, nil, nil, nil, nil, mock.NewMockIndex(ctrl))
+	require.NoError(t, err)
+
+	for _, r := range e.Routes() {
+		assert.False(t, strings.HasPrefix(r.Path, "/api/v1/admin"), r.Path)
+	}
+}
+
+func getLogger() logger.Logger {
+	cfg := &config.Config{}
+
+	l := &logrus.Logger{
+		Out:          io.Discard,
+		Hooks:        make(logrus.LevelHooks),
+		ReportCaller: false,
+		ExitFunc:     os.Exit,
+		Level:        logrus.InfoLevel,
+		Formatter:    &logrus.JSONFormatter{},
+	}
+
+	return logger.New(cfg, l)
+}
@@ -12,6 +12,16 @@ var (
 	ErrInternal   = errors.New("something went wrong")
 	ErrNotFound   = errors.New("not found")
 	ErrBadRequest = errors.New("bad request")
+	// ErrNotAcceptable is returned when none of the media types a request accepts can be produced.
+	ErrNotAcceptable = errors.New("not acceptable")
+	// ErrUnauthorized is returned when a request has no valid credentials.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrForbidden is returned when the credentials of a request do not allow it.
+	ErrForbidden = errors.New("forbidden")
+	// ErrTooManyRequests is returned when a request exceeds the limits of its credentials.
+	ErrTooManyRequests = errors.New("too many requests")
+	// ErrConflict is returned when a request conflicts with the state of what it acts on.
+	ErrConflict = errors.New("conflict")
 )
 
 type RestErr struct {
@@ -36,6 +46,20 @@ func ErrorResponse(ctx echo.Context, err error) error {
 
 func parseError(err error) *RestErr {
 	switch {
+	case errors.Is(err, ErrBadRequest):
+		return NewRestError(http.StatusBadRequest, ErrBadRequest.Error(), err.Error())
+	case errors.Is(err, ErrNotFound):
+		return NewRestError(http.StatusNotFound, ErrNotFound.Error(), err.Error())
+	case errors.Is(err, ErrNotAcceptable):
+		return NewRestError(http.StatusNotAcceptable, ErrNotAcceptable.Error(), err.Error())
+	case errors.Is(err, ErrUnauthorized):
+		return NewRestError(http.StatusUnauthorized, ErrUnauthorized.Error(), err.Error())
+	case errors.Is(err, ErrForbidden):
+		return NewRestError(http.StatusForbidden, ErrForbidden.Error(), err.Error())
+	case errors.Is(err, ErrTooManyRequests):
+		return NewRestError(http.StatusTooManyRequests, ErrTooManyRequests.Error(), err.Error())
+	case errors.Is(err, ErrConflict):
+		return NewRestError(http.StatusConflict, ErrConflict.Error(), err.Error())
 	case strings.Contains(err.Error(), "no documents in result"):
 		return NewRestError(http.StatusNotFound, ErrNotFound.Error(), err.Error())
 	case strings.Contains(err.Error(), "provided hex string is not a valid ObjectID"):
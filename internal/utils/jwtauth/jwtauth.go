@@ -0,0 +1,181 @@
+package jwtauth
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+	httperrors "github.com/KarolosLykos/sportsnews/internal/utils/http_errors"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+const (
+	// operatorKey is the key of the operator of a request in its echo context.
+	operatorKey = "operator"
+	// leeway is the clock skew allowed when the times of a token are checked.
+	leeway = 30 * time.Second
+)
+
+var (
+	ErrNew    = errors.New("jwtauth: new")
+	ErrNoKeys = errors.New("jwtauth: no signing keys")
+	ErrVerify = errors.New("jwtauth: verify")
+)
+
+// Authenticator authenticates the operators of the admin API by the JWT bearer tokens of their
+// requests, and authorises them by the roles the tokens hold.
+type Authenticator struct {
+	cfg    *config.Config
+	logger logger.Logger
+	secret []byte
+	keys   map[string]crypto.PublicKey
+	parser *jwt.Parser
+}
+
+// New returns the authenticator of the signing keys of the configuration: the HMAC secret, the keys
+// of the JWKS file, or both. Operators are authenticated whether API keys are required or not, so
+// ErrNoKeys is returned when neither is configured.
+func New(cfg *config.Config, logger logger.Logger) (*Authenticator, error) {
+	a := &Authenticator{cfg: cfg, logger: logger}
+
+	methods := make([]string, 0)
+
+	if cfg.Auth.JWTSecret != "" {
+		a.secret = []byte(cfg.Auth.JWTSecret)
+		methods = append(methods, "HS256", "HS384", "HS512")
+	}
+
+	if cfg.Auth.JWKSFile != "" {
+		keys, err := readJWKS(cfg.Auth.JWKSFile)
+		if err != nil {
+			return nil, fmt.Errorf("%w:%v", ErrNew, err)
+		}
+
+		a.keys = keys
+		methods = append(methods, "RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512")
+	}
+
+	if len(methods) == 0 {
+		return nil, fmt.Errorf("%w:AUTH_JWT_SECRET or AUTH_JWKS_FILE is required", ErrNoKeys)
+	}
+
+	opts := []jwt.ParserOption{jwt.WithValidMethods(methods), jwt.WithLeeway(leeway)}
+	if cfg.Auth.JWTIssuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Auth.JWTIssuer))
+	}
+
+	if cfg.Auth.JWTAudience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Auth.JWTAudience))
+	}
+
+	a.parser = jwt.NewParser(opts...)
+
+	return a, nil
+}
+
+// Verify returns the operator of the token, which must be signed with one of the keys, be valid
+// now, expire and name its subject.
+func (a *Authenticator) Verify(token string) (*domain.Operator, error) {
+	claims := jwt.MapClaims{}
+	if _, err := a.parser.ParseWithClaims(token, claims, a.key); err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrVerify, err)
+	}
+
+	if exp, err := claims.GetExpirationTime(); err != nil || exp == nil {
+		return nil, fmt.Errorf("%w:token has no expiry", ErrVerify)
+	}
+
+	subject, err := claims.GetSubject()
+	if err != nil || subject == "" {
+		return nil, fmt.Errorf("%w:token has no subject", ErrVerify)
+	}
+
+	return &domain.Operator{Subject: subject, Roles: roles(claims[a.cfg.Auth.RolesClaim])}, nil
+}
+
+// Require lets through the requests of the operators that have the role, and keeps the operator in
+// the context of the request.
+func (a *Authenticator) Require(role domain.Role) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			auth := c.Request().Header.Get(echo.HeaderAuthorization)
+			if len(auth) <= len("Bearer ") || !strings.EqualFold(auth[:len("Bearer ")], "Bearer ") {
+				c.Response().Header().Set(echo.HeaderWWWAuthenticate, "Bearer")
+				return httperrors.ErrorResponse(c, fmt.Errorf("%w:missing bearer token", httperrors.ErrUnauthorized))
+			}
+
+			operator, err := a.Verify(auth[len("Bearer "):])
+			if err != nil {
+				c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Bearer error="invalid_token"`)
+				return httperrors.ErrorResponse(c, fmt.Errorf("%w:%v", httperrors.ErrUnauthorized, err))
+			}
+
+			if !operator.Has(role) {
+				return httperrors.ErrorResponse(c, fmt.Errorf("%w:the %s role is required", httperrors.ErrForbidden, role))
+			}
+
+			c.Set(operatorKey, operator)
+
+			return next(c)
+		}
+	}
+}
+
+// OperatorOf returns the operator of the request, nil before Require let it through.
+func OperatorOf(c echo.Context) *domain.Operator {
+	operator, _ := c.Get(operatorKey).(*domain.Operator)
+
+	return operator
+}
+
+// key returns the key the token is verified with: the secret for HMAC tokens, otherwise the key of
+// the JWKS with the id of the token, or its only key when the token names none.
+func (a *Authenticator) key(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+		if a.secret == nil {
+			return nil, errors.New("hmac tokens are not accepted")
+		}
+
+		return a.secret, nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	if key, ok := a.keys[kid]; ok {
+		return key, nil
+	}
+
+	if kid == "" && len(a.keys) == 1 {
+		for _, key := range a.keys {
+			return key, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown key %q", kid)
+}
+
+// roles reads the roles claim: a list of roles or a string of roles separated by spaces.
+func roles(claim interface{}) []domain.Role {
+	rs := make([]domain.Role, 0)
+
+	switch v := claim.(type) {
+	case string:
+		for _, r := range strings.Fields(v) {
+			rs = append(rs, domain.Role(r))
+		}
+	case []interface{}:
+		for _, r := range v {
+			if s, ok := r.(string); ok {
+				rs = append(rs, domain.Role(s))
+			}
+		}
+	}
+
+	return rs
+}
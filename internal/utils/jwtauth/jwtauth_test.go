@@ -0,0 +1,299 @@
+package jwtauth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+func TestAuthenticator_Verify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	jwks := writeJWKS(t, map[string]interface{}{
+		"keys": []map[string]string{
+			{"kty": "RSA", "kid": "rsa", "use": "sig", "n": encode(rsaKey.N), "e": encode(big.NewInt(int64(rsaKey.E)))},
+			{"kty": "EC", "kid": "ec", "crv": "P-256", "x": encode(ecKey.X), "y": encode(ecKey.Y)},
+			{"kty": "RSA", "kid": "enc", "use": "enc", "n": encode(otherKey.N), "e": encode(big.NewInt(int64(otherKey.E)))},
+		},
+	})
+
+	valid := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"sub":   "jane@example.com",
+			"iss":   "https://id.example.com",
+			"exp":   time.Now().Add(time.Hour).Unix(),
+			"roles": []string{"operator"},
+		}
+	}
+
+	tt := []struct {
+		name   string
+		token  func() string
+		roles  []domain.Role
+		hasErr bool
+	}{
+		{
+			name:  "hmac",
+			token: func() string { return sign(t, jwt.SigningMethodHS256, "", valid(), []byte("secret")) },
+			roles: []domain.Role{domain.RoleOperator},
+		},
+		{
+			name:  "rsa",
+			token: func() string { return sign(t, jwt.SigningMethodRS256, "rsa", valid(), rsaKey) },
+			roles: []domain.Role{domain.RoleOperator},
+		},
+		{
+			name:  "ec",
+			token: func() string { return sign(t, jwt.SigningMethodES256, "ec", valid(), ecKey) },
+			roles: []domain.Role{domain.RoleOperator},
+		},
+		{
+			name: "roles string",
+			token: func() string {
+				claims := valid()
+				claims["roles"] = "viewer admin"
+				return sign(t, jwt.SigningMethodHS256, "", claims, []byte("secret"))
+			},
+			roles: []domain.Role{domain.RoleViewer, domain.RoleAdmin},
+		},
+		{
+			name:   "wrong secret",
+			token:  func() string { return sign(t, jwt.SigningMethodHS256, "", valid(), []byte("other")) },
+			hasErr: true,
+		},
+		{
+			name:   "unknown key",
+			token:  func() string { return sign(t, jwt.SigningMethodRS256, "other", valid(), otherKey) },
+			hasErr: true,
+		},
+		{
+			name:   "encryption key",
+			token:  func() string { return sign(t, jwt.SigningMethodRS256, "enc", valid(), otherKey) },
+			hasErr: true,
+		},
+		{
+			name:   "key of another algorithm",
+			token:  func() string { return sign(t, jwt.SigningMethodES256, "rsa", valid(), ecKey) },
+			hasErr: true,
+		},
+		{
+			name: "expired",
+			token: func() string {
+				claims := valid()
+				claims["exp"] = time.Now().Add(-time.Hour).Unix()
+				return sign(t, jwt.SigningMethodHS256, "", claims, []byte("secret"))
+			},
+			hasErr: true,
+		},
+		{
+			name: "without expiry",
+			token: func() string {
+				claims := valid()
+				delete(claims, "exp")
+				return sign(t, jwt.SigningMethodHS256, "", claims, []byte("secret"))
+			},
+			hasErr: true,
+		},
+		{
+			name: "without subject",
+			token: func() string {
+				claims := valid()
+				delete(claims, "sub")
+				return sign(t, jwt.SigningMethodHS256, "", claims, []byte("secret"))
+			},
+			hasErr: true,
+		},
+		{
+			name: "wrong issuer",
+			token: func() string {
+				claims := valid()
+				claims["iss"] = "https://evil.example.com"
+				return sign(t, jwt.SigningMethodHS256, "", claims, []byte("secret"))
+			},
+			hasErr: true,
+		},
+		{
+			name:   "unsigned",
+			token:  func() string { return sign(t, jwt.SigningMethodNone, "", valid(), jwt.UnsafeAllowNoneSignatureType) },
+			hasErr: true,
+		},
+	}
+
+	cfg := &config.Config{}
+	cfg.Auth.Enabled = true
+	cfg.Auth.JWTSecret = "secret"
+	cfg.Auth.JWKSFile = jwks
+	cfg.Auth.JWTIssuer = "https://id.example.com"
+	cfg.Auth.RolesClaim = "roles"
+
+	a, err := New(cfg, getLogger())
+	require.NoError(t, err)
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			operator, err := a.Verify(tc.token())
+			if tc.hasErr {
+				assert.ErrorIs(t, err, ErrVerify)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "jane@example.com", operator.Subject)
+			assert.Equal(t, tc.roles, operator.Roles)
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	cfg := &config.Config{}
+
+	_, err := New(cfg, getLogger())
+	assert.ErrorIs(t, err, ErrNoKeys)
+
+	cfg.Auth.JWKSFile = filepath.Join(t.TempDir(), "missing.json")
+
+	_, err = New(cfg, getLogger())
+	assert.ErrorIs(t, err, ErrNew)
+
+	cfg.Auth.JWKSFile = ""
+	cfg.Auth.JWTSecret = "secret"
+
+	_, err = New(cfg, getLogger())
+	assert.NoError(t, err)
+}
+
+func TestAuthenticator_Require(t *testing.T) {
+	token := func(roles ...string) string {
+		return sign(t, jwt.SigningMethodHS256, "", jwt.MapClaims{
+			"sub":   "jane@example.com",
+			"exp":   time.Now().Add(time.Hour).Unix(),
+			"roles": roles,
+		}, []byte("secret"))
+	}
+
+	tt := []struct {
+		name     string
+		disabled bool
+		header   string
+		role     domain.Role
+		code     int
+		subject  string
+	}{
+		{name: "api keys not required", disabled: true, role: domain.RoleViewer, code: http.StatusUnauthorized},
+		{name: "api keys not required with token", disabled: true, header: "Bearer " + token("admin"), role: domain.RoleAdmin, code: http.StatusOK, subject: "jane@example.com"},
+		{name: "missing token", role: domain.RoleViewer, code: http.StatusUnauthorized},
+		{name: "api key", header: "Bearer snk_1", role: domain.RoleViewer, code: http.StatusUnauthorized},
+		{name: "role", header: "Bearer " + token("operator"), role: domain.RoleOperator, code: http.StatusOK, subject: "jane@example.com"},
+		{name: "higher role", header: "Bearer " + token("admin"), role: domain.RoleViewer, code: http.StatusOK, subject: "jane@example.com"},
+		{name: "lower role", header: "Bearer " + token("viewer"), role: domain.RoleOperator, code: http.StatusForbidden},
+		{name: "unknown role", header: "Bearer " + token("root"), role: domain.RoleViewer, code: http.StatusForbidden},
+		{name: "without roles", header: "Bearer " + token(), role: domain.RoleViewer, code: http.StatusForbidden},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &config.Config{}
+			cfg.Auth.Enabled = !tc.disabled
+			cfg.Auth.JWTSecret = "secret"
+			cfg.Auth.RolesClaim = "roles"
+
+			a, err := New(cfg, getLogger())
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/jobs", nil)
+			if tc.header != "" {
+				req.Header.Set(echo.HeaderAuthorization, tc.header)
+			}
+
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+
+			var operator *domain.Operator
+			next := func(c echo.Context) error {
+				operator = OperatorOf(c)
+				return c.NoContent(http.StatusOK)
+			}
+
+			require.NoError(t, a.Require(tc.role)(next)(c))
+			assert.Equal(t, tc.code, rec.Code, rec.Body.String())
+
+			if tc.code == http.StatusUnauthorized {
+				assert.NotEmpty(t, rec.Header().Get(echo.HeaderWWWAuthenticate))
+			}
+
+			if tc.code == http.StatusOK {
+				require.NotNil(t, operator)
+				assert.Equal(t, tc.subject, operator.Subject)
+			}
+		})
+	}
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, kid string, claims jwt.MapClaims, key interface{}) string {
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+
+	s, err := token.SignedString(key)
+	require.NoError(t, err)
+
+	return s
+}
+
+func writeJWKS(t *testing.T, set interface{}) string {
+	b, err := json.Marshal(set)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, b, 0o600))
+
+	return path
+}
+
+func encode(i *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(i.Bytes())
+}
+
+func getLogger() logger.Logger {
+	cfg := &config.Config{}
+
+	l := &logrus.Logger{
+		Out:          io.Discard,
+		Hooks:        make(logrus.LevelHooks),
+		ReportCaller: false,
+		ExitFunc:     os.Exit,
+		Level:        logrus.InfoLevel,
+		Formatter:    &logrus.JSONFormatter{},
+	}
+
+	return logger.New(cfg, l)
+}
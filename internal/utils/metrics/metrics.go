@@ -0,0 +1,180 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.mongodb.org/mongo-driver/event"
+
+	"github.com/KarolosLykos/sportsnews/domain"
+)
+
+const namespace = "sportsnews"
+
+var (
+	providerRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "provider",
+		Name:      "requests_total",
+		Help:      "Number of requests made to feed providers.",
+	}, []string{"provider", "operation", "status"})
+
+	providerRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "provider",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of requests made to feed providers.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"provider", "operation", "status"})
+
+	syncArticles = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "sync",
+		Name:      "articles_total",
+		Help:      "Number of articles processed by sync runs, by result.",
+	}, []string{"provider", "result"})
+
+	syncDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "sync",
+		Name:      "run_duration_seconds",
+		Help:      "Duration of sync runs.",
+		Buckets:   []float64{.5, 1, 2.5, 5, 10, 30, 60, 120, 300},
+	}, []string{"provider", "status"})
+
+	cacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "cache",
+		Name:      "requests_total",
+		Help:      "Number of article cache lookups, by result.",
+	}, []string{"result"})
+
+	mongoDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "mongo",
+		Name:      "command_duration_seconds",
+		Help:      "Latency of MongoDB commands.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+	}, []string{"command", "status"})
+
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Number of HTTP requests served.",
+	}, []string{"method", "path", "status"})
+
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of HTTP requests served.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "path", "status"})
+
+	registry = prometheus.NewRegistry()
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		providerRequests,
+		providerRequestDuration,
+		syncArticles,
+		syncDuration,
+		cacheRequests,
+		mongoDuration,
+		httpRequests,
+		httpRequestDuration,
+	)
+}
+
+// Handler returns the handler that exposes the metrics in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// ObserveProviderRequest records a request made to a feed provider.
+// A nil response is recorded as a transport error.
+func ObserveProviderRequest(provider, operation string, res *http.Response, start time.Time) {
+	status := "error"
+	if res != nil {
+		status = strconv.Itoa(res.StatusCode)
+	}
+
+	providerRequests.WithLabelValues(provider, operation, status).Inc()
+	providerRequestDuration.WithLabelValues(provider, operation, status).Observe(time.Since(start).Seconds())
+}
+
+// ObserveSync records the outcome of a sync run. A nil result is recorded as a failed run.
+func ObserveSync(provider string, result *domain.SyncResult, duration time.Duration) {
+	if result == nil {
+		syncDuration.WithLabelValues(provider, "error").Observe(duration.Seconds())
+		return
+	}
+
+	syncDuration.WithLabelValues(provider, "success").Observe(duration.Seconds())
+	syncArticles.WithLabelValues(provider, "created").Add(float64(result.Created))
+	syncArticles.WithLabelValues(provider, "updated").Add(float64(result.Updated))
+	syncArticles.WithLabelValues(provider, "skipped").Add(float64(result.Unchanged))
+	syncArticles.WithLabelValues(provider, "failed").Add(float64(result.Failed))
+}
+
+// CacheHit records an article found in the cache.
+func CacheHit() {
+	cacheRequests.WithLabelValues("hit").Inc()
+}
+
+// CacheMiss records an article missing from the cache.
+func CacheMiss() {
+	cacheRequests.WithLabelValues("miss").Inc()
+}
+
+// CommandMonitor returns a MongoDB command monitor that records command latency.
+func CommandMonitor() *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+			mongoDuration.WithLabelValues(e.CommandName, "success").
+				Observe(time.Duration(e.DurationNanos).Seconds())
+		},
+		Failed: func(_ context.Context, e *event.CommandFailedEvent) {
+			mongoDuration.WithLabelValues(e.CommandName, "error").
+				Observe(time.Duration(e.DurationNanos).Seconds())
+		},
+	}
+}
+
+// Middleware returns an echo middleware that records HTTP request metrics.
+// Requests are labelled by route so that path parameters do not blow up cardinality.
+func Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+
+			err := next(c)
+			if err != nil {
+				c.Error(err)
+			}
+
+			path := c.Path()
+			if path == "" {
+				path = "unmatched"
+			}
+
+			status := strconv.Itoa(c.Response().Status)
+			method := c.Request().Method
+
+			httpRequests.WithLabelValues(method, path, status).Inc()
+			httpRequestDuration.WithLabelValues(method, path, status).Observe(time.Since(start).Seconds())
+
+			return nil
+		}
+	}
+}
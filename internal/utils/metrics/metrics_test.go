@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/sportsnews/domain"
+)
+
+func TestMiddleware(t *testing.T) {
+	e := echo.New()
+	e.Use(Middleware())
+	e.GET("/api/v1/articles/:id", func(c echo.Context) error {
+		if c.Param("id") == "missing" {
+			return echo.NewHTTPError(http.StatusNotFound)
+		}
+
+		return c.NoContent(http.StatusOK)
+	})
+
+	for _, id := range []string{"1", "2", "missing"} {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/articles/"+id, nil))
+	}
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(httpRequests.WithLabelValues(http.MethodGet, "/api/v1/articles/:id", "200")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(httpRequests.WithLabelValues(http.MethodGet, "/api/v1/articles/:id", "404")))
+}
+
+func TestObserveSync(t *testing.T) {
+	ObserveSync("test", &domain.SyncResult{Created: 2, Updated: 1, Unchanged: 5}, time.Second)
+	ObserveSync("test", nil, time.Second)
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(syncArticles.WithLabelValues("test", "created")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(syncArticles.WithLabelValues("test", "updated")))
+	assert.Equal(t, float64(5), testutil.ToFloat64(syncArticles.WithLabelValues("test", "skipped")))
+	assert.Equal(t, 2, testutil.CollectAndCount(syncDuration))
+}
+
+func TestHandler(t *testing.T) {
+	ObserveProviderRequest("test", "list", nil, time.Now())
+	CacheHit()
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `sportsnews_provider_requests_total{operation="list",provider="test",status="error"} 1`)
+	assert.Contains(t, rec.Body.String(), `sportsnews_cache_requests_total{result="hit"}`)
+}
@@ -9,6 +9,7 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/internal/utils/metrics"
 )
 
 var ErrMongoConn = errors.New("mongo connection error")
@@ -21,7 +22,8 @@ func New(ctx context.Context, cfg *config.Config) (*mongo.Client, error) {
 			SetAuth(options.Credential{
 				Username: cfg.MongoDB.Username,
 				Password: cfg.MongoDB.Password,
-			}),
+			}).
+			SetMonitor(metrics.CommandMonitor()),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("%w:%v", ErrMongoConn, err)
@@ -0,0 +1,121 @@
+package openapi
+
+import (
+	"context"
+	_ "embed" // the document.
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/labstack/echo/v4"
+
+	httperrors "github.com/KarolosLykos/sportsnews/internal/utils/http_errors"
+)
+
+var ErrLoad = errors.New("openapi: load")
+
+//go:embed openapi.yaml
+var document []byte
+
+// docsPage renders the document with Swagger UI.
+const docsPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Sportsnews API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>SwaggerUIBundle({url: "/openapi.json", dom_id: "#swagger-ui"});</script>
+</body>
+</html>`
+
+// Spec is the OpenAPI document of the service, with the router that finds the operation of a request.
+type Spec struct {
+	doc    *openapi3.T
+	router routers.Router
+	json   []byte
+}
+
+// Load reads and validates the embedded document.
+func Load() (*Spec, error) {
+	doc, err := openapi3.NewLoader().LoadFromData(document)
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrLoad, err)
+	}
+
+	if err = doc.Validate(context.Background()); err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrLoad, err)
+	}
+
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrLoad, err)
+	}
+
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrLoad, err)
+	}
+
+	return &Spec{doc: doc, router: router, json: b}, nil
+}
+
+// Doc returns the document.
+func (s *Spec) Doc() *openapi3.T {
+	return s.doc
+}
+
+// Handler serves the document as JSON.
+func (s *Spec) Handler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return c.Blob(http.StatusOK, echo.MIMEApplicationJSONCharsetUTF8, s.json)
+	}
+}
+
+// DocsHandler serves a page that renders the document served at /openapi.json.
+func DocsHandler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return c.HTML(http.StatusOK, docsPage)
+	}
+}
+
+// Middleware answers 400 Bad Request to requests whose query, path or header parameters do not
+// match the parameters of their operation. Bodies are left to the handlers, and so are requests
+// of routes the document does not describe, and credentials to the routes.
+func (s *Spec) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+
+			route, pathParams, err := s.router.FindRoute(req)
+			if err != nil {
+				return next(c)
+			}
+
+			input := &openapi3filter.RequestValidationInput{
+				Request:    req,
+				PathParams: pathParams,
+				Route:      route,
+				Options: &openapi3filter.Options{
+					ExcludeRequestBody: true,
+					// The keys are checked by the routes, with the scopes the document cannot express.
+					AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+				},
+			}
+
+			if err = openapi3filter.ValidateRequest(req.Context(), input); err != nil {
+				return httperrors.ErrorResponse(c, fmt.Errorf("%w:%v", httperrors.ErrBadRequest, err))
+			}
+
+			return next(c)
+		}
+	}
+}
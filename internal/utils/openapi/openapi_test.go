@@ -0,0 +1,77 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpec_Middleware(t *testing.T) {
+	spec, err := Load()
+	require.NoError(t, err)
+
+	tt := []struct {
+		name   string
+		target string
+		header map[string]string
+		code   int
+	}{
+		{name: "list", target: "/api/v1/articles?limit=10&teamId=Hull+City&type=Academy&type=Video&typeMatch=all", code: http.StatusOK},
+		{name: "list dates", target: "/api/v1/articles?publishedSince=2023-03-01&publishedUntil=2023-03-02T10:00:00Z", code: http.StatusOK},
+		{name: "list unknown parameter", target: "/api/v1/articles?utm_source=feed", code: http.StatusOK},
+		{name: "list invalid limit", target: "/api/v1/articles?limit=ten", code: http.StatusBadRequest},
+		{name: "list negative offset", target: "/api/v1/articles?offset=-1", code: http.StatusBadRequest},
+		{name: "list invalid type match", target: "/api/v1/articles?typeMatch=some", code: http.StatusBadRequest},
+		{name: "list invalid view", target: "/api/v1/articles?view=short", code: http.StatusBadRequest},
+		{name: "list invalid date", target: "/api/v1/articles?publishedSince=yesterday", code: http.StatusBadRequest},
+		{name: "list invalid boolean", target: "/api/v1/articles?hasVideo=maybe", code: http.StatusBadRequest},
+		{name: "search", target: "/api/v1/articles/search?q=hall&fuzzy=true", code: http.StatusOK},
+		{name: "search without query", target: "/api/v1/articles/search", code: http.StatusBadRequest},
+		{name: "article", target: "/api/v1/articles/6405f896a019b8815f6892c7?format=xml", code: http.StatusOK},
+		{name: "provider article", target: "/api/v1/articles/provider/hullcity/1", code: http.StatusOK},
+		{name: "stream", target: "/api/v1/articles/stream", header: map[string]string{"Last-Event-ID": "42"}, code: http.StatusOK},
+		{name: "stream invalid last event id", target: "/api/v1/articles/stream", header: map[string]string{"Last-Event-ID": "x"}, code: http.StatusBadRequest},
+		{name: "deliveries invalid limit", target: "/api/v1/webhooks/1/deliveries?limit=0", code: http.StatusBadRequest},
+		{name: "key usage invalid days", target: "/api/v1/admin/keys/1/usage?days=0", code: http.StatusBadRequest},
+		{name: "api key in query", target: "/feeds/articles.rss?apiKey=snk_1", code: http.StatusOK},
+		{name: "undocumented route", target: "/api/v2/articles?limit=ten", code: http.StatusOK},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tc.target, nil)
+			for k, v := range tc.header {
+				req.Header.Set(k, v)
+			}
+
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+
+			next := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+
+			require.NoError(t, spec.Middleware()(next)(c))
+			assert.Equal(t, tc.code, rec.Code, rec.Body.String())
+		})
+	}
+}
+
+func TestSpec_Handler(t *testing.T) {
+	spec, err := Load()
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/openapi.json", nil), rec)
+
+	require.NoError(t, spec.Handler()(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	doc := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+	assert.Equal(t, "3.0.3", doc["openapi"])
+	assert.Contains(t, doc["paths"], "/api/v1/articles/{id}")
+}
@@ -0,0 +1,131 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/KarolosLykos/sportsnews/domain"
+	apikeyv1 "github.com/KarolosLykos/sportsnews/internal/apikey/delivery/http/v1"
+	httperrors "github.com/KarolosLykos/sportsnews/internal/utils/http_errors"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+	"github.com/KarolosLykos/sportsnews/internal/webhook"
+)
+
+type webhookHandler struct {
+	logger logger.Logger
+	uc     webhook.UseCase
+}
+
+func NewWebhookHandler(logger logger.Logger, uc webhook.UseCase) *webhookHandler {
+	return &webhookHandler{
+		logger: logger,
+		uc:     uc,
+	}
+}
+
+// Create registers the webhook of the body, owned by the API key of the request. The response holds
+// the secret deliveries are signed with, which is not shown again.
+func (h *webhookHandler) Create() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		w := &domain.Webhook{}
+		if err := c.Bind(w); err != nil {
+			return httperrors.ErrorResponse(c, fmt.Errorf("%w:%v", httperrors.ErrBadRequest, err))
+		}
+
+		if err := w.Validate(); err != nil {
+			return httperrors.ErrorResponse(c, fmt.Errorf("%w:%v", httperrors.ErrBadRequest, err))
+		}
+
+		w.Owner = ownerOf(c)
+
+		created, err := h.uc.Create(c.Request().Context(), w)
+		if err != nil {
+			return httperrors.ErrorResponse(c, err)
+		}
+
+		return c.JSON(http.StatusCreated, created.ToRest())
+	}
+}
+
+func (h *webhookHandler) GetByID() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		w, err := h.uc.GetByID(c.Request().Context(), ownerOf(c), c.Param("id"))
+		if err != nil {
+			return httperrors.ErrorResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, w.ToRest())
+	}
+}
+
+func (h *webhookHandler) List() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		webhooks, err := h.uc.List(c.Request().Context(), ownerOf(c))
+		if err != nil {
+			return httperrors.ErrorResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, webhooks.ToRest())
+	}
+}
+
+func (h *webhookHandler) Delete() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if err := h.uc.Delete(c.Request().Context(), ownerOf(c), c.Param("id")); err != nil {
+			return httperrors.ErrorResponse(c, err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// Enable enables a webhook that was disabled after failing deliveries.
+func (h *webhookHandler) Enable() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		w, err := h.uc.Enable(c.Request().Context(), ownerOf(c), c.Param("id"))
+		if err != nil {
+			return httperrors.ErrorResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, w.ToRest())
+	}
+}
+
+// Deliveries returns the latest deliveries of the webhook, limit of them.
+func (h *webhookHandler) Deliveries() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		limit := domain.DefaultPageSize
+		if s := c.QueryParam("limit"); s != "" {
+			n, err := strconv.Atoi(s)
+			if err != nil || n <= 0 {
+				return httperrors.ErrorResponse(c, fmt.Errorf("%w:limit must be a positive integer", httperrors.ErrBadRequest))
+			}
+
+			limit = n
+		}
+
+		if limit > domain.MaxPageSize {
+			limit = domain.MaxPageSize
+		}
+
+		deliveries, err := h.uc.Deliveries(c.Request().Context(), ownerOf(c), c.Param("id"), limit)
+		if err != nil {
+			return httperrors.ErrorResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, deliveries.ToRest())
+	}
+}
+
+// ownerOf returns the owner of the webhooks of the request: the id of its API key, or every owner
+// when the request was made with the root key or without authentication.
+func ownerOf(c echo.Context) string {
+	if key := apikeyv1.APIKeyOf(c); key != nil {
+		return key.ID
+	}
+
+	return ""
+}
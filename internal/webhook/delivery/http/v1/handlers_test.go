@@ -0,0 +1,255 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+	apikeyv1 "github.com/KarolosLykos/sportsnews/internal/apikey/delivery/http/v1"
+	apikeymock "github.com/KarolosLykos/sportsnews/internal/apikey/mock"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+	mock "github.com/KarolosLykos/sportsnews/internal/webhook/mock"
+)
+
+func TestWebhookHandler_Create(t *testing.T) {
+	tt := []struct {
+		name string
+		body string
+		stub func(uc *mock.MockUseCase)
+		code int
+	}{
+		{
+			name: "created",
+			body: `{"url":"https://partner.example.com/hooks","events":["created","withdrawn"],"teamId":"Hull City"}`,
+			stub: func(uc *mock.MockUseCase) {
+				uc.EXPECT().Create(gomock.Any(), &domain.Webhook{
+					URL:    "https://partner.example.com/hooks",
+					Events: []domain.EventType{domain.EventCreated, domain.EventWithdrawn},
+					TeamID: "Hull City",
+				}).Times(1).Return(&domain.Webhook{ID: "1", URL: "https://partner.example.com/hooks", Secret: "whsec_1"}, nil)
+			},
+			code: http.StatusCreated,
+		},
+		{
+			name: "malformed",
+			body: `{"url":`,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "relative url",
+			body: `{"url":"/hooks"}`,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "loopback address",
+			body: `{"url":"http://127.0.0.1:8080/hooks"}`,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "private address",
+			body: `{"url":"https://10.0.0.12/hooks"}`,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "metadata address",
+			body: `{"url":"http://169.254.169.254/latest/meta-data"}`,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "private ipv6 address",
+			body: `{"url":"http://[fd00::1]/hooks"}`,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "localhost",
+			body: `{"url":"http://localhost:8080/hooks"}`,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "local name",
+			body: `{"url":"http://metadata.google.internal/computeMetadata/v1"}`,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "unqualified name",
+			body: `{"url":"http://redis:6379"}`,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "unknown event",
+			body: `{"url":"https://partner.example.com/hooks","events":["deleted"]}`,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "error",
+			body: `{"url":"https://partner.example.com/hooks"}`,
+			stub: func(uc *mock.MockUseCase) {
+				uc.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("something went wrong"))
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := mock.NewMockUseCase(ctrl)
+			if tc.stub != nil {
+				tc.stub(uc)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+
+			require.NoError(t, NewWebhookHandler(getLogger(), uc).Create()(c))
+			assert.Equal(t, tc.code, rec.Code)
+
+			if tc.code != http.StatusCreated {
+				return
+			}
+
+			res := &domain.WebhookRest{}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), res))
+			assert.Equal(t, "whsec_1", res.Data.Secret)
+		})
+	}
+}
+
+func TestWebhookHandler_List(t *testing.T) {
+	tt := []struct {
+		name  string
+		key   string
+		owner string
+	}{
+		{name: "without authentication"},
+		{name: "root key", key: "root"},
+		{name: "api key", key: "snk_1", owner: "k1"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := mock.NewMockUseCase(ctrl)
+			uc.EXPECT().List(gomock.Any(), tc.owner).Times(1).Return(domain.Webhooks{{ID: "1", Secret: "whsec_1"}}, nil)
+
+			keys := apikeymock.NewMockUseCase(ctrl)
+			limiter := apikeymock.NewMockLimiter(ctrl)
+			key := &domain.APIKey{ID: "k1", Scopes: []domain.Scope{domain.ScopeWebhooks}}
+			keys.EXPECT().Authenticate(gomock.Any(), "snk_1").AnyTimes().Return(key, nil)
+			limiter.EXPECT().Allow(gomock.Any(), key).AnyTimes().Return(&domain.RateLimit{Allowed: true}, nil)
+
+			cfg := &config.Config{}
+			cfg.Auth.Enabled = tc.key != ""
+			cfg.Auth.RootKey = "root"
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/webhooks", nil)
+			req.Header.Set(apikeyv1.HeaderAPIKey, tc.key)
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+
+			auth := apikeyv1.NewAuthMiddleware(cfg, getLogger(), keys, limiter).Require(domain.ScopeWebhooks)
+			require.NoError(t, auth(NewWebhookHandler(getLogger(), uc).List())(c))
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.NotContains(t, rec.Body.String(), "whsec_1")
+		})
+	}
+}
+
+func TestWebhookHandler_Delete(t *testing.T) {
+	tt := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{name: "deleted", code: http.StatusNoContent},
+		{name: "not found", err: errors.New("mongo: no documents in result"), code: http.StatusNotFound},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := mock.NewMockUseCase(ctrl)
+			uc.EXPECT().Delete(gomock.Any(), "", "1").Times(1).Return(tc.err)
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/webhooks/1", nil)
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+
+			require.NoError(t, NewWebhookHandler(getLogger(), uc).Delete()(c))
+			assert.Equal(t, tc.code, rec.Code)
+		})
+	}
+}
+
+func TestWebhookHandler_Deliveries(t *testing.T) {
+	tt := []struct {
+		name  string
+		query string
+		limit int
+		code  int
+	}{
+		{name: "default limit", limit: domain.DefaultPageSize, code: http.StatusOK},
+		{name: "limit", query: "?limit=5", limit: 5, code: http.StatusOK},
+		{name: "capped limit", query: "?limit=1000", limit: domain.MaxPageSize, code: http.StatusOK},
+		{name: "invalid limit", query: "?limit=0", code: http.StatusBadRequest},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := mock.NewMockUseCase(ctrl)
+			if tc.code == http.StatusOK {
+				uc.EXPECT().Deliveries(gomock.Any(), "", "1", tc.limit).Times(1).Return(domain.WebhookDeliveries{}, nil)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/webhooks/1/deliveries"+tc.query, nil)
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+
+			require.NoError(t, NewWebhookHandler(getLogger(), uc).Deliveries()(c))
+			assert.Equal(t, tc.code, rec.Code)
+		})
+	}
+}
+
+func getLogger() logger.Logger {
+	cfg := &config.Config{}
+
+	l := &logrus.Logger{
+		Out:          io.Discard,
+		Hooks:        make(logrus.LevelHooks),
+		ReportCaller: false,
+		ExitFunc:     os.Exit,
+		Level:        logrus.InfoLevel,
+		Formatter:    &logrus.JSONFormatter{},
+	}
+
+	return logger.New(cfg, l)
+}
@@ -0,0 +1,53 @@
+package dispatcher
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/KarolosLykos/sportsnews/domain"
+)
+
+var ErrNonPublicAddress = errors.New("dispatcher: non-public address")
+
+// NewClient returns the client that delivers to webhooks. Webhook URLs are validated when they are
+// created, but their names may resolve to other addresses later on, so the client connects only to
+// public addresses, does not use a proxy and does not follow redirects.
+func NewClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   dialPublic,
+	}
+
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext:           dialer.DialContext,
+			ForceAttemptHTTP2:     true,
+			MaxIdleConns:          100,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ExpectContinueTimeout: time.Second,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// dialPublic refuses to connect to the addresses that are not public, once they are resolved.
+func dialPublic(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("%w:%v", ErrNonPublicAddress, err)
+	}
+
+	if ip := net.ParseIP(host); ip == nil || !domain.PublicIP(ip) {
+		return fmt.Errorf("%w:%s", ErrNonPublicAddress, host)
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package dispatcher
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+
+	_, err := client.Post(srv.URL, "application/json", nil)
+	assert.ErrorIs(t, err, ErrNonPublicAddress)
+
+	req, err := http.NewRequest(http.MethodPost, "https://partner.example.com/hooks", nil)
+	require.NoError(t, err)
+	assert.ErrorIs(t, client.CheckRedirect(req, []*http.Request{req}), http.ErrUseLastResponse)
+}
+
+func TestDialPublic(t *testing.T) {
+	tt := []struct {
+		address string
+		err     bool
+	}{
+		{address: "93.184.216.34:443"},
+		{address: "[2606:2800:220:1:248:1893:25c8:1946]:443"},
+		{address: "127.0.0.1:80", err: true},
+		{address: "[::1]:80", err: true},
+		{address: "10.0.0.1:80", err: true},
+		{address: "172.16.0.1:80", err: true},
+		{address: "192.168.1.1:80", err: true},
+		{address: "169.254.169.254:80", err: true},
+		{address: "100.100.100.200:80", err: true},
+		{address: "0.0.0.0:80", err: true},
+		{address: "[fd00::1]:80", err: true},
+		{address: "[fe80::1]:80", err: true},
+		{address: "[::ffff:127.0.0.1]:80", err: true},
+		{address: "partner.example.com:443", err: true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.address, func(t *testing.T) {
+			err := dialPublic("tcp", tc.address, nil)
+			if tc.err {
+				assert.ErrorIs(t, err, ErrNonPublicAddress)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,349 @@
+package dispatcher
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+	"github.com/KarolosLykos/sportsnews/internal/article"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+	"github.com/KarolosLykos/sportsnews/internal/webhook"
+)
+
+const (
+	HeaderID        = "X-Webhook-Id"
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+
+	// maxResponseSize is the part of a response that is read, so that the connection can be reused.
+	maxResponseSize = 64 << 10
+	// leaseMargin is how long a claimed delivery is left to its replica beyond the timeout of the
+	// attempt, to log it. The delivery is attempted again once the lease expires.
+	leaseMargin = time.Minute
+)
+
+type dispatcher struct {
+	cfg        *config.Config
+	logger     logger.Logger
+	client     *http.Client
+	repository webhook.Repository
+	events     article.Events
+
+	wg sync.WaitGroup
+}
+
+func NewDispatcher(
+	cfg *config.Config,
+	logger logger.Logger,
+	client *http.Client,
+	repository webhook.Repository,
+	events article.Events,
+) *dispatcher {
+	return &dispatcher{
+		cfg:        cfg,
+		logger:     logger,
+		client:     client,
+		repository: repository,
+		events:     events,
+	}
+}
+
+// Run delivers the article events to the webhooks that match them, until ctx is done. When it falls
+// behind the events, it subscribes again and delivers the events it missed from the replay buffer.
+// Failed deliveries are retried from the delivery log once they are due. Every replica runs a
+// dispatcher, the delivery log makes sure each event is delivered once and retried by one replica.
+func (d *dispatcher) Run(ctx context.Context) {
+	defer d.wg.Wait()
+
+	d.wg.Add(1)
+
+	go func() {
+		defer d.wg.Done()
+		d.retry(ctx)
+	}()
+
+	var lastID int64
+
+	for ctx.Err() == nil {
+		events := d.events.Subscribe(ctx)
+
+		if lastID > 0 {
+			missed, err := d.events.Replay(ctx, lastID)
+			if err != nil {
+				d.logger.Warnf(ctx, err, "could not replay the article events after: %d", lastID)
+			}
+
+			for _, e := range missed {
+				lastID = e.ID
+				d.dispatch(ctx, e)
+			}
+		}
+
+		for e := range events {
+			if e.ID <= lastID {
+				continue
+			}
+
+			lastID = e.ID
+			d.dispatch(ctx, e)
+		}
+	}
+}
+
+// dispatch starts the delivery of the event to every active webhook that matches it.
+func (d *dispatcher) dispatch(ctx context.Context, e *domain.ArticleEvent) {
+	webhooks, err := d.repository.Active(ctx)
+	if err != nil {
+		d.logger.Warnf(ctx, err, "could not get the webhooks of event: %d", e.ID)
+		return
+	}
+
+	for _, w := range webhooks {
+		if !w.Matches(e) {
+			continue
+		}
+
+		d.wg.Add(1)
+
+		go func(w *domain.Webhook) {
+			defer d.wg.Done()
+			d.deliver(ctx, w, e)
+		}(w)
+	}
+}
+
+// deliver logs the delivery of the event to the webhook, claimed by this replica, and makes its
+// first attempt.
+func (d *dispatcher) deliver(ctx context.Context, w *domain.Webhook, e *domain.ArticleEvent) {
+	body, err := json.Marshal(e)
+	if err != nil {
+		d.logger.Warnf(ctx, err, "could not encode event: %d", e.ID)
+		return
+	}
+
+	claimed := time.Now().UTC().Add(d.lease())
+	delivery := &domain.WebhookDelivery{
+		WebhookID:   w.ID,
+		EventID:     e.ID,
+		EventType:   e.Type,
+		ArticleID:   e.Article.ID,
+		Status:      domain.DeliveryPending,
+		Attempts:    make([]*domain.WebhookAttempt, 0, d.cfg.Webhook.MaxAttempts),
+		NextAttempt: &claimed,
+		Body:        body,
+		Created:     time.Now().UTC(),
+	}
+
+	if err = d.repository.CreateDelivery(ctx, delivery); err != nil {
+		if !errors.Is(err, domain.ErrDeliveryExists) {
+			d.logger.Warnf(ctx, err, "could not log the delivery of event %d to webhook: %s", e.ID, w.ID)
+		}
+
+		return
+	}
+
+	d.attempt(ctx, w, delivery)
+}
+
+// retry attempts the deliveries that are due every poll interval, until ctx is done.
+func (d *dispatcher) retry(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.Webhook.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.retryDue(ctx)
+		}
+	}
+}
+
+// retryDue claims the deliveries that are due and attempts them again. The deliveries of webhooks
+// that were disabled or removed since, and the ones logged without their body, fail.
+func (d *dispatcher) retryDue(ctx context.Context) {
+	webhooks, err := d.repository.Active(ctx)
+	if err != nil {
+		d.logger.Warn(ctx, err, "could not get the webhooks to retry deliveries to")
+		return
+	}
+
+	active := make(map[string]*domain.Webhook, len(webhooks))
+	for _, w := range webhooks {
+		active[w.ID] = w
+	}
+
+	for ctx.Err() == nil {
+		delivery, err := d.repository.ClaimDue(ctx, time.Now().UTC(), d.lease())
+		if err != nil {
+			d.logger.Warn(ctx, err, "could not claim the deliveries that are due")
+			return
+		}
+
+		if delivery == nil {
+			return
+		}
+
+		w, ok := active[delivery.WebhookID]
+		if !ok || delivery.Body == nil {
+			d.abandon(ctx, delivery)
+			continue
+		}
+
+		d.wg.Add(1)
+
+		go func() {
+			defer d.wg.Done()
+			d.attempt(ctx, w, delivery)
+		}()
+	}
+}
+
+// attempt posts the delivery to the webhook and logs the attempt. A failed attempt is retried after
+// the backoff, until every attempt failed. A webhook is disabled when its deliveries keep failing.
+func (d *dispatcher) attempt(ctx context.Context, w *domain.Webhook, delivery *domain.WebhookDelivery) {
+	a := d.post(ctx, w, delivery.ID, delivery.EventType, delivery.Body)
+	if ctx.Err() != nil {
+		// The attempt was cut short by the shutdown. It is made again once the claim expires.
+		return
+	}
+
+	delivery.Attempts = append(delivery.Attempts, a)
+	delivery.NextAttempt = nil
+
+	switch {
+	case a.StatusCode >= http.StatusOK && a.StatusCode < http.StatusMultipleChoices:
+		delivery.Status = domain.DeliveryDelivered
+	case len(delivery.Attempts) >= d.cfg.Webhook.MaxAttempts:
+		delivery.Status = domain.DeliveryFailed
+	default:
+		next := time.Now().UTC().Add(d.backoff(len(delivery.Attempts)))
+		delivery.NextAttempt = &next
+	}
+
+	if err := d.repository.UpdateDelivery(ctx, delivery); err != nil {
+		d.logger.Warnf(ctx, err, "could not log the delivery of event %d to webhook: %s", delivery.EventID, w.ID)
+	}
+
+	if delivery.Status != domain.DeliveryPending {
+		d.record(ctx, w, delivery)
+	}
+}
+
+// abandon fails the delivery without attempting it again.
+func (d *dispatcher) abandon(ctx context.Context, delivery *domain.WebhookDelivery) {
+	delivery.Status = domain.DeliveryFailed
+	delivery.NextAttempt = nil
+
+	if err := d.repository.UpdateDelivery(ctx, delivery); err != nil {
+		d.logger.Warnf(ctx, err, "could not log the delivery of event %d to webhook: %s", delivery.EventID, delivery.WebhookID)
+	}
+}
+
+// lease returns how long a claimed delivery is left to this replica.
+func (d *dispatcher) lease() time.Duration {
+	return d.cfg.Webhook.Timeout + leaseMargin
+}
+
+// record counts the delivery towards the consecutive failures of the webhook.
+func (d *dispatcher) record(ctx context.Context, w *domain.Webhook, delivery *domain.WebhookDelivery) {
+	if delivery.Status == domain.DeliveryDelivered {
+		if err := d.repository.Succeeded(ctx, w.ID); err != nil {
+			d.logger.Warnf(ctx, err, "could not record the delivery to webhook: %s", w.ID)
+		}
+
+		return
+	}
+
+	disabled, err := d.repository.Failed(ctx, w.ID, d.cfg.Webhook.DisableAfter)
+	if err != nil {
+		d.logger.Warnf(ctx, err, "could not record the failed delivery to webhook: %s", w.ID)
+		return
+	}
+
+	if disabled {
+		d.logger.Infof(ctx, "webhook %s disabled after %d consecutive failed deliveries", w.ID, d.cfg.Webhook.DisableAfter)
+	}
+}
+
+// post makes an attempt to deliver the signed body to the webhook.
+func (d *dispatcher) post(ctx context.Context, w *domain.Webhook, id string, t domain.EventType, body []byte) *domain.WebhookAttempt {
+	start := time.Now()
+	a := &domain.WebhookAttempt{Time: start.UTC()}
+
+	ctx, cancel := context.WithTimeout(ctx, d.cfg.Webhook.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		a.Error = err.Error()
+		a.Duration = time.Since(start).String()
+
+		return a
+	}
+
+	timestamp := strconv.FormatInt(start.Unix(), 10)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderID, id)
+	req.Header.Set(HeaderEvent, string(t))
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(w.Secret, timestamp, body))
+
+	res, err := d.client.Do(req)
+	a.Duration = time.Since(start).String()
+
+	if err != nil {
+		a.Error = err.Error()
+		return a
+	}
+
+	defer res.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, maxResponseSize))
+
+	a.StatusCode = res.StatusCode
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		a.Error = fmt.Sprintf("unexpected status: %s", res.Status)
+	}
+
+	return a
+}
+
+// backoff returns the wait before the retry, doubling from the base up to the max.
+func (d *dispatcher) backoff(retry int) time.Duration {
+	wait := d.cfg.Webhook.RetryBase
+	for i := 1; i < retry && wait < d.cfg.Webhook.RetryMax; i++ {
+		wait *= 2
+	}
+
+	if wait > d.cfg.Webhook.RetryMax {
+		return d.cfg.Webhook.RetryMax
+	}
+
+	return wait
+}
+
+// Sign returns the signature of a delivery: the hex HMAC-SHA256, keyed with the secret of the webhook,
+// of the timestamp, a dot and the body.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,366 @@
+package dispatcher
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+	mock "github.com/KarolosLykos/sportsnews/internal/article/mock"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+	webhookmock "github.com/KarolosLykos/sportsnews/internal/webhook/mock"
+)
+
+func TestDispatcher_Deliver(t *testing.T) {
+	event := &domain.ArticleEvent{ID: 7, Type: domain.EventCreated, Article: &domain.Article{ID: "1", TeamID: "Hull City"}}
+
+	tt := []struct {
+		name   string
+		code   int
+		exists bool
+		status string
+	}{
+		{
+			name:   "delivered",
+			code:   http.StatusOK,
+			status: domain.DeliveryDelivered,
+		},
+		{
+			name:   "retried later",
+			code:   http.StatusInternalServerError,
+			status: domain.DeliveryPending,
+		},
+		{
+			name:   "delivered by another replica",
+			exists: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			var (
+				requests []*http.Request
+				bodies   [][]byte
+			)
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := io.ReadAll(r.Body)
+				requests = append(requests, r)
+				bodies = append(bodies, b)
+				w.WriteHeader(tc.code)
+			}))
+			defer srv.Close()
+
+			hook := &domain.Webhook{ID: "w1", URL: srv.URL, Secret: "whsec_test"}
+
+			repo := webhookmock.NewMockRepository(ctrl)
+			if tc.exists {
+				repo.EXPECT().CreateDelivery(gomock.Any(), gomock.Any()).Times(1).Return(domain.ErrDeliveryExists)
+			} else {
+				repo.EXPECT().CreateDelivery(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, d *domain.WebhookDelivery) error {
+						assert.Equal(t, "w1", d.WebhookID)
+						assert.Equal(t, int64(7), d.EventID)
+						assert.Equal(t, domain.DeliveryPending, d.Status)
+						assert.NotEmpty(t, d.Body)
+						// The delivery is claimed by this replica while it is attempted.
+						require.NotNil(t, d.NextAttempt)
+						assert.WithinDuration(t, time.Now().Add(time.Second+leaseMargin), *d.NextAttempt, time.Second)
+						d.ID = "d1"
+
+						return nil
+					})
+				repo.EXPECT().UpdateDelivery(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, d *domain.WebhookDelivery) error {
+						assert.Equal(t, tc.status, d.Status)
+						require.Len(t, d.Attempts, 1)
+						assert.Equal(t, tc.code, d.Attempts[0].StatusCode)
+
+						return nil
+					})
+			}
+
+			if tc.status == domain.DeliveryDelivered {
+				repo.EXPECT().Succeeded(gomock.Any(), "w1").Times(1).Return(nil)
+			}
+
+			d := NewDispatcher(getConfig(), getLogger(), srv.Client(), repo, nil)
+			d.deliver(context.Background(), hook, event)
+
+			if tc.exists {
+				assert.Empty(t, requests)
+				return
+			}
+
+			require.Len(t, requests, 1)
+			assert.Equal(t, http.MethodPost, requests[0].Method)
+			assert.Equal(t, "d1", requests[0].Header.Get(HeaderID))
+			assert.Equal(t, "created", requests[0].Header.Get(HeaderEvent))
+			assert.Equal(t, Sign("whsec_test", requests[0].Header.Get(HeaderTimestamp), bodies[0]), requests[0].Header.Get(HeaderSignature))
+
+			got := &domain.ArticleEvent{}
+			require.NoError(t, json.Unmarshal(bodies[0], got))
+			assert.Equal(t, int64(7), got.ID)
+			assert.Equal(t, "1", got.Article.ID)
+		})
+	}
+}
+
+func TestDispatcher_Attempt(t *testing.T) {
+	failed := &domain.WebhookAttempt{StatusCode: http.StatusInternalServerError}
+
+	tt := []struct {
+		name     string
+		previous []*domain.WebhookAttempt
+		code     int
+		status   string
+		next     time.Duration
+		disabled bool
+	}{
+		{
+			name:     "delivered on a retry",
+			previous: []*domain.WebhookAttempt{failed},
+			code:     http.StatusNoContent,
+			status:   domain.DeliveryDelivered,
+		},
+		{
+			name:     "retried after the backoff",
+			previous: []*domain.WebhookAttempt{failed},
+			code:     http.StatusBadGateway,
+			status:   domain.DeliveryPending,
+			next:     2 * time.Millisecond,
+		},
+		{
+			name:     "failed",
+			previous: []*domain.WebhookAttempt{failed, failed},
+			code:     http.StatusGone,
+			status:   domain.DeliveryFailed,
+		},
+		{
+			name:     "failed and disabled",
+			previous: []*domain.WebhookAttempt{failed, failed},
+			code:     http.StatusInternalServerError,
+			status:   domain.DeliveryFailed,
+			disabled: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			var body []byte
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ = io.ReadAll(r.Body)
+				w.WriteHeader(tc.code)
+			}))
+			defer srv.Close()
+
+			hook := &domain.Webhook{ID: "w1", URL: srv.URL, Secret: "whsec_test"}
+			delivery := &domain.WebhookDelivery{
+				ID:        "d1",
+				WebhookID: "w1",
+				EventID:   7,
+				EventType: domain.EventCreated,
+				Status:    domain.DeliveryPending,
+				Attempts:  tc.previous,
+				Body:      []byte(`{"id":7}`),
+			}
+
+			repo := webhookmock.NewMockRepository(ctrl)
+			repo.EXPECT().UpdateDelivery(gomock.Any(), delivery).Times(1).Return(nil)
+
+			switch tc.status {
+			case domain.DeliveryDelivered:
+				repo.EXPECT().Succeeded(gomock.Any(), "w1").Times(1).Return(nil)
+			case domain.DeliveryFailed:
+				repo.EXPECT().Failed(gomock.Any(), "w1", 2).Times(1).Return(tc.disabled, nil)
+			}
+
+			NewDispatcher(getConfig(), getLogger(), srv.Client(), repo, nil).attempt(context.Background(), hook, delivery)
+
+			assert.Equal(t, `{"id":7}`, string(body))
+			assert.Equal(t, tc.status, delivery.Status)
+			assert.Len(t, delivery.Attempts, len(tc.previous)+1)
+			assert.Equal(t, tc.code, delivery.Attempts[len(delivery.Attempts)-1].StatusCode)
+
+			if tc.next == 0 {
+				assert.Nil(t, delivery.NextAttempt)
+				return
+			}
+
+			require.NotNil(t, delivery.NextAttempt)
+			assert.WithinDuration(t, time.Now().Add(tc.next), *delivery.NextAttempt, time.Second)
+		})
+	}
+}
+
+func TestDispatcher_RetryDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var attempted []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempted = append(attempted, r.Header.Get(HeaderID))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	hook := &domain.Webhook{ID: "w1", URL: srv.URL, Secret: "whsec_test"}
+
+	due := &domain.WebhookDelivery{ID: "d1", WebhookID: "w1", Status: domain.DeliveryPending, Body: []byte(`{"id":7}`)}
+	removed := &domain.WebhookDelivery{ID: "d2", WebhookID: "w2", Status: domain.DeliveryPending, Body: []byte(`{"id":7}`)}
+	legacy := &domain.WebhookDelivery{ID: "d3", WebhookID: "w1", Status: domain.DeliveryPending}
+
+	repo := webhookmock.NewMockRepository(ctrl)
+	repo.EXPECT().Active(gomock.Any()).Times(1).Return(domain.Webhooks{hook}, nil)
+	gomock.InOrder(
+		repo.EXPECT().ClaimDue(gomock.Any(), gomock.Any(), time.Second+leaseMargin).Times(1).Return(due, nil),
+		repo.EXPECT().ClaimDue(gomock.Any(), gomock.Any(), time.Second+leaseMargin).Times(1).Return(removed, nil),
+		repo.EXPECT().ClaimDue(gomock.Any(), gomock.Any(), time.Second+leaseMargin).Times(1).Return(legacy, nil),
+		repo.EXPECT().ClaimDue(gomock.Any(), gomock.Any(), time.Second+leaseMargin).Times(1).Return(nil, nil),
+	)
+	repo.EXPECT().UpdateDelivery(gomock.Any(), gomock.Any()).Times(3).Return(nil)
+	repo.EXPECT().Succeeded(gomock.Any(), "w1").Times(1).Return(nil)
+
+	d := NewDispatcher(getConfig(), getLogger(), srv.Client(), repo, nil)
+	d.retryDue(context.Background())
+	d.wg.Wait()
+
+	assert.Equal(t, []string{"d1"}, attempted)
+	assert.Equal(t, domain.DeliveryDelivered, due.Status)
+	assert.Equal(t, domain.DeliveryFailed, removed.Status)
+	assert.Empty(t, removed.Attempts)
+	assert.Equal(t, domain.DeliveryFailed, legacy.Status)
+	assert.Empty(t, legacy.Attempts)
+}
+
+func TestDispatcher_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	hull := &domain.Webhook{ID: "hull", TeamID: "Hull City"}
+	updates := &domain.Webhook{ID: "updates", Events: []domain.EventType{domain.EventUpdated}}
+
+	event := func(id int64, t domain.EventType) *domain.ArticleEvent {
+		return &domain.ArticleEvent{ID: id, Type: t, Article: &domain.Article{ID: "1", TeamID: "Hull City"}}
+	}
+
+	first := make(chan *domain.ArticleEvent, 2)
+	first <- event(1, domain.EventCreated)
+	first <- event(1, domain.EventCreated)
+	close(first)
+
+	second := make(chan *domain.ArticleEvent, 1)
+	second <- event(2, domain.EventUpdated)
+	close(second)
+
+	events := mock.NewMockEvents(ctrl)
+	gomock.InOrder(
+		events.EXPECT().Subscribe(gomock.Any()).Times(1).Return(first),
+		events.EXPECT().Subscribe(gomock.Any()).Times(1).
+			DoAndReturn(func(context.Context) <-chan *domain.ArticleEvent {
+				cancel()
+				return second
+			}),
+	)
+	// The dispatcher fell behind after the first event, the second one is delivered from the replay
+	// and not again from the new subscription.
+	events.EXPECT().Replay(gomock.Any(), int64(1)).Times(1).Return([]*domain.ArticleEvent{event(2, domain.EventUpdated)}, nil)
+
+	repo := webhookmock.NewMockRepository(ctrl)
+	repo.EXPECT().Active(gomock.Any()).Times(2).Return(domain.Webhooks{hull, updates}, nil)
+
+	var mu sync.Mutex
+	delivered := make(map[string][]int64)
+	repo.EXPECT().CreateDelivery(gomock.Any(), gomock.Any()).Times(3).
+		DoAndReturn(func(_ context.Context, d *domain.WebhookDelivery) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			delivered[d.WebhookID] = append(delivered[d.WebhookID], d.EventID)
+
+			return domain.ErrDeliveryExists
+		})
+
+	NewDispatcher(getConfig(), getLogger(), http.DefaultClient, repo, events).Run(ctx)
+
+	// Deliveries run concurrently, in any order.
+	assert.ElementsMatch(t, []int64{1, 2}, delivered["hull"])
+	assert.Equal(t, []int64{2}, delivered["updates"])
+}
+
+func TestDispatcher_Backoff(t *testing.T) {
+	cfg := &config.Config{Webhook: config.WebhookConfig{RetryBase: 30 * time.Second, RetryMax: 5 * time.Minute}}
+	d := NewDispatcher(cfg, getLogger(), http.DefaultClient, nil, nil)
+
+	tt := []struct {
+		retry int
+		wait  time.Duration
+	}{
+		{retry: 1, wait: 30 * time.Second},
+		{retry: 2, wait: time.Minute},
+		{retry: 4, wait: 4 * time.Minute},
+		{retry: 5, wait: 5 * time.Minute},
+		{retry: 100, wait: 5 * time.Minute},
+	}
+
+	for _, tc := range tt {
+		assert.Equal(t, tc.wait, d.backoff(tc.retry), "retry %d", tc.retry)
+	}
+}
+
+func TestSign(t *testing.T) {
+	// echo -n '1700000000.{"id":1}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(t,
+		"sha256=3dd1b9aef568d75f6790a84bd2e5dfa1f44409eef3cbdbd3f10b837376100c11",
+		Sign("secret", "1700000000", []byte(`{"id":1}`)),
+	)
+}
+
+func getConfig() *config.Config {
+	return &config.Config{Webhook: config.WebhookConfig{
+		Timeout:      time.Second,
+		MaxAttempts:  3,
+		RetryBase:    time.Millisecond,
+		RetryMax:     5 * time.Millisecond,
+		PollInterval: time.Hour,
+		DisableAfter: 2,
+	}}
+}
+
+func getLogger() logger.Logger {
+	cfg := &config.Config{}
+
+	l := &logrus.Logger{
+		Out:          io.Discard,
+		Hooks:        make(logrus.LevelHooks),
+		ReportCaller: false,
+		ExitFunc:     os.Exit,
+		Level:        logrus.InfoLevel,
+		Formatter:    &logrus.JSONFormatter{},
+	}
+
+	return logger.New(cfg, l)
+}
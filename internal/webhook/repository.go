@@ -0,0 +1,36 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/KarolosLykos/sportsnews/domain"
+)
+
+// Repository stores the webhooks and their deliveries. The webhooks that take an owner are the
+// webhooks of that owner, of every owner when it is empty.
+type Repository interface {
+	Create(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error)
+	GetByID(ctx context.Context, owner, id string) (*domain.Webhook, error)
+	List(ctx context.Context, owner string) (domain.Webhooks, error)
+	Delete(ctx context.Context, owner, id string) error
+	// Enable enables the webhook again and forgets its failures.
+	Enable(ctx context.Context, owner, id string) (*domain.Webhook, error)
+	// Active returns the webhooks that are not disabled.
+	Active(ctx context.Context) (domain.Webhooks, error)
+	// Succeeded forgets the failures of the webhook.
+	Succeeded(ctx context.Context, id string) error
+	// Failed counts a failed delivery of the webhook and disables it after disableAfter consecutive
+	// failures. It reports whether this failure disabled the webhook.
+	Failed(ctx context.Context, id string, disableAfter int) (bool, error)
+	// CreateDelivery stores a new delivery, or returns domain.ErrDeliveryExists when the event was
+	// delivered to the webhook already.
+	CreateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error
+	// UpdateDelivery stores the status, the attempts and the next attempt of the delivery.
+	UpdateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error
+	// ClaimDue claims the pending delivery that has been due the longest at now until now+lease, so
+	// that no other replica attempts it meanwhile. It returns nil when no delivery is due.
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (*domain.WebhookDelivery, error)
+	// Deliveries returns the latest deliveries of the webhook, newest first.
+	Deliveries(ctx context.Context, id string, limit int) (domain.WebhookDeliveries, error)
+}
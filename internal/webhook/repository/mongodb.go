@@ -0,0 +1,344 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+)
+
+const (
+	sportsNewsDB         = "sportsnews"
+	webhooksCollection   = "webhooks"
+	deliveriesCollection = "webhookDeliveries"
+)
+
+var (
+	ErrCreate         = errors.New("repository: create")
+	ErrGetByID        = errors.New("repository: getByID")
+	ErrList           = errors.New("repository: list")
+	ErrDelete         = errors.New("repository: delete")
+	ErrEnable         = errors.New("repository: enable")
+	ErrActive         = errors.New("repository: active")
+	ErrSucceeded      = errors.New("repository: succeeded")
+	ErrFailed         = errors.New("repository: failed")
+	ErrCreateDelivery = errors.New("repository: createDelivery")
+	ErrUpdateDelivery = errors.New("repository: updateDelivery")
+	ErrClaimDue       = errors.New("repository: claimDue")
+	ErrDeliveries     = errors.New("repository: deliveries")
+	ErrIndexes        = errors.New("repository: indexes")
+)
+
+type mongoRepository struct {
+	cfg    *config.Config
+	logger logger.Logger
+	client *mongo.Client
+}
+
+func NewMongoRepository(cfg *config.Config, client *mongo.Client, logger logger.Logger) *mongoRepository {
+	return &mongoRepository{
+		cfg:    cfg,
+		client: client,
+		logger: logger,
+	}
+}
+
+// EnsureIndexes creates the indexes that do not exist yet: the webhooks of an owner, one delivery
+// per event and webhook, the log of a webhook by time, the deliveries that are due, and the expiry
+// of old deliveries.
+func (m *mongoRepository) EnsureIndexes(ctx context.Context) error {
+	owned := mongo.IndexModel{
+		Keys:    bson.D{{Key: "owner", Value: 1}, {Key: "created", Value: 1}},
+		Options: options.Index().SetName("owner_created"),
+	}
+
+	if _, err := m.webhooksCollection().Indexes().CreateOne(ctx, owned); err != nil {
+		return fmt.Errorf("%w:%v", ErrIndexes, err)
+	}
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "webhookId", Value: 1}, {Key: "eventId", Value: 1}},
+			Options: options.Index().SetName("webhook_event").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "webhookId", Value: 1}, {Key: "created", Value: -1}},
+			Options: options.Index().SetName("webhook_created"),
+		},
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "nextAttempt", Value: 1}},
+			Options: options.Index().SetName("status_next_attempt"),
+		},
+		{
+			Keys: bson.D{{Key: "created", Value: 1}},
+			Options: options.Index().SetName("created_ttl").
+				SetExpireAfterSeconds(int32(m.cfg.Webhook.Retention.Seconds())),
+		},
+	}
+
+	if _, err := m.deliveriesCollection().Indexes().CreateMany(ctx, indexes); err != nil {
+		return fmt.Errorf("%w:%v", ErrIndexes, err)
+	}
+
+	return nil
+}
+
+func (m *mongoRepository) Create(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error) {
+	res, err := m.webhooksCollection().InsertOne(ctx, webhook)
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrCreate, err)
+	}
+
+	created := *webhook
+	if id, ok := res.InsertedID.(primitive.ObjectID); ok {
+		created.ID = id.Hex()
+	}
+
+	return &created, nil
+}
+
+func (m *mongoRepository) GetByID(ctx context.Context, owner, id string) (*domain.Webhook, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrGetByID, err)
+	}
+
+	webhook := &domain.Webhook{}
+	if err = m.webhooksCollection().FindOne(ctx, ownedBy(owner, bson.D{{Key: "_id", Value: objectID}})).Decode(webhook); err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrGetByID, err)
+	}
+
+	return webhook, nil
+}
+
+func (m *mongoRepository) List(ctx context.Context, owner string) (domain.Webhooks, error) {
+	webhooks, err := m.find(ctx, ownedBy(owner, bson.D{}))
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrList, err)
+	}
+
+	return webhooks, nil
+}
+
+// Delete removes the webhook and its delivery log.
+func (m *mongoRepository) Delete(ctx context.Context, owner, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("%w:%v", ErrDelete, err)
+	}
+
+	res, err := m.webhooksCollection().DeleteOne(ctx, ownedBy(owner, bson.D{{Key: "_id", Value: objectID}}))
+	if err != nil {
+		return fmt.Errorf("%w:%v", ErrDelete, err)
+	}
+
+	if res.DeletedCount == 0 {
+		return fmt.Errorf("%w:%v", ErrDelete, mongo.ErrNoDocuments)
+	}
+
+	if _, err = m.deliveriesCollection().DeleteMany(ctx, bson.M{"webhookId": id}); err != nil {
+		return fmt.Errorf("%w:%v", ErrDelete, err)
+	}
+
+	return nil
+}
+
+func (m *mongoRepository) Enable(ctx context.Context, owner, id string) (*domain.Webhook, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrEnable, err)
+	}
+
+	update := bson.D{
+		{Key: "$set", Value: bson.D{{Key: "disabled", Value: false}, {Key: "consecutiveFailures", Value: 0}}},
+		{Key: "$unset", Value: bson.D{{Key: "disabledAt", Value: ""}}},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	webhook := &domain.Webhook{}
+	filter := ownedBy(owner, bson.D{{Key: "_id", Value: objectID}})
+	if err = m.webhooksCollection().FindOneAndUpdate(ctx, filter, update, opts).Decode(webhook); err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrEnable, err)
+	}
+
+	return webhook, nil
+}
+
+func (m *mongoRepository) Active(ctx context.Context) (domain.Webhooks, error) {
+	webhooks, err := m.find(ctx, bson.D{{Key: "disabled", Value: false}})
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrActive, err)
+	}
+
+	return webhooks, nil
+}
+
+func (m *mongoRepository) Succeeded(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("%w:%v", ErrSucceeded, err)
+	}
+
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "consecutiveFailures", Value: 0}}}}
+	if _, err = m.webhooksCollection().UpdateOne(ctx, bson.M{"_id": objectID}, update); err != nil {
+		return fmt.Errorf("%w:%v", ErrSucceeded, err)
+	}
+
+	return nil
+}
+
+// Failed counts the failure and disables the webhook when it reaches disableAfter. Only the
+// update that disables it reports so, when replicas count failures concurrently.
+func (m *mongoRepository) Failed(ctx context.Context, id string, disableAfter int) (bool, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, fmt.Errorf("%w:%v", ErrFailed, err)
+	}
+
+	update := bson.D{{Key: "$inc", Value: bson.D{{Key: "consecutiveFailures", Value: 1}}}}
+	if _, err = m.webhooksCollection().UpdateOne(ctx, bson.M{"_id": objectID}, update); err != nil {
+		return false, fmt.Errorf("%w:%v", ErrFailed, err)
+	}
+
+	filter := bson.D{
+		{Key: "_id", Value: objectID},
+		{Key: "disabled", Value: false},
+		{Key: "consecutiveFailures", Value: bson.D{{Key: "$gte", Value: disableAfter}}},
+	}
+	disable := bson.D{{Key: "$set", Value: bson.D{{Key: "disabled", Value: true}, {Key: "disabledAt", Value: time.Now()}}}}
+
+	res, err := m.webhooksCollection().UpdateOne(ctx, filter, disable)
+	if err != nil {
+		return false, fmt.Errorf("%w:%v", ErrFailed, err)
+	}
+
+	return res.ModifiedCount > 0, nil
+}
+
+func (m *mongoRepository) CreateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	res, err := m.deliveriesCollection().InsertOne(ctx, delivery)
+	if mongo.IsDuplicateKeyError(err) {
+		return fmt.Errorf("%w:%v", domain.ErrDeliveryExists, err)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w:%v", ErrCreateDelivery, err)
+	}
+
+	if id, ok := res.InsertedID.(primitive.ObjectID); ok {
+		delivery.ID = id.Hex()
+	}
+
+	return nil
+}
+
+func (m *mongoRepository) UpdateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	objectID, err := primitive.ObjectIDFromHex(delivery.ID)
+	if err != nil {
+		return fmt.Errorf("%w:%v", ErrUpdateDelivery, err)
+	}
+
+	set := bson.D{
+		{Key: "status", Value: delivery.Status},
+		{Key: "attempts", Value: delivery.Attempts},
+	}
+
+	update := bson.D{{Key: "$unset", Value: bson.D{{Key: "nextAttempt", Value: ""}}}}
+	if delivery.NextAttempt != nil {
+		set = append(set, bson.E{Key: "nextAttempt", Value: delivery.NextAttempt})
+		update = bson.D{}
+	}
+
+	update = append(update, bson.E{Key: "$set", Value: set})
+
+	if _, err = m.deliveriesCollection().UpdateOne(ctx, bson.M{"_id": objectID}, update); err != nil {
+		return fmt.Errorf("%w:%v", ErrUpdateDelivery, err)
+	}
+
+	return nil
+}
+
+// ClaimDue claims the delivery by moving its next attempt to the end of the lease. Pending
+// deliveries without a next attempt, logged before they were scheduled, are due.
+func (m *mongoRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (*domain.WebhookDelivery, error) {
+	filter := bson.D{
+		{Key: "status", Value: domain.DeliveryPending},
+		{Key: "$or", Value: bson.A{
+			bson.D{{Key: "nextAttempt", Value: bson.D{{Key: "$lte", Value: now}}}},
+			bson.D{{Key: "nextAttempt", Value: nil}},
+		}},
+	}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "nextAttempt", Value: now.Add(lease)}}}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "nextAttempt", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	delivery := &domain.WebhookDelivery{}
+
+	err := m.deliveriesCollection().FindOneAndUpdate(ctx, filter, update, opts).Decode(delivery)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrClaimDue, err)
+	}
+
+	return delivery, nil
+}
+
+func (m *mongoRepository) Deliveries(ctx context.Context, id string, limit int) (domain.WebhookDeliveries, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created", Value: -1}}).SetLimit(int64(limit))
+
+	cursor, err := m.deliveriesCollection().Find(ctx, bson.M{"webhookId": id}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrDeliveries, err)
+	}
+
+	deliveries := make(domain.WebhookDeliveries, 0)
+	if err = cursor.All(ctx, &deliveries); err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrDeliveries, err)
+	}
+
+	return deliveries, nil
+}
+
+// ownedBy restricts the filter to the webhooks of the owner, unless the owner is empty.
+func ownedBy(owner string, filter bson.D) bson.D {
+	if owner == "" {
+		return filter
+	}
+
+	return append(filter, bson.E{Key: "owner", Value: owner})
+}
+
+func (m *mongoRepository) find(ctx context.Context, filter bson.D) (domain.Webhooks, error) {
+	cursor, err := m.webhooksCollection().Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "created", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks := make(domain.Webhooks, 0)
+	if err = cursor.All(ctx, &webhooks); err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func (m *mongoRepository) webhooksCollection() *mongo.Collection {
+	return m.client.Database(sportsNewsDB).Collection(webhooksCollection)
+}
+
+func (m *mongoRepository) deliveriesCollection() *mongo.Collection {
+	return m.client.Database(sportsNewsDB).Collection(deliveriesCollection)
+}
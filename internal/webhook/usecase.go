@@ -0,0 +1,18 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/KarolosLykos/sportsnews/domain"
+)
+
+// UseCase manages the webhooks of their owners. The webhooks of every owner are managed when the
+// owner is empty.
+type UseCase interface {
+	Create(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error)
+	GetByID(ctx context.Context, owner, id string) (*domain.Webhook, error)
+	List(ctx context.Context, owner string) (domain.Webhooks, error)
+	Delete(ctx context.Context, owner, id string) error
+	Enable(ctx context.Context, owner, id string) (*domain.Webhook, error)
+	Deliveries(ctx context.Context, owner, id string, limit int) (domain.WebhookDeliveries, error)
+}
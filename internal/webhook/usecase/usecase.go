@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/KarolosLykos/sportsnews/domain"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+	"github.com/KarolosLykos/sportsnews/internal/webhook"
+)
+
+// secretPrefix marks the secrets of webhooks, so that they are recognised when leaked.
+const secretPrefix = "whsec_"
+
+var (
+	ErrCreate     = errors.New("usecase: create")
+	ErrGetByID    = errors.New("usecase: getByID")
+	ErrList       = errors.New("usecase: list")
+	ErrDelete     = errors.New("usecase: delete")
+	ErrEnable     = errors.New("usecase: enable")
+	ErrDeliveries = errors.New("usecase: deliveries")
+)
+
+type webhookUseCase struct {
+	logger     logger.Logger
+	repository webhook.Repository
+}
+
+func New(logger logger.Logger, repository webhook.Repository) *webhookUseCase {
+	return &webhookUseCase{
+		logger:     logger,
+		repository: repository,
+	}
+}
+
+// Create stores the webhook of its owner, enabled, with a new secret. The webhook is returned with its secret.
+func (u *webhookUseCase) Create(ctx context.Context, w *domain.Webhook) (*domain.Webhook, error) {
+	secret, err := newSecret()
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrCreate, err)
+	}
+
+	hook := &domain.Webhook{
+		Owner:   w.Owner,
+		URL:     w.URL,
+		Events:  w.Events,
+		TeamID:  w.TeamID,
+		Secret:  secret,
+		Created: time.Now().UTC(),
+	}
+
+	if hook.Events == nil {
+		hook.Events = make([]domain.EventType, 0)
+	}
+
+	created, err := u.repository.Create(ctx, hook)
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrCreate, err)
+	}
+
+	return created, nil
+}
+
+// GetByID returns the webhook without its secret.
+func (u *webhookUseCase) GetByID(ctx context.Context, owner, id string) (*domain.Webhook, error) {
+	w, err := u.repository.GetByID(ctx, owner, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrGetByID, err)
+	}
+
+	return w.Redacted(), nil
+}
+
+func (u *webhookUseCase) List(ctx context.Context, owner string) (domain.Webhooks, error) {
+	webhooks, err := u.repository.List(ctx, owner)
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrList, err)
+	}
+
+	return webhooks, nil
+}
+
+func (u *webhookUseCase) Delete(ctx context.Context, owner, id string) error {
+	if err := u.repository.Delete(ctx, owner, id); err != nil {
+		return fmt.Errorf("%w:%v", ErrDelete, err)
+	}
+
+	return nil
+}
+
+// Enable enables a disabled webhook again. It is returned without its secret.
+func (u *webhookUseCase) Enable(ctx context.Context, owner, id string) (*domain.Webhook, error) {
+	w, err := u.repository.Enable(ctx, owner, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrEnable, err)
+	}
+
+	return w.Redacted(), nil
+}
+
+// Deliveries returns the latest deliveries of the webhook, which must exist and belong to the owner.
+func (u *webhookUseCase) Deliveries(ctx context.Context, owner, id string, limit int) (domain.WebhookDeliveries, error) {
+	if _, err := u.repository.GetByID(ctx, owner, id); err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrDeliveries, err)
+	}
+
+	deliveries, err := u.repository.Deliveries(ctx, id, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrDeliveries, err)
+	}
+
+	return deliveries, nil
+}
+
+// newSecret returns a random secret to sign deliveries with.
+func newSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return secretPrefix + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/sportsnews/config"
+	"github.com/KarolosLykos/sportsnews/domain"
+	"github.com/KarolosLykos/sportsnews/internal/utils/logger"
+	mock "github.com/KarolosLykos/sportsnews/internal/webhook/mock"
+)
+
+func TestWebhookUseCase_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockRepository(ctrl)
+	repo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, w *domain.Webhook) (*domain.Webhook, error) {
+			created := *w
+			created.ID = "6405f896a019b8815f6892c7"
+
+			return &created, nil
+		})
+
+	uc := New(getLogger(), repo)
+
+	w, err := uc.Create(context.Background(), &domain.Webhook{
+		Owner:               "k1",
+		URL:                 "https://partner.example.com/hooks",
+		TeamID:              "Hull City",
+		Secret:              "chosen by the client",
+		Disabled:            true,
+		ConsecutiveFailures: 3,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "6405f896a019b8815f6892c7", w.ID)
+	assert.Equal(t, "k1", w.Owner)
+	assert.Equal(t, "https://partner.example.com/hooks", w.URL)
+	assert.Equal(t, "Hull City", w.TeamID)
+	assert.Equal(t, []domain.EventType{}, w.Events)
+	assert.True(t, strings.HasPrefix(w.Secret, secretPrefix))
+	assert.Len(t, w.Secret, len(secretPrefix)+64)
+	assert.False(t, w.Disabled)
+	assert.Zero(t, w.ConsecutiveFailures)
+	assert.False(t, w.Created.IsZero())
+}
+
+func TestWebhookUseCase_GetByID(t *testing.T) {
+	tt := []struct {
+		name string
+		stub func(repo *mock.MockRepository)
+		err  error
+	}{
+		{
+			name: "ok",
+			stub: func(repo *mock.MockRepository) {
+				repo.EXPECT().GetByID(gomock.Any(), "k1", "1").Times(1).Return(&domain.Webhook{ID: "1", Secret: "whsec_1"}, nil)
+			},
+		},
+		{
+			name: "not found",
+			stub: func(repo *mock.MockRepository) {
+				repo.EXPECT().GetByID(gomock.Any(), "k1", "1").Times(1).Return(nil, errors.New("no documents in result"))
+			},
+			err: ErrGetByID,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockRepository(ctrl)
+			tc.stub(repo)
+
+			w, err := New(getLogger(), repo).GetByID(context.Background(), "k1", "1")
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "1", w.ID)
+			assert.Empty(t, w.Secret)
+		})
+	}
+}
+
+func TestWebhookUseCase_Deliveries(t *testing.T) {
+	tt := []struct {
+		name string
+		stub func(repo *mock.MockRepository)
+		err  error
+	}{
+		{
+			name: "ok",
+			stub: func(repo *mock.MockRepository) {
+				repo.EXPECT().GetByID(gomock.Any(), "k1", "1").Times(1).Return(&domain.Webhook{ID: "1"}, nil)
+				repo.EXPECT().Deliveries(gomock.Any(), "1", 20).Times(1).
+					Return(domain.WebhookDeliveries{{ID: "d1", WebhookID: "1"}}, nil)
+			},
+		},
+		{
+			name: "webhook of another owner",
+			stub: func(repo *mock.MockRepository) {
+				repo.EXPECT().GetByID(gomock.Any(), "k1", "1").Times(1).Return(nil, errors.New("no documents in result"))
+				repo.EXPECT().Deliveries(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			err: ErrDeliveries,
+		},
+		{
+			name: "repository error",
+			stub: func(repo *mock.MockRepository) {
+				repo.EXPECT().GetByID(gomock.Any(), "k1", "1").Times(1).Return(&domain.Webhook{ID: "1"}, nil)
+				repo.EXPECT().Deliveries(gomock.Any(), "1", 20).Times(1).Return(nil, errors.New("something went wrong"))
+			},
+			err: ErrDeliveries,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockRepository(ctrl)
+			tc.stub(repo)
+
+			deliveries, err := New(getLogger(), repo).Deliveries(context.Background(), "k1", "1", 20)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Len(t, deliveries, 1)
+		})
+	}
+}
+
+func getLogger() logger.Logger {
+	cfg := &config.Config{}
+
+	l := &logrus.Logger{
+		Out:          io.Discard,
+		Hooks:        make(logrus.LevelHooks),
+		ReportCaller: false,
+		ExitFunc:     os.Exit,
+		Level:        logrus.InfoLevel,
+		Formatter:    &logrus.JSONFormatter{},
+	}
+
+	return logger.New(cfg, l)
+}